@@ -27,10 +27,18 @@ type add_preset_shape_layer struct {
 	server.ToolApp
 	*MCPApp
 }
+type add_shape_operators struct {
+	server.ToolApp
+	*MCPApp
+}
 type add_solid_layer struct {
 	server.ToolApp
 	*MCPApp
 }
+type add_text_animator struct {
+	server.ToolApp
+	*MCPApp
+}
 type add_text_layer struct {
 	server.ToolApp
 	*MCPApp
@@ -39,10 +47,66 @@ type apply_effect struct {
 	server.ToolApp
 	*MCPApp
 }
+type apply_preset struct {
+	server.ToolApp
+	*MCPApp
+}
+type apply_recipe struct {
+	server.ToolApp
+	*MCPApp
+}
+type apply_text_animator_preset struct {
+	server.ToolApp
+	*MCPApp
+}
+type camera_crane struct {
+	server.ToolApp
+	*MCPApp
+}
+type camera_dolly struct {
+	server.ToolApp
+	*MCPApp
+}
+type camera_orbit struct {
+	server.ToolApp
+	*MCPApp
+}
+type camera_push_in struct {
+	server.ToolApp
+	*MCPApp
+}
+type camera_shake struct {
+	server.ToolApp
+	*MCPApp
+}
+type camera_truck struct {
+	server.ToolApp
+	*MCPApp
+}
 type create_composition struct {
 	server.ToolApp
 	*MCPApp
 }
+type create_folder struct {
+	server.ToolApp
+	*MCPApp
+}
+type describe_effect struct {
+	server.ToolApp
+	*MCPApp
+}
+type duplicate_composition struct {
+	server.ToolApp
+	*MCPApp
+}
+type duplicate_effect struct {
+	server.ToolApp
+	*MCPApp
+}
+type effect_render_report struct {
+	server.ToolApp
+	*MCPApp
+}
 type get_effect_categories struct {
 	server.ToolApp
 	*MCPApp
@@ -51,25 +115,137 @@ type get_effects_by_category struct {
 	server.ToolApp
 	*MCPApp
 }
+type get_layer_bounds struct {
+	server.ToolApp
+	*MCPApp
+}
+type get_light_layer_info struct {
+	server.ToolApp
+	*MCPApp
+}
+type get_project_item_tree struct {
+	server.ToolApp
+	*MCPApp
+}
+type get_shape_layer_tree struct {
+	server.ToolApp
+	*MCPApp
+}
+type import_svg_shape_layer struct {
+	server.ToolApp
+	*MCPApp
+}
+type list_fonts struct {
+	server.ToolApp
+	*MCPApp
+}
+type list_layer_effects struct {
+	server.ToolApp
+	*MCPApp
+}
+type list_project_items struct {
+	server.ToolApp
+	*MCPApp
+}
+type list_recipes struct {
+	server.ToolApp
+	*MCPApp
+}
 type MCPApp struct {
 	server.MCPApp
 }
+type modify_composition struct {
+	server.ToolApp
+	*MCPApp
+}
+type modify_effect struct {
+	server.ToolApp
+	*MCPApp
+}
 type modify_layer struct {
 	server.ToolApp
 	*MCPApp
 }
+type modify_light struct {
+	server.ToolApp
+	*MCPApp
+}
 type modify_text struct {
 	server.ToolApp
 	*MCPApp
 }
+type morph_shape struct {
+	server.ToolApp
+	*MCPApp
+}
+type move_effect struct {
+	server.ToolApp
+	*MCPApp
+}
+type move_project_items struct {
+	server.ToolApp
+	*MCPApp
+}
+type offset_shape_path struct {
+	server.ToolApp
+	*MCPApp
+}
 type project struct {
 	server.ToolApp
 	*MCPApp
 }
+type refresh_effect_catalog struct {
+	server.ToolApp
+	*MCPApp
+}
+type remove_effect struct {
+	server.ToolApp
+	*MCPApp
+}
+type remove_unused_items struct {
+	server.ToolApp
+	*MCPApp
+}
+type rename_project_item struct {
+	server.ToolApp
+	*MCPApp
+}
+type save_recipe struct {
+	server.ToolApp
+	*MCPApp
+}
 type script struct {
 	server.ToolApp
 	*MCPApp
 }
+type search_effects struct {
+	server.ToolApp
+	*MCPApp
+}
+type search_presets struct {
+	server.ToolApp
+	*MCPApp
+}
+type set_layer_extrusion struct {
+	server.ToolApp
+	*MCPApp
+}
+type set_layer_material struct {
+	server.ToolApp
+	*MCPApp
+}
+type set_text_path struct {
+	server.ToolApp
+	*MCPApp
+}
+type shape_boolean struct {
+	server.ToolApp
+	*MCPApp
+}
+type trim_comp_to_work_area struct {
+	server.ToolApp
+	*MCPApp
+}
 //line cmd/ae-mcp/main_mcp.gox:1
 // main_mcp.gox - Main MCP server definition
 func (this *MCPApp) MainEntry() {
@@ -77,7 +253,7 @@ func (this *MCPApp) MainEntry() {
 	this.Server("After Effects MCP Tool Suite 🚀", "1.0.0")
 }
 func (this *MCPApp) Main() {
-	server.Gopt_MCPApp_Main(this, nil, []server.ToolProto{new(add_camera_layer), new(add_custom_shape_layer), new(add_light_layer), new(add_preset_shape_layer), new(add_solid_layer), new(add_text_layer), new(apply_effect), new(create_composition), new(get_effect_categories), new(get_effects_by_category), new(modify_layer), new(modify_text), new(project), new(script)}, nil)
+	server.Gopt_MCPApp_Main(this, nil, []server.ToolProto{new(add_camera_layer), new(add_custom_shape_layer), new(add_light_layer), new(add_preset_shape_layer), new(add_shape_operators), new(add_solid_layer), new(add_text_animator), new(add_text_layer), new(apply_effect), new(apply_preset), new(apply_recipe), new(apply_text_animator_preset), new(camera_crane), new(camera_dolly), new(camera_orbit), new(camera_push_in), new(camera_shake), new(camera_truck), new(create_composition), new(create_folder), new(describe_effect), new(duplicate_composition), new(duplicate_effect), new(effect_render_report), new(get_effect_categories), new(get_effects_by_category), new(get_layer_bounds), new(get_light_layer_info), new(get_project_item_tree), new(get_shape_layer_tree), new(import_svg_shape_layer), new(list_fonts), new(list_layer_effects), new(list_project_items), new(list_recipes), new(modify_composition), new(modify_effect), new(modify_layer), new(modify_light), new(modify_text), new(morph_shape), new(move_effect), new(move_project_items), new(offset_shape_path), new(project), new(refresh_effect_catalog), new(remove_effect), new(remove_unused_items), new(rename_project_item), new(save_recipe), new(script), new(search_effects), new(search_presets), new(set_layer_extrusion), new(set_layer_material), new(set_text_path), new(shape_boolean), new(trim_comp_to_work_area)}, nil)
 }
 //line cmd/ae-mcp/add_camera_layer_tool.gox:6
 // Tool for adding camera layers
@@ -163,65 +339,98 @@ func (this *add_custom_shape_layer) Main(_gop_arg0 context.Context, _gop_arg1 mc
 //line cmd/ae-mcp/add_custom_shape_layer_tool.gox:17:1
 		this.Array("vertices", func() {
 //line cmd/ae-mcp/add_custom_shape_layer_tool.gox:18:1
-			this.Description("Array of points defining the shape's vertices as [[x1,y1], [x2,y2], ...]")
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:19:1
-			this.Required()
+			this.Description("Array of points defining the shape's vertices as [[x1,y1], [x2,y2], ...]; optional when groups are given")
 		})
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:21:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:20:1
 		this.Bool("closed", func() {
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:22:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:21:1
 			this.Description("Whether the shape is closed (connects first and last vertices)")
 		})
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:24:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:23:1
 		this.Array("in_tangents", func() {
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:25:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:24:1
 			this.Description("Array of incoming tangent points as [[x1,y1], [x2,y2], ...] (must match vertices length)")
 		})
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:27:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:26:1
 		this.Array("out_tangents", func() {
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:28:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:27:1
 			this.Description("Array of outgoing tangent points as [[x1,y1], [x2,y2], ...] (must match vertices length)")
 		})
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:30:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:29:1
 		this.Array("feather_radii", func() {
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:31:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:30:1
 			this.Description("Array of feather point radii (optional, for mask feathering)")
 		})
-	})
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:32:1
+		this.Bool("smooth", func() {
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:33:1
+			this.Description("Fit smooth Bezier curves through the vertices (for plotted, traced or hand-drawn polylines); tangents are computed automatically")
+		})
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:35:1
+		this.Float("smooth_tolerance", func() {
 //line cmd/ae-mcp/add_custom_shape_layer_tool.gox:36:1
+			this.Description("Maximum distance in pixels between a vertex and the fitted curve when smoothing (default: 1)")
+		})
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:38:1
+		this.Array("groups", func() {
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:39:1
+			this.Description("Nested shape groups built in composition coordinates, each {name, transform {anchorPoint, position, scale, rotation, opacity, skew, skewAxis}, contents}; contents are ordered top to bottom and each item is {path {vertices, inTangents, outTangents, closed, smooth}, name}, {group {...}} or {operator {...}} using the ae_add_shape_operators operator format")
+		})
+	})
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:44:1
 	compName := this.Gop_Env("composition_name").(string)
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:37:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:45:1
 	layerName := this.Gop_Env("layer_name").(string)
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:40:1
-	args := map[string]interface{}{"composition_name": compName, "layer_name": layerName, "vertices": this.Gop_Env("vertices").([]interface{})}
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:47:1
-	if this.Gop_Env("closed") != nil {
 //line cmd/ae-mcp/add_custom_shape_layer_tool.gox:48:1
+	args := map[string]interface{}{"composition_name": compName, "layer_name": layerName}
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:54:1
+	if this.Gop_Env("vertices") != nil {
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:55:1
+		args["vertices"] = this.Gop_Env("vertices").([]interface{})
+	}
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:58:1
+	if this.Gop_Env("closed") != nil {
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:59:1
 		args["closed"] = this.Gop_Env("closed").(bool)
 	}
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:51:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:62:1
 	if this.Gop_Env("in_tangents") != nil {
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:52:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:63:1
 		args["in_tangents"] = this.Gop_Env("in_tangents").([]interface{})
 	}
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:55:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:66:1
 	if this.Gop_Env("out_tangents") != nil {
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:56:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:67:1
 		args["out_tangents"] = this.Gop_Env("out_tangents").([]interface{})
 	}
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:59:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:70:1
 	if this.Gop_Env("feather_radii") != nil {
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:60:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:71:1
 		args["feather_radii"] = this.Gop_Env("feather_radii").([]interface{})
 	}
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:64:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:74:1
+	if this.Gop_Env("smooth") != nil {
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:75:1
+		args["smooth"] = this.Gop_Env("smooth").(bool)
+	}
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:78:1
+	if this.Gop_Env("smooth_tolerance") != nil {
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:79:1
+		args["smooth_tolerance"] = this.Gop_Env("smooth_tolerance").(float64)
+	}
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:82:1
+	if this.Gop_Env("groups") != nil {
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:83:1
+		args["groups"] = this.Gop_Env("groups").([]interface{})
+	}
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:87:1
 	result, err := tools.MCPAddCustomShapeLayer(args)
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:65:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:88:1
 	if err != nil {
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:66:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:89:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:70:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:93:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
 func (this *add_custom_shape_layer) Classclone() server.ToolProto {
@@ -231,7 +440,7 @@ func (this *add_custom_shape_layer) Classclone() server.ToolProto {
 //line cmd/ae-mcp/add_light_layer_tool.gox:6
 // Tool for adding light layers
 func (this *add_light_layer) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
-//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:70:1
+//line cmd/ae-mcp/add_custom_shape_layer_tool.gox:93:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
 //line cmd/ae-mcp/add_light_layer_tool.gox:7:1
 	this.Tool("ae_add_light_layer", func() {
@@ -320,7 +529,7 @@ func (this *add_preset_shape_layer) Main(_gop_arg0 context.Context, _gop_arg1 mc
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:7:1
 	this.Tool("mcp_aftereffects_ae_add_preset_shape_layer", func() {
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:8:1
-		this.Description("Add a preset shape layer (rectangle, ellipse, polygon, star) to a composition")
+		this.Description("Add a preset shape layer to a composition: rectangle, ellipse, polygon, star, or a generated ngon, rounded_rectangle, arrow, arc, donut, spiral, gear, speech_bubble, callout or checkmark")
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:9:1
 		this.String("composition_name", func() {
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:10:1
@@ -338,7 +547,7 @@ func (this *add_preset_shape_layer) Main(_gop_arg0 context.Context, _gop_arg1 mc
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:17:1
 		this.String("shape_type", func() {
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:18:1
-			this.Description("Type of shape to create (rectangle, ellipse, polygon, star)")
+			this.Description("Type of shape to create (rectangle, ellipse, polygon, star, ngon, rounded_rectangle, arrow, arc, donut, spiral, gear, speech_bubble, callout, checkmark)")
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:19:1
 			this.Required()
 		})
@@ -352,43 +561,264 @@ func (this *add_preset_shape_layer) Main(_gop_arg0 context.Context, _gop_arg1 mc
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:25:1
 			this.Description("Height of the shape in pixels (default: 100)")
 		})
-	})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:27:1
+		this.Float("sides", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:28:1
+			this.Description("Number of sides for polygon/ngon (default: 6)")
+		})
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:30:1
-	compName := this.Gop_Env("composition_name").(string)
+		this.Float("points", func() {
 //line cmd/ae-mcp/add_preset_shape_layer_tool.gox:31:1
+			this.Description("Number of points for star (default: 5)")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:33:1
+		this.Float("corner_radius", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:34:1
+			this.Description("Corner rounding in pixels for ngon, star, rounded_rectangle, speech_bubble and callout")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:36:1
+		this.Array("corner_radii", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:37:1
+			this.Description("Per-corner radii [topLeft, topRight, bottomRight, bottomLeft] for rounded_rectangle")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:39:1
+		this.Float("inner_radius", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:40:1
+			this.Description("Inner radius as a ratio of the outer radius for star, arc, donut, spiral start and gear hole")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:42:1
+		this.Float("start_angle", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:43:1
+			this.Description("Arc start angle in degrees, clockwise from the positive x axis (default: 0)")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:45:1
+		this.Float("end_angle", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:46:1
+			this.Description("Arc end angle in degrees (default: 270)")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:48:1
+		this.Float("turns", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:49:1
+			this.Description("Number of spiral turns (default: 3)")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:51:1
+		this.Float("teeth", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:52:1
+			this.Description("Number of gear teeth (default: 12)")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:54:1
+		this.Float("tooth_depth", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:55:1
+			this.Description("Gear tooth depth as a ratio of the radius (default: 0.2)")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:57:1
+		this.Float("thickness", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:58:1
+			this.Description("Arrow shaft or checkmark thickness as a ratio of the height")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:60:1
+		this.Float("head_length", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:61:1
+			this.Description("Arrow head length as a ratio of the width (default: 0.4)")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:63:1
+		this.Array("tail", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:64:1
+			this.Description("Speech bubble or callout tail tip [x, y] relative to the shape center")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:66:1
+		this.Float("tail_width", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:67:1
+			this.Description("Width of the speech bubble or callout tail where it meets the body")
+		})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:69:1
+		this.Float("rotation", func() {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:70:1
+			this.Description("Rotation of the generated shape in degrees")
+		})
+	})
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:75:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:76:1
 	layerName := this.Gop_Env("layer_name").(string)
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:32:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:77:1
 	shapeType := this.Gop_Env("shape_type").(string)
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:35:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:80:1
 	args := map[string]interface{}{"composition_name": compName, "layer_name": layerName, "shape_type": shapeType}
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:42:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:87:1
 	if this.Gop_Env("width") != nil {
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:43:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:88:1
 		args["width"] = this.Gop_Env("width").(float64)
 	}
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:46:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:91:1
 	if this.Gop_Env("height") != nil {
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:47:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:92:1
 		args["height"] = this.Gop_Env("height").(float64)
 	}
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:51:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:96:1
+	if this.Gop_Env("sides") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:97:1
+		args["sides"] = this.Gop_Env("sides").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:100:1
+	if this.Gop_Env("points") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:101:1
+		args["points"] = this.Gop_Env("points").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:104:1
+	if this.Gop_Env("corner_radius") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:105:1
+		args["corner_radius"] = this.Gop_Env("corner_radius").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:108:1
+	if this.Gop_Env("corner_radii") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:109:1
+		args["corner_radii"] = this.Gop_Env("corner_radii")
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:112:1
+	if this.Gop_Env("inner_radius") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:113:1
+		args["inner_radius"] = this.Gop_Env("inner_radius").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:116:1
+	if this.Gop_Env("start_angle") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:117:1
+		args["start_angle"] = this.Gop_Env("start_angle").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:120:1
+	if this.Gop_Env("end_angle") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:121:1
+		args["end_angle"] = this.Gop_Env("end_angle").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:124:1
+	if this.Gop_Env("turns") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:125:1
+		args["turns"] = this.Gop_Env("turns").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:128:1
+	if this.Gop_Env("teeth") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:129:1
+		args["teeth"] = this.Gop_Env("teeth").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:132:1
+	if this.Gop_Env("tooth_depth") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:133:1
+		args["tooth_depth"] = this.Gop_Env("tooth_depth").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:136:1
+	if this.Gop_Env("thickness") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:137:1
+		args["thickness"] = this.Gop_Env("thickness").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:140:1
+	if this.Gop_Env("head_length") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:141:1
+		args["head_length"] = this.Gop_Env("head_length").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:144:1
+	if this.Gop_Env("tail") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:145:1
+		args["tail"] = this.Gop_Env("tail")
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:148:1
+	if this.Gop_Env("tail_width") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:149:1
+		args["tail_width"] = this.Gop_Env("tail_width").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:152:1
+	if this.Gop_Env("rotation") != nil {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:153:1
+		args["rotation"] = this.Gop_Env("rotation").(float64)
+	}
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:157:1
 	result, err := tools.MCPAddPresetShapeLayer(args)
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:52:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:158:1
 	if err != nil {
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:53:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:159:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:57:1
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:163:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
 func (this *add_preset_shape_layer) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
+//line cmd/ae-mcp/add_shape_operators_tool.gox:6
+// Tool for adding shape operators
+func (this *add_shape_operators) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:163:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/add_shape_operators_tool.gox:7:1
+	this.Tool("ae_add_shape_operators", func() {
+//line cmd/ae-mcp/add_shape_operators_tool.gox:8:1
+		this.Description("Add path operators (Trim Paths, Repeater, Offset Paths, Round Corners, Zig Zag, Twist, Wiggle Paths) and fills or strokes (solid or gradient, with dashes, caps, joins and taper) to a shape layer or one of its groups, in order")
+//line cmd/ae-mcp/add_shape_operators_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/add_shape_operators_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/add_shape_operators_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/add_shape_operators_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/add_shape_operators_tool.gox:14:1
+			this.Description("Name of the shape layer")
+//line cmd/ae-mcp/add_shape_operators_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/add_shape_operators_tool.gox:17:1
+		this.String("group_name", func() {
+//line cmd/ae-mcp/add_shape_operators_tool.gox:18:1
+			this.Description("Name of the shape group to add to (searched depth-first); the layer's root contents when omitted")
+		})
+//line cmd/ae-mcp/add_shape_operators_tool.gox:20:1
+		this.Array("operators", func() {
+//line cmd/ae-mcp/add_shape_operators_tool.gox:21:1
+			this.Description("Operators to add, each an object with a type (trimPaths, repeater, offsetPaths, roundCorners, zigZag, twist, wigglePaths, fill, stroke, gradientFill, gradientStroke) and its settings: start/end/offset/trimMultiple; copies/offset/composite/anchorPoint/position/scale/rotation/startOpacity/endOpacity; amount/lineJoin/miterLimit/copies/copyOffset; radius; size/detail/pointType; wigglesPerSecond/correlation/temporalPhase/spatialPhase/randomSeed; angle/center; color/opacity/fillRule; width/lineCap/lineJoin/miterLimit/dashes/dashOffset/taper {startLength, endLength, startWidth, endWidth, startEase, endEase}; gradientType/startPoint/endPoint/highlightLength/highlightAngle/stops [{position 0-1, color [r,g,b], opacity 0-100}]")
+//line cmd/ae-mcp/add_shape_operators_tool.gox:22:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/add_shape_operators_tool.gox:27:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/add_shape_operators_tool.gox:28:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/add_shape_operators_tool.gox:30:1
+	groupName := ""
+//line cmd/ae-mcp/add_shape_operators_tool.gox:31:1
+	if this.Gop_Env("group_name") != nil {
+//line cmd/ae-mcp/add_shape_operators_tool.gox:32:1
+		groupName = this.Gop_Env("group_name").(string)
+	}
+//line cmd/ae-mcp/add_shape_operators_tool.gox:35:1
+	operators, err := tools.ParseShapeOperators(this.Gop_Env("operators").([]interface{}))
+//line cmd/ae-mcp/add_shape_operators_tool.gox:36:1
+	if err != nil {
+//line cmd/ae-mcp/add_shape_operators_tool.gox:37:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/add_shape_operators_tool.gox:42:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/add_shape_operators_tool.gox:44:1
+	result, err = tools.AddShapeOperators(compName, layerName, groupName, operators)
+//line cmd/ae-mcp/add_shape_operators_tool.gox:45:1
+	if err != nil {
+//line cmd/ae-mcp/add_shape_operators_tool.gox:46:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/add_shape_operators_tool.gox:50:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *add_shape_operators) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
 //line cmd/ae-mcp/add_solid_layer_tool.gox:6
 // Tool for adding solid color layers
 func (this *add_solid_layer) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
-//line cmd/ae-mcp/add_preset_shape_layer_tool.gox:57:1
+//line cmd/ae-mcp/add_shape_operators_tool.gox:50:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
 //line cmd/ae-mcp/add_solid_layer_tool.gox:7:1
 	this.Tool("ae_add_solid_layer", func() {
@@ -493,40 +923,107 @@ func (this *add_solid_layer) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
-//line cmd/ae-mcp/add_text_layer_tool.gox:6
-// Tool for adding text layers
-func (this *add_text_layer) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/add_text_animator_tool.gox:6
+// Tool for adding a text animator to a text layer
+func (this *add_text_animator) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
 //line cmd/ae-mcp/add_solid_layer_tool.gox:75:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
-//line cmd/ae-mcp/add_text_layer_tool.gox:7:1
-	this.Tool("ae_add_text_layer", func() {
-//line cmd/ae-mcp/add_text_layer_tool.gox:8:1
-		this.Description("Add a text layer to a composition")
-//line cmd/ae-mcp/add_text_layer_tool.gox:9:1
+//line cmd/ae-mcp/add_text_animator_tool.gox:7:1
+	this.Tool("ae_add_text_animator", func() {
+//line cmd/ae-mcp/add_text_animator_tool.gox:8:1
+		this.Description("Add a text animator with a range selector to a text layer, optionally keyframing the selector")
+//line cmd/ae-mcp/add_text_animator_tool.gox:9:1
 		this.String("composition_name", func() {
-//line cmd/ae-mcp/add_text_layer_tool.gox:10:1
-			this.Description("Name of the composition to add the layer to")
-//line cmd/ae-mcp/add_text_layer_tool.gox:11:1
+//line cmd/ae-mcp/add_text_animator_tool.gox:10:1
+			this.Description("Name of the composition containing the text layer")
+//line cmd/ae-mcp/add_text_animator_tool.gox:11:1
 			this.Required()
 		})
-//line cmd/ae-mcp/add_text_layer_tool.gox:13:1
+//line cmd/ae-mcp/add_text_animator_tool.gox:13:1
 		this.String("layer_name", func() {
-//line cmd/ae-mcp/add_text_layer_tool.gox:14:1
-			this.Description("Name of the new text layer")
-//line cmd/ae-mcp/add_text_layer_tool.gox:15:1
+//line cmd/ae-mcp/add_text_animator_tool.gox:14:1
+			this.Description("Name of the text layer")
+//line cmd/ae-mcp/add_text_animator_tool.gox:15:1
 			this.Required()
 		})
-//line cmd/ae-mcp/add_text_layer_tool.gox:17:1
-		this.String("text", func() {
-//line cmd/ae-mcp/add_text_layer_tool.gox:18:1
-			this.Description("Text content")
-//line cmd/ae-mcp/add_text_layer_tool.gox:19:1
+//line cmd/ae-mcp/add_text_animator_tool.gox:17:1
+		this.String("animator_name", func() {
+//line cmd/ae-mcp/add_text_animator_tool.gox:18:1
+			this.Description("Name of the new animator (default: Animator)")
+		})
+//line cmd/ae-mcp/add_text_animator_tool.gox:20:1
+		this.Object("properties", func() {
+//line cmd/ae-mcp/add_text_animator_tool.gox:21:1
+			this.Description("Animator properties and their values: position [x,y,z], anchorPoint, scale [x,y], rotation, skew, opacity, tracking, fillColor [r,g,b], strokeColor, strokeWidth, blur [x,y], characterOffset")
+//line cmd/ae-mcp/add_text_animator_tool.gox:22:1
+			this.Required()
+		})
+//line cmd/ae-mcp/add_text_animator_tool.gox:24:1
+		this.Object("selector", func() {
+//line cmd/ae-mcp/add_text_animator_tool.gox:25:1
+			this.Description("Range selector: start, end, offset, units (percentage, index), basedOn (characters, characters_excluding_spaces, words, lines), mode, amount, shape (square, ramp_up, ramp_down, triangle, round, smooth), smoothness, easeHigh, easeLow, randomize, randomSeed, keyframes [{property: start|end|offset, time (composition seconds), value, ease}]")
+		})
+	})
+//line cmd/ae-mcp/add_text_animator_tool.gox:30:1
+	args := map[string]interface{}{"composition_name": this.Gop_Env("composition_name").(string), "layer_name": this.Gop_Env("layer_name").(string), "properties": this.Gop_Env("properties").(map[string]interface{})}
+//line cmd/ae-mcp/add_text_animator_tool.gox:36:1
+	if this.Gop_Env("animator_name") != nil {
+//line cmd/ae-mcp/add_text_animator_tool.gox:37:1
+		args["animator_name"] = this.Gop_Env("animator_name").(string)
+	}
+//line cmd/ae-mcp/add_text_animator_tool.gox:40:1
+	if this.Gop_Env("selector") != nil {
+//line cmd/ae-mcp/add_text_animator_tool.gox:41:1
+		args["selector"] = this.Gop_Env("selector").(map[string]interface{})
+	}
+//line cmd/ae-mcp/add_text_animator_tool.gox:45:1
+	result, err := tools.MCPAddTextAnimator(args)
+//line cmd/ae-mcp/add_text_animator_tool.gox:46:1
+	if err != nil {
+//line cmd/ae-mcp/add_text_animator_tool.gox:47:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/add_text_animator_tool.gox:51:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *add_text_animator) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/add_text_layer_tool.gox:6
+// Tool for adding text layers
+func (this *add_text_layer) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/add_text_animator_tool.gox:51:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/add_text_layer_tool.gox:7:1
+	this.Tool("ae_add_text_layer", func() {
+//line cmd/ae-mcp/add_text_layer_tool.gox:8:1
+		this.Description("Add a text layer to a composition")
+//line cmd/ae-mcp/add_text_layer_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/add_text_layer_tool.gox:10:1
+			this.Description("Name of the composition to add the layer to")
+//line cmd/ae-mcp/add_text_layer_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/add_text_layer_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/add_text_layer_tool.gox:14:1
+			this.Description("Name of the new text layer")
+//line cmd/ae-mcp/add_text_layer_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/add_text_layer_tool.gox:17:1
+		this.String("text", func() {
+//line cmd/ae-mcp/add_text_layer_tool.gox:18:1
+			this.Description("Text content")
+//line cmd/ae-mcp/add_text_layer_tool.gox:19:1
 			this.Required()
 		})
 //line cmd/ae-mcp/add_text_layer_tool.gox:21:1
 		this.Object("options", func() {
 //line cmd/ae-mcp/add_text_layer_tool.gox:22:1
-			this.Description("Text options (fontSize, fontName, color, position, justification, etc.)")
+			this.Description("Text options (fontSize, fontName or fontFamily plus fontStyle, resolved to an installed font with typo correction, color, position, justification, boxSize/boxPosition for paragraph text, firstLineIndent, spaceBefore, spaceAfter, vertical, path {maskName, shape, firstMargin, lastMargin, perpendicularToPath, forceAlignment, reversePath}, autoFit {mode: shrink|wrap|wrapShrink, box: [left, top, width, height] (default title-safe), minFontSize, keepInside}, etc.)")
 		})
 	})
 //line cmd/ae-mcp/add_text_layer_tool.gox:27:1
@@ -565,140 +1062,225 @@ func (this *add_text_layer) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallTo
 //line cmd/ae-mcp/add_text_layer_tool.gox:49:1
 			textOptions.FontFamily = fontFamily
 		}
-//line cmd/ae-mcp/add_text_layer_tool.gox:53:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:51:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:51:1
+		fontStyle, ok := optionsMap["fontStyle"].(string); ok {
+//line cmd/ae-mcp/add_text_layer_tool.gox:52:1
+			textOptions.FontStyle = fontStyle
+		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:56:1
 		if
-//line cmd/ae-mcp/add_text_layer_tool.gox:53:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:56:1
 		color, ok := optionsMap["color"].([]interface{}); ok && len(color) >= 3 {
-//line cmd/ae-mcp/add_text_layer_tool.gox:54:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:57:1
 			r, _ := color[0].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:55:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:58:1
 			g, _ := color[1].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:56:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:59:1
 			b, _ := color[2].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:57:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:60:1
 			textOptions.Color = tools.ColorRGB{r, g, b}
 		}
-//line cmd/ae-mcp/add_text_layer_tool.gox:59:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:62:1
 		if
-//line cmd/ae-mcp/add_text_layer_tool.gox:59:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:62:1
 		fillColor, ok := optionsMap["fillColor"].([]interface{}); ok && len(fillColor) >= 3 {
-//line cmd/ae-mcp/add_text_layer_tool.gox:60:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:63:1
 			r, _ := fillColor[0].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:61:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:64:1
 			g, _ := fillColor[1].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:62:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:65:1
 			b, _ := fillColor[2].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:63:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:66:1
 			textOptions.FillColor = tools.ColorRGB{r, g, b}
 		}
-//line cmd/ae-mcp/add_text_layer_tool.gox:65:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:68:1
 		if
-//line cmd/ae-mcp/add_text_layer_tool.gox:65:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:68:1
 		strokeColor, ok := optionsMap["strokeColor"].([]interface{}); ok && len(strokeColor) >= 3 {
-//line cmd/ae-mcp/add_text_layer_tool.gox:66:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:69:1
 			r, _ := strokeColor[0].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:67:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:70:1
 			g, _ := strokeColor[1].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:68:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:71:1
 			b, _ := strokeColor[2].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:69:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:72:1
 			textOptions.StrokeColor = tools.ColorRGB{r, g, b}
 		}
-//line cmd/ae-mcp/add_text_layer_tool.gox:73:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:76:1
 		if
-//line cmd/ae-mcp/add_text_layer_tool.gox:73:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:76:1
 		position, ok := optionsMap["position"].([]interface{}); ok && len(position) >= 2 {
-//line cmd/ae-mcp/add_text_layer_tool.gox:74:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:77:1
 			x, _ := position[0].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:75:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:78:1
 			y, _ := position[1].(float64)
-//line cmd/ae-mcp/add_text_layer_tool.gox:76:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:79:1
 			textOptions.Position = [2]float64{x, y}
 		}
-//line cmd/ae-mcp/add_text_layer_tool.gox:80:1
-		if
-//line cmd/ae-mcp/add_text_layer_tool.gox:80:1
-		justification, ok := optionsMap["justification"].(string); ok {
-//line cmd/ae-mcp/add_text_layer_tool.gox:81:1
-			textOptions.Justification = justification
-		}
 //line cmd/ae-mcp/add_text_layer_tool.gox:83:1
 		if
 //line cmd/ae-mcp/add_text_layer_tool.gox:83:1
-		strokeWidth, ok := optionsMap["strokeWidth"].(float64); ok {
+		justification, ok := optionsMap["justification"].(string); ok {
 //line cmd/ae-mcp/add_text_layer_tool.gox:84:1
-			textOptions.StrokeWidth = strokeWidth
+			textOptions.Justification = justification
 		}
 //line cmd/ae-mcp/add_text_layer_tool.gox:86:1
 		if
 //line cmd/ae-mcp/add_text_layer_tool.gox:86:1
-		tracking, ok := optionsMap["tracking"].(float64); ok {
+		strokeWidth, ok := optionsMap["strokeWidth"].(float64); ok {
 //line cmd/ae-mcp/add_text_layer_tool.gox:87:1
-			textOptions.Tracking = tracking
+			textOptions.StrokeWidth = strokeWidth
 		}
 //line cmd/ae-mcp/add_text_layer_tool.gox:89:1
 		if
 //line cmd/ae-mcp/add_text_layer_tool.gox:89:1
-		leading, ok := optionsMap["leading"].(float64); ok {
+		tracking, ok := optionsMap["tracking"].(float64); ok {
 //line cmd/ae-mcp/add_text_layer_tool.gox:90:1
-			textOptions.Leading = leading
+			textOptions.Tracking = tracking
 		}
-//line cmd/ae-mcp/add_text_layer_tool.gox:94:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:92:1
 		if
-//line cmd/ae-mcp/add_text_layer_tool.gox:94:1
-		applyFill, ok := optionsMap["applyFill"].(bool); ok {
-//line cmd/ae-mcp/add_text_layer_tool.gox:95:1
-			textOptions.ApplyFill = &applyFill
+//line cmd/ae-mcp/add_text_layer_tool.gox:92:1
+		leading, ok := optionsMap["leading"].(float64); ok {
+//line cmd/ae-mcp/add_text_layer_tool.gox:93:1
+			textOptions.Leading = leading
 		}
 //line cmd/ae-mcp/add_text_layer_tool.gox:97:1
 		if
 //line cmd/ae-mcp/add_text_layer_tool.gox:97:1
-		applyStroke, ok := optionsMap["applyStroke"].(bool); ok {
+		applyFill, ok := optionsMap["applyFill"].(bool); ok {
 //line cmd/ae-mcp/add_text_layer_tool.gox:98:1
-			textOptions.ApplyStroke = &applyStroke
+			textOptions.ApplyFill = &applyFill
 		}
 //line cmd/ae-mcp/add_text_layer_tool.gox:100:1
 		if
 //line cmd/ae-mcp/add_text_layer_tool.gox:100:1
-		fauxBold, ok := optionsMap["fauxBold"].(bool); ok {
+		applyStroke, ok := optionsMap["applyStroke"].(bool); ok {
 //line cmd/ae-mcp/add_text_layer_tool.gox:101:1
-			textOptions.FauxBold = &fauxBold
+			textOptions.ApplyStroke = &applyStroke
 		}
 //line cmd/ae-mcp/add_text_layer_tool.gox:103:1
 		if
 //line cmd/ae-mcp/add_text_layer_tool.gox:103:1
-		fauxItalic, ok := optionsMap["fauxItalic"].(bool); ok {
+		fauxBold, ok := optionsMap["fauxBold"].(bool); ok {
 //line cmd/ae-mcp/add_text_layer_tool.gox:104:1
-			textOptions.FauxItalic = &fauxItalic
+			textOptions.FauxBold = &fauxBold
 		}
 //line cmd/ae-mcp/add_text_layer_tool.gox:106:1
 		if
 //line cmd/ae-mcp/add_text_layer_tool.gox:106:1
-		allCaps, ok := optionsMap["allCaps"].(bool); ok {
+		fauxItalic, ok := optionsMap["fauxItalic"].(bool); ok {
 //line cmd/ae-mcp/add_text_layer_tool.gox:107:1
-			textOptions.AllCaps = &allCaps
+			textOptions.FauxItalic = &fauxItalic
 		}
 //line cmd/ae-mcp/add_text_layer_tool.gox:109:1
 		if
 //line cmd/ae-mcp/add_text_layer_tool.gox:109:1
-		smallCaps, ok := optionsMap["smallCaps"].(bool); ok {
+		allCaps, ok := optionsMap["allCaps"].(bool); ok {
 //line cmd/ae-mcp/add_text_layer_tool.gox:110:1
+			textOptions.AllCaps = &allCaps
+		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:112:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:112:1
+		smallCaps, ok := optionsMap["smallCaps"].(bool); ok {
+//line cmd/ae-mcp/add_text_layer_tool.gox:113:1
 			textOptions.SmallCaps = &smallCaps
 		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:117:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:117:1
+		boxSize, ok := optionsMap["boxSize"].([]interface{}); ok && len(boxSize) >= 2 {
+//line cmd/ae-mcp/add_text_layer_tool.gox:118:1
+			w, _ := boxSize[0].(float64)
+//line cmd/ae-mcp/add_text_layer_tool.gox:119:1
+			h, _ := boxSize[1].(float64)
+//line cmd/ae-mcp/add_text_layer_tool.gox:120:1
+			textOptions.BoxSize = [2]float64{w, h}
+		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:122:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:122:1
+		boxPosition, ok := optionsMap["boxPosition"].([]interface{}); ok && len(boxPosition) >= 2 {
+//line cmd/ae-mcp/add_text_layer_tool.gox:123:1
+			x, _ := boxPosition[0].(float64)
+//line cmd/ae-mcp/add_text_layer_tool.gox:124:1
+			y, _ := boxPosition[1].(float64)
+//line cmd/ae-mcp/add_text_layer_tool.gox:125:1
+			textOptions.BoxPosition = &[2]float64{x, y}
+		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:129:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:129:1
+		firstLineIndent, ok := optionsMap["firstLineIndent"].(float64); ok {
+//line cmd/ae-mcp/add_text_layer_tool.gox:130:1
+			textOptions.FirstLineIndent = firstLineIndent
+		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:132:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:132:1
+		spaceBefore, ok := optionsMap["spaceBefore"].(float64); ok {
+//line cmd/ae-mcp/add_text_layer_tool.gox:133:1
+			textOptions.SpaceBefore = spaceBefore
+		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:135:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:135:1
+		spaceAfter, ok := optionsMap["spaceAfter"].(float64); ok {
+//line cmd/ae-mcp/add_text_layer_tool.gox:136:1
+			textOptions.SpaceAfter = spaceAfter
+		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:138:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:138:1
+		vertical, ok := optionsMap["vertical"].(bool); ok {
+//line cmd/ae-mcp/add_text_layer_tool.gox:139:1
+			textOptions.Vertical = &vertical
+		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:143:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:143:1
+		pathMap, ok := optionsMap["path"].(map[string]interface{}); ok {
+//line cmd/ae-mcp/add_text_layer_tool.gox:144:1
+			pathSettings, err := tools.ParseTextPathSettings(pathMap)
+//line cmd/ae-mcp/add_text_layer_tool.gox:145:1
+			if err != nil {
+//line cmd/ae-mcp/add_text_layer_tool.gox:146:1
+				return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+			}
+//line cmd/ae-mcp/add_text_layer_tool.gox:150:1
+			textOptions.Path = pathSettings
+		}
+//line cmd/ae-mcp/add_text_layer_tool.gox:154:1
+		if
+//line cmd/ae-mcp/add_text_layer_tool.gox:154:1
+		autoFitMap, ok := optionsMap["autoFit"].(map[string]interface{}); ok {
+//line cmd/ae-mcp/add_text_layer_tool.gox:155:1
+			autoFit, err := tools.ParseTextAutoFit(autoFitMap)
+//line cmd/ae-mcp/add_text_layer_tool.gox:156:1
+			if err != nil {
+//line cmd/ae-mcp/add_text_layer_tool.gox:157:1
+				return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+			}
+//line cmd/ae-mcp/add_text_layer_tool.gox:161:1
+			textOptions.AutoFit = autoFit
+		}
 	}
-//line cmd/ae-mcp/add_text_layer_tool.gox:114:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:165:1
 	// Call the implementation in golang
 	var result map[string]interface{}
-//line cmd/ae-mcp/add_text_layer_tool.gox:116:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:167:1
 	var err error
-//line cmd/ae-mcp/add_text_layer_tool.gox:117:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:168:1
 	result, err = tools.AddTextLayer(compName, layerName, textContent, textOptions)
-//line cmd/ae-mcp/add_text_layer_tool.gox:118:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:169:1
 	if err != nil {
-//line cmd/ae-mcp/add_text_layer_tool.gox:119:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:170:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/add_text_layer_tool.gox:123:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:174:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
 func (this *add_text_layer) Classclone() server.ToolProto {
@@ -708,7 +1290,7 @@ func (this *add_text_layer) Classclone() server.ToolProto {
 //line cmd/ae-mcp/apply_effect_tool.gox:6
 // Tool for applying effects to layers
 func (this *apply_effect) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
-//line cmd/ae-mcp/add_text_layer_tool.gox:123:1
+//line cmd/ae-mcp/add_text_layer_tool.gox:174:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
 //line cmd/ae-mcp/apply_effect_tool.gox:7:1
 	this.Tool("ae_apply_effect", func() {
@@ -731,14 +1313,14 @@ func (this *apply_effect) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallTool
 //line cmd/ae-mcp/apply_effect_tool.gox:17:1
 		this.String("effect_name", func() {
 //line cmd/ae-mcp/apply_effect_tool.gox:18:1
-			this.Description("Name of the effect to apply (e.g. 'Blur', 'Color Correction', etc.)")
+			this.Description("Name of the effect to apply (display name in English or any scanned AE language, or match name)")
 //line cmd/ae-mcp/apply_effect_tool.gox:19:1
 			this.Required()
 		})
 //line cmd/ae-mcp/apply_effect_tool.gox:21:1
 		this.Object("parameters", func() {
 //line cmd/ae-mcp/apply_effect_tool.gox:22:1
-			this.Description("Effect parameters to set (specific to the effect type)")
+			this.Description("Effect parameters to set, keyed by parameter display name or match name (see ae_describe_effect); values are validated and the result reports each parameter as applied or failed")
 		})
 	})
 //line cmd/ae-mcp/apply_effect_tool.gox:27:1
@@ -774,371 +1356,3857 @@ func (this *apply_effect) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
-//line cmd/ae-mcp/create_composition_tool.gox:6
-// Tool for creating new compositions
-func (this *create_composition) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/apply_preset_tool.gox:6
+// Tool for applying animation presets
+func (this *apply_preset) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
 //line cmd/ae-mcp/apply_effect_tool.gox:46:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
-//line cmd/ae-mcp/create_composition_tool.gox:7:1
-	this.Tool("ae_create_composition", func() {
-//line cmd/ae-mcp/create_composition_tool.gox:8:1
-		this.Description("Create a new composition in After Effects")
-//line cmd/ae-mcp/create_composition_tool.gox:9:1
-		this.String("name", func() {
-//line cmd/ae-mcp/create_composition_tool.gox:10:1
-			this.Description("Name of the new composition")
-//line cmd/ae-mcp/create_composition_tool.gox:11:1
-			this.Required()
-		})
-//line cmd/ae-mcp/create_composition_tool.gox:13:1
-		this.Float("width", func() {
-//line cmd/ae-mcp/create_composition_tool.gox:14:1
-			this.Description("Composition width (pixels)")
-//line cmd/ae-mcp/create_composition_tool.gox:15:1
+//line cmd/ae-mcp/apply_preset_tool.gox:7:1
+	this.Tool("ae_apply_preset", func() {
+//line cmd/ae-mcp/apply_preset_tool.gox:8:1
+		this.Description("Apply an After Effects animation preset (.ffx) to a layer, by file path or by a preset name found with ae_search_presets")
+//line cmd/ae-mcp/apply_preset_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/apply_preset_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/apply_preset_tool.gox:11:1
 			this.Required()
 		})
-//line cmd/ae-mcp/create_composition_tool.gox:17:1
-		this.Float("height", func() {
-//line cmd/ae-mcp/create_composition_tool.gox:18:1
-			this.Description("Composition height (pixels)")
-//line cmd/ae-mcp/create_composition_tool.gox:19:1
+//line cmd/ae-mcp/apply_preset_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/apply_preset_tool.gox:14:1
+			this.Description("Name of the layer to apply the preset to")
+//line cmd/ae-mcp/apply_preset_tool.gox:15:1
 			this.Required()
 		})
-//line cmd/ae-mcp/create_composition_tool.gox:21:1
-		this.Float("duration", func() {
-//line cmd/ae-mcp/create_composition_tool.gox:22:1
-			this.Description("Composition duration (seconds)")
-//line cmd/ae-mcp/create_composition_tool.gox:23:1
+//line cmd/ae-mcp/apply_preset_tool.gox:17:1
+		this.String("preset", func() {
+//line cmd/ae-mcp/apply_preset_tool.gox:18:1
+			this.Description("Path to an .ffx file, a preset name, or category/name as returned by ae_search_presets")
+//line cmd/ae-mcp/apply_preset_tool.gox:19:1
 			this.Required()
 		})
-//line cmd/ae-mcp/create_composition_tool.gox:25:1
-		this.Float("frameRate", func() {
-//line cmd/ae-mcp/create_composition_tool.gox:26:1
-			this.Description("Composition frame rate")
-		})
 	})
-//line cmd/ae-mcp/create_composition_tool.gox:31:1
-	nameStr := this.Gop_Env("name").(string)
-//line cmd/ae-mcp/create_composition_tool.gox:34:1
-	widthVal := int(this.Gop_Env("width").(float64))
-//line cmd/ae-mcp/create_composition_tool.gox:35:1
-	if widthVal == 0 {
-//line cmd/ae-mcp/create_composition_tool.gox:36:1
-		widthVal = 1920
-	}
-//line cmd/ae-mcp/create_composition_tool.gox:39:1
-	heightVal := int(this.Gop_Env("height").(float64))
-//line cmd/ae-mcp/create_composition_tool.gox:40:1
-	if heightVal == 0 {
-//line cmd/ae-mcp/create_composition_tool.gox:41:1
-		heightVal = 1080
-	}
-//line cmd/ae-mcp/create_composition_tool.gox:44:1
-	durationVal, ok := this.Gop_Env("duration").(float64)
-//line cmd/ae-mcp/create_composition_tool.gox:45:1
-	if !ok {
-//line cmd/ae-mcp/create_composition_tool.gox:46:1
-		durationVal = 60
-	}
-//line cmd/ae-mcp/create_composition_tool.gox:49:1
-	frameRateVal, ok := this.Gop_Env("frameRate").(float64)
-//line cmd/ae-mcp/create_composition_tool.gox:50:1
-	if !ok {
-//line cmd/ae-mcp/create_composition_tool.gox:51:1
-		frameRateVal = 30
-	}
-//line cmd/ae-mcp/create_composition_tool.gox:54:1
+//line cmd/ae-mcp/apply_preset_tool.gox:24:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/apply_preset_tool.gox:25:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/apply_preset_tool.gox:26:1
+	preset := this.Gop_Env("preset").(string)
+//line cmd/ae-mcp/apply_preset_tool.gox:28:1
 	// Call the implementation in golang
-	var result map[string]interface{}
-//line cmd/ae-mcp/create_composition_tool.gox:56:1
-	var err error
-//line cmd/ae-mcp/create_composition_tool.gox:57:1
-	result, err = tools.CreateComposition(nameStr, widthVal, heightVal, durationVal, frameRateVal)
-//line cmd/ae-mcp/create_composition_tool.gox:58:1
+	var result tools.EffectDetails
+//line cmd/ae-mcp/apply_preset_tool.gox:30:1
+	result, err := tools.ApplyPreset(compName, layerName, preset)
+//line cmd/ae-mcp/apply_preset_tool.gox:31:1
 	if err != nil {
-//line cmd/ae-mcp/create_composition_tool.gox:59:1
+//line cmd/ae-mcp/apply_preset_tool.gox:32:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/create_composition_tool.gox:63:1
+//line cmd/ae-mcp/apply_preset_tool.gox:36:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
-func (this *create_composition) Classclone() server.ToolProto {
+func (this *apply_preset) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
-//line cmd/ae-mcp/get_effect_categories_tool.gox:6
-// Tool for getting available effect categories
-func (this *get_effect_categories) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
-//line cmd/ae-mcp/create_composition_tool.gox:63:1
+//line cmd/ae-mcp/apply_recipe_tool.gox:6
+// Tool for applying effect recipes
+func (this *apply_recipe) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/apply_preset_tool.gox:36:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
-//line cmd/ae-mcp/get_effect_categories_tool.gox:7:1
-	this.Tool("mcp_aftereffects_ae_get_effect_categories", func() {
-//line cmd/ae-mcp/get_effect_categories_tool.gox:8:1
-		this.Description("Get a list of all available effect categories in After Effects")
-//line cmd/ae-mcp/get_effect_categories_tool.gox:9:1
-		this.String("random_string", func() {
-//line cmd/ae-mcp/get_effect_categories_tool.gox:10:1
-			this.Description("Dummy parameter for no-parameter tools")
+//line cmd/ae-mcp/apply_recipe_tool.gox:7:1
+	this.Tool("ae_apply_recipe", func() {
+//line cmd/ae-mcp/apply_recipe_tool.gox:8:1
+		this.Description("Apply a named effect recipe (a saved stack of effects with parameters, such as soft glow or film look) to a layer in one step; see ae_list_recipes")
+//line cmd/ae-mcp/apply_recipe_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/apply_recipe_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/apply_recipe_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/apply_recipe_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/apply_recipe_tool.gox:14:1
+			this.Description("Name of the layer to apply the recipe to")
+//line cmd/ae-mcp/apply_recipe_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/apply_recipe_tool.gox:17:1
+		this.String("recipe_name", func() {
+//line cmd/ae-mcp/apply_recipe_tool.gox:18:1
+			this.Description("Name of the recipe")
+//line cmd/ae-mcp/apply_recipe_tool.gox:19:1
+			this.Required()
 		})
 	})
-//line cmd/ae-mcp/get_effect_categories_tool.gox:15:1
-	result, err := tools.MCPGetEffectCategories(map[string]interface{}{})
-//line cmd/ae-mcp/get_effect_categories_tool.gox:16:1
+//line cmd/ae-mcp/apply_recipe_tool.gox:24:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/apply_recipe_tool.gox:25:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/apply_recipe_tool.gox:26:1
+	recipeName := this.Gop_Env("recipe_name").(string)
+//line cmd/ae-mcp/apply_recipe_tool.gox:28:1
+	// Call the implementation in golang
+	var result tools.EffectDetails
+//line cmd/ae-mcp/apply_recipe_tool.gox:30:1
+	result, err := tools.ApplyEffectRecipe(compName, layerName, recipeName)
+//line cmd/ae-mcp/apply_recipe_tool.gox:31:1
 	if err != nil {
-//line cmd/ae-mcp/get_effect_categories_tool.gox:17:1
+//line cmd/ae-mcp/apply_recipe_tool.gox:32:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/get_effect_categories_tool.gox:21:1
+//line cmd/ae-mcp/apply_recipe_tool.gox:36:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
-func (this *get_effect_categories) Classclone() server.ToolProto {
+func (this *apply_recipe) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:6
-// Tool for getting effects by category
-func (this *get_effects_by_category) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
-//line cmd/ae-mcp/get_effect_categories_tool.gox:21:1
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:6
+// Tool for applying a text animator preset
+func (this *apply_text_animator_preset) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/apply_recipe_tool.gox:36:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:7:1
-	this.Tool("mcp_aftereffects_ae_get_effects_by_category", func() {
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:8:1
-		this.Description("Get a list of effects in a specified category")
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:9:1
-		this.String("category", func() {
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:10:1
-			this.Description("Name of the effect category to query")
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:11:1
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:7:1
+	this.Tool("ae_apply_text_animator_preset", func() {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:8:1
+		this.Description("Apply a built-in text animation preset (typewriter, fade-up-by-word, scramble, blur-in, tracking-in) to a text layer")
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:10:1
+			this.Description("Name of the composition containing the text layer")
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:11:1
 			this.Required()
 		})
-	})
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:16:1
-	category := this.Gop_Env("category").(string)
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:19:1
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:14:1
+			this.Description("Name of the text layer")
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:17:1
+		this.String("preset", func() {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:18:1
+			this.Description("Preset name (typewriter, fade-up-by-word, scramble, blur-in, tracking-in)")
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:21:1
+		this.Float("start_time", func() {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:22:1
+			this.Description("Seconds after the layer in point at which the animation starts (default: 0)")
+		})
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:24:1
+		this.Float("duration", func() {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:25:1
+			this.Description("Duration of the animation in seconds (default: 1)")
+		})
+	})
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:30:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:31:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:32:1
+	presetName := this.Gop_Env("preset").(string)
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:34:1
+	startTime := 0.0
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:35:1
+	if this.Gop_Env("start_time") != nil {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:36:1
+		startTime = this.Gop_Env("start_time").(float64)
+	}
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:39:1
+	duration := 1.0
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:40:1
+	if this.Gop_Env("duration") != nil {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:41:1
+		duration = this.Gop_Env("duration").(float64)
+	}
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:44:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:46:1
+	var err error
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:47:1
+	result, err = tools.ApplyTextAnimatorPreset(compName, layerName, presetName, startTime, duration)
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:48:1
+	if err != nil {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:49:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:53:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *apply_text_animator_preset) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/camera_crane_tool.gox:6
+// Tool for the crane camera move
+func (this *camera_crane) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/apply_text_animator_preset_tool.gox:53:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/camera_crane_tool.gox:7:1
+	this.Tool("ae_camera_crane", func() {
+//line cmd/ae-mcp/camera_crane_tool.gox:8:1
+		this.Description("Crane a camera up or down, moving its point of interest with it. Writes keyframes on the camera, or with rig builds a 3D null the camera is parented to and animates that instead")
+//line cmd/ae-mcp/camera_crane_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/camera_crane_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/camera_crane_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_crane_tool.gox:13:1
+		this.String("camera_name", func() {
+//line cmd/ae-mcp/camera_crane_tool.gox:14:1
+			this.Description("Name of the camera layer")
+//line cmd/ae-mcp/camera_crane_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_crane_tool.gox:17:1
+		this.Float("duration", func() {
+//line cmd/ae-mcp/camera_crane_tool.gox:18:1
+			this.Description("Length of the move in seconds")
+//line cmd/ae-mcp/camera_crane_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_crane_tool.gox:21:1
+		this.Float("intensity", func() {
+//line cmd/ae-mcp/camera_crane_tool.gox:22:1
+			this.Description("Distance in pixels up (default: 300); negative moves down")
+		})
+//line cmd/ae-mcp/camera_crane_tool.gox:24:1
+		this.Float("start_time", func() {
+//line cmd/ae-mcp/camera_crane_tool.gox:25:1
+			this.Description("Start of the move in seconds (default: current composition time)")
+		})
+//line cmd/ae-mcp/camera_crane_tool.gox:27:1
+		this.Bool("rig", func() {
+//line cmd/ae-mcp/camera_crane_tool.gox:28:1
+			this.Description("Animate a new null parent instead of the camera, leaving camera keyframes untouched")
+		})
+//line cmd/ae-mcp/camera_crane_tool.gox:30:1
+		this.Bool("linear", func() {
+//line cmd/ae-mcp/camera_crane_tool.gox:31:1
+			this.Description("Constant speed instead of easing in and out")
+		})
+	})
+//line cmd/ae-mcp/camera_crane_tool.gox:36:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/camera_crane_tool.gox:37:1
+	cameraName := this.Gop_Env("camera_name").(string)
+//line cmd/ae-mcp/camera_crane_tool.gox:39:1
+	var move tools.CameraMove
+//line cmd/ae-mcp/camera_crane_tool.gox:40:1
+	move.Duration = this.Gop_Env("duration").(float64)
+//line cmd/ae-mcp/camera_crane_tool.gox:41:1
+	if this.Gop_Env("intensity") != nil {
+//line cmd/ae-mcp/camera_crane_tool.gox:42:1
+		move.Intensity = this.Gop_Env("intensity").(float64)
+	}
+//line cmd/ae-mcp/camera_crane_tool.gox:44:1
+	if this.Gop_Env("start_time") != nil {
+//line cmd/ae-mcp/camera_crane_tool.gox:45:1
+		startTime := this.Gop_Env("start_time").(float64)
+//line cmd/ae-mcp/camera_crane_tool.gox:46:1
+		move.StartTime = &startTime
+	}
+//line cmd/ae-mcp/camera_crane_tool.gox:48:1
+	if this.Gop_Env("rig") != nil {
+//line cmd/ae-mcp/camera_crane_tool.gox:49:1
+		move.Rig = this.Gop_Env("rig").(bool)
+	}
+//line cmd/ae-mcp/camera_crane_tool.gox:51:1
+	if this.Gop_Env("linear") != nil {
+//line cmd/ae-mcp/camera_crane_tool.gox:52:1
+		move.Linear = this.Gop_Env("linear").(bool)
+	}
+//line cmd/ae-mcp/camera_crane_tool.gox:55:1
+	// Call the implementation in golang
+	var result tools.LayerInfo
+//line cmd/ae-mcp/camera_crane_tool.gox:57:1
+	result, err := tools.ApplyCameraMove(compName, cameraName, "crane", move)
+//line cmd/ae-mcp/camera_crane_tool.gox:58:1
+	if err != nil {
+//line cmd/ae-mcp/camera_crane_tool.gox:59:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/camera_crane_tool.gox:63:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *camera_crane) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/camera_dolly_tool.gox:6
+// Tool for the dolly camera move
+func (this *camera_dolly) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/camera_crane_tool.gox:63:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/camera_dolly_tool.gox:7:1
+	this.Tool("ae_camera_dolly", func() {
+//line cmd/ae-mcp/camera_dolly_tool.gox:8:1
+		this.Description("Dolly a camera forward or back along its view direction, moving its point of interest with it. Writes keyframes on the camera, or with rig builds a 3D null the camera is parented to and animates that instead")
+//line cmd/ae-mcp/camera_dolly_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/camera_dolly_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/camera_dolly_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_dolly_tool.gox:13:1
+		this.String("camera_name", func() {
+//line cmd/ae-mcp/camera_dolly_tool.gox:14:1
+			this.Description("Name of the camera layer")
+//line cmd/ae-mcp/camera_dolly_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_dolly_tool.gox:17:1
+		this.Float("duration", func() {
+//line cmd/ae-mcp/camera_dolly_tool.gox:18:1
+			this.Description("Length of the move in seconds")
+//line cmd/ae-mcp/camera_dolly_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_dolly_tool.gox:21:1
+		this.Float("intensity", func() {
+//line cmd/ae-mcp/camera_dolly_tool.gox:22:1
+			this.Description("Distance in pixels (default: 400); negative pulls back")
+		})
+//line cmd/ae-mcp/camera_dolly_tool.gox:24:1
+		this.Float("start_time", func() {
+//line cmd/ae-mcp/camera_dolly_tool.gox:25:1
+			this.Description("Start of the move in seconds (default: current composition time)")
+		})
+//line cmd/ae-mcp/camera_dolly_tool.gox:27:1
+		this.Bool("rig", func() {
+//line cmd/ae-mcp/camera_dolly_tool.gox:28:1
+			this.Description("Animate a new null parent instead of the camera, leaving camera keyframes untouched")
+		})
+//line cmd/ae-mcp/camera_dolly_tool.gox:30:1
+		this.Bool("linear", func() {
+//line cmd/ae-mcp/camera_dolly_tool.gox:31:1
+			this.Description("Constant speed instead of easing in and out")
+		})
+	})
+//line cmd/ae-mcp/camera_dolly_tool.gox:36:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/camera_dolly_tool.gox:37:1
+	cameraName := this.Gop_Env("camera_name").(string)
+//line cmd/ae-mcp/camera_dolly_tool.gox:39:1
+	var move tools.CameraMove
+//line cmd/ae-mcp/camera_dolly_tool.gox:40:1
+	move.Duration = this.Gop_Env("duration").(float64)
+//line cmd/ae-mcp/camera_dolly_tool.gox:41:1
+	if this.Gop_Env("intensity") != nil {
+//line cmd/ae-mcp/camera_dolly_tool.gox:42:1
+		move.Intensity = this.Gop_Env("intensity").(float64)
+	}
+//line cmd/ae-mcp/camera_dolly_tool.gox:44:1
+	if this.Gop_Env("start_time") != nil {
+//line cmd/ae-mcp/camera_dolly_tool.gox:45:1
+		startTime := this.Gop_Env("start_time").(float64)
+//line cmd/ae-mcp/camera_dolly_tool.gox:46:1
+		move.StartTime = &startTime
+	}
+//line cmd/ae-mcp/camera_dolly_tool.gox:48:1
+	if this.Gop_Env("rig") != nil {
+//line cmd/ae-mcp/camera_dolly_tool.gox:49:1
+		move.Rig = this.Gop_Env("rig").(bool)
+	}
+//line cmd/ae-mcp/camera_dolly_tool.gox:51:1
+	if this.Gop_Env("linear") != nil {
+//line cmd/ae-mcp/camera_dolly_tool.gox:52:1
+		move.Linear = this.Gop_Env("linear").(bool)
+	}
+//line cmd/ae-mcp/camera_dolly_tool.gox:55:1
+	// Call the implementation in golang
+	var result tools.LayerInfo
+//line cmd/ae-mcp/camera_dolly_tool.gox:57:1
+	result, err := tools.ApplyCameraMove(compName, cameraName, "dolly", move)
+//line cmd/ae-mcp/camera_dolly_tool.gox:58:1
+	if err != nil {
+//line cmd/ae-mcp/camera_dolly_tool.gox:59:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/camera_dolly_tool.gox:63:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *camera_dolly) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/camera_orbit_tool.gox:6
+// Tool for the orbit camera move
+func (this *camera_orbit) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/camera_dolly_tool.gox:63:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/camera_orbit_tool.gox:7:1
+	this.Tool("ae_camera_orbit", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:8:1
+		this.Description("Orbit a camera around a point or layer on the vertical axis; its point of interest orbits along, so a camera aimed at the center stays aimed at it. Writes keyframes on the camera, or with rig builds a 3D null the camera is parented to and animates that instead")
+//line cmd/ae-mcp/camera_orbit_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/camera_orbit_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_orbit_tool.gox:13:1
+		this.String("camera_name", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:14:1
+			this.Description("Name of the camera layer")
+//line cmd/ae-mcp/camera_orbit_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_orbit_tool.gox:17:1
+		this.Float("duration", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:18:1
+			this.Description("Length of the move in seconds")
+//line cmd/ae-mcp/camera_orbit_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_orbit_tool.gox:21:1
+		this.Float("intensity", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:22:1
+			this.Description("Degrees to orbit (default: 90); negative orbits the other way")
+		})
+//line cmd/ae-mcp/camera_orbit_tool.gox:24:1
+		this.Float("start_time", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:25:1
+			this.Description("Start of the move in seconds (default: current composition time)")
+		})
+//line cmd/ae-mcp/camera_orbit_tool.gox:27:1
+		this.Bool("rig", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:28:1
+			this.Description("Animate a new null parent instead of the camera, leaving camera keyframes untouched")
+		})
+//line cmd/ae-mcp/camera_orbit_tool.gox:30:1
+		this.Array("center", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:31:1
+			this.Description("Orbit center [x, y, z] (default: the point of interest)")
+		})
+//line cmd/ae-mcp/camera_orbit_tool.gox:33:1
+		this.String("target_layer", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:34:1
+			this.Description("Orbit around the anchor point of this layer, including any parenting")
+		})
+//line cmd/ae-mcp/camera_orbit_tool.gox:36:1
+		this.Bool("linear", func() {
+//line cmd/ae-mcp/camera_orbit_tool.gox:37:1
+			this.Description("Constant speed instead of easing in and out")
+		})
+	})
+//line cmd/ae-mcp/camera_orbit_tool.gox:42:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/camera_orbit_tool.gox:43:1
+	cameraName := this.Gop_Env("camera_name").(string)
+//line cmd/ae-mcp/camera_orbit_tool.gox:45:1
+	var move tools.CameraMove
+//line cmd/ae-mcp/camera_orbit_tool.gox:46:1
+	move.Duration = this.Gop_Env("duration").(float64)
+//line cmd/ae-mcp/camera_orbit_tool.gox:47:1
+	if this.Gop_Env("intensity") != nil {
+//line cmd/ae-mcp/camera_orbit_tool.gox:48:1
+		move.Intensity = this.Gop_Env("intensity").(float64)
+	}
+//line cmd/ae-mcp/camera_orbit_tool.gox:50:1
+	if this.Gop_Env("start_time") != nil {
+//line cmd/ae-mcp/camera_orbit_tool.gox:51:1
+		startTime := this.Gop_Env("start_time").(float64)
+//line cmd/ae-mcp/camera_orbit_tool.gox:52:1
+		move.StartTime = &startTime
+	}
+//line cmd/ae-mcp/camera_orbit_tool.gox:54:1
+	if this.Gop_Env("rig") != nil {
+//line cmd/ae-mcp/camera_orbit_tool.gox:55:1
+		move.Rig = this.Gop_Env("rig").(bool)
+	}
+//line cmd/ae-mcp/camera_orbit_tool.gox:57:1
+	if this.Gop_Env("center") != nil {
+//line cmd/ae-mcp/camera_orbit_tool.gox:58:1
+		values := this.Gop_Env("center").([]interface{})
+//line cmd/ae-mcp/camera_orbit_tool.gox:59:1
+		if len(values) >= 3 {
+//line cmd/ae-mcp/camera_orbit_tool.gox:60:1
+			x, _ := values[0].(float64)
+//line cmd/ae-mcp/camera_orbit_tool.gox:61:1
+			y, _ := values[1].(float64)
+//line cmd/ae-mcp/camera_orbit_tool.gox:62:1
+			z, _ := values[2].(float64)
+//line cmd/ae-mcp/camera_orbit_tool.gox:63:1
+			move.Center = &[3]float64{x, y, z}
+		}
+	}
+//line cmd/ae-mcp/camera_orbit_tool.gox:66:1
+	if this.Gop_Env("target_layer") != nil {
+//line cmd/ae-mcp/camera_orbit_tool.gox:67:1
+		move.TargetLayer = this.Gop_Env("target_layer").(string)
+	}
+//line cmd/ae-mcp/camera_orbit_tool.gox:69:1
+	if this.Gop_Env("linear") != nil {
+//line cmd/ae-mcp/camera_orbit_tool.gox:70:1
+		move.Linear = this.Gop_Env("linear").(bool)
+	}
+//line cmd/ae-mcp/camera_orbit_tool.gox:73:1
+	// Call the implementation in golang
+	var result tools.LayerInfo
+//line cmd/ae-mcp/camera_orbit_tool.gox:75:1
+	result, err := tools.ApplyCameraMove(compName, cameraName, "orbit", move)
+//line cmd/ae-mcp/camera_orbit_tool.gox:76:1
+	if err != nil {
+//line cmd/ae-mcp/camera_orbit_tool.gox:77:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/camera_orbit_tool.gox:81:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *camera_orbit) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/camera_push_in_tool.gox:6
+// Tool for the push-in camera move
+func (this *camera_push_in) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/camera_orbit_tool.gox:81:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/camera_push_in_tool.gox:7:1
+	this.Tool("ae_camera_push_in", func() {
+//line cmd/ae-mcp/camera_push_in_tool.gox:8:1
+		this.Description("Push a two-node camera in towards its point of interest with an ease in and out, keeping the point of interest in place. Writes keyframes on the camera, or with rig builds a 3D null the camera is parented to and animates that instead")
+//line cmd/ae-mcp/camera_push_in_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/camera_push_in_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/camera_push_in_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_push_in_tool.gox:13:1
+		this.String("camera_name", func() {
+//line cmd/ae-mcp/camera_push_in_tool.gox:14:1
+			this.Description("Name of the camera layer")
+//line cmd/ae-mcp/camera_push_in_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_push_in_tool.gox:17:1
+		this.Float("duration", func() {
+//line cmd/ae-mcp/camera_push_in_tool.gox:18:1
+			this.Description("Length of the move in seconds")
+//line cmd/ae-mcp/camera_push_in_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_push_in_tool.gox:21:1
+		this.Float("intensity", func() {
+//line cmd/ae-mcp/camera_push_in_tool.gox:22:1
+			this.Description("Percent of the distance to the point of interest to travel (default: 30)")
+		})
+//line cmd/ae-mcp/camera_push_in_tool.gox:24:1
+		this.Float("start_time", func() {
+//line cmd/ae-mcp/camera_push_in_tool.gox:25:1
+			this.Description("Start of the move in seconds (default: current composition time)")
+		})
+//line cmd/ae-mcp/camera_push_in_tool.gox:27:1
+		this.Bool("rig", func() {
+//line cmd/ae-mcp/camera_push_in_tool.gox:28:1
+			this.Description("Animate a new null parent instead of the camera, leaving camera keyframes untouched")
+		})
+	})
+//line cmd/ae-mcp/camera_push_in_tool.gox:33:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/camera_push_in_tool.gox:34:1
+	cameraName := this.Gop_Env("camera_name").(string)
+//line cmd/ae-mcp/camera_push_in_tool.gox:36:1
+	var move tools.CameraMove
+//line cmd/ae-mcp/camera_push_in_tool.gox:37:1
+	move.Duration = this.Gop_Env("duration").(float64)
+//line cmd/ae-mcp/camera_push_in_tool.gox:38:1
+	if this.Gop_Env("intensity") != nil {
+//line cmd/ae-mcp/camera_push_in_tool.gox:39:1
+		move.Intensity = this.Gop_Env("intensity").(float64)
+	}
+//line cmd/ae-mcp/camera_push_in_tool.gox:41:1
+	if this.Gop_Env("start_time") != nil {
+//line cmd/ae-mcp/camera_push_in_tool.gox:42:1
+		startTime := this.Gop_Env("start_time").(float64)
+//line cmd/ae-mcp/camera_push_in_tool.gox:43:1
+		move.StartTime = &startTime
+	}
+//line cmd/ae-mcp/camera_push_in_tool.gox:45:1
+	if this.Gop_Env("rig") != nil {
+//line cmd/ae-mcp/camera_push_in_tool.gox:46:1
+		move.Rig = this.Gop_Env("rig").(bool)
+	}
+//line cmd/ae-mcp/camera_push_in_tool.gox:49:1
+	// Call the implementation in golang
+	var result tools.LayerInfo
+//line cmd/ae-mcp/camera_push_in_tool.gox:51:1
+	result, err := tools.ApplyCameraMove(compName, cameraName, "push_in", move)
+//line cmd/ae-mcp/camera_push_in_tool.gox:52:1
+	if err != nil {
+//line cmd/ae-mcp/camera_push_in_tool.gox:53:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/camera_push_in_tool.gox:57:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *camera_push_in) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/camera_shake_tool.gox:6
+// Tool for the shake camera move
+func (this *camera_shake) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/camera_push_in_tool.gox:57:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/camera_shake_tool.gox:7:1
+	this.Tool("ae_camera_shake", func() {
+//line cmd/ae-mcp/camera_shake_tool.gox:8:1
+		this.Description("Add handheld camera shake baked from smooth noise into keyframes, at most one per frame; keyframed shake is added on top of the existing camera animation. Writes keyframes on the camera, or with rig builds a 3D null the camera is parented to and animates that instead")
+//line cmd/ae-mcp/camera_shake_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/camera_shake_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/camera_shake_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_shake_tool.gox:13:1
+		this.String("camera_name", func() {
+//line cmd/ae-mcp/camera_shake_tool.gox:14:1
+			this.Description("Name of the camera layer")
+//line cmd/ae-mcp/camera_shake_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_shake_tool.gox:17:1
+		this.Float("duration", func() {
+//line cmd/ae-mcp/camera_shake_tool.gox:18:1
+			this.Description("Length of the move in seconds")
+//line cmd/ae-mcp/camera_shake_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_shake_tool.gox:21:1
+		this.Float("intensity", func() {
+//line cmd/ae-mcp/camera_shake_tool.gox:22:1
+			this.Description("Shake amplitude in pixels (default: 10)")
+		})
+//line cmd/ae-mcp/camera_shake_tool.gox:24:1
+		this.Float("start_time", func() {
+//line cmd/ae-mcp/camera_shake_tool.gox:25:1
+			this.Description("Start of the move in seconds (default: current composition time)")
+		})
+//line cmd/ae-mcp/camera_shake_tool.gox:27:1
+		this.Bool("rig", func() {
+//line cmd/ae-mcp/camera_shake_tool.gox:28:1
+			this.Description("Animate a new null parent instead of the camera, leaving camera keyframes untouched")
+		})
+//line cmd/ae-mcp/camera_shake_tool.gox:30:1
+		this.Float("frequency", func() {
+//line cmd/ae-mcp/camera_shake_tool.gox:31:1
+			this.Description("Wobbles per second (default: 1.5)")
+		})
+//line cmd/ae-mcp/camera_shake_tool.gox:33:1
+		this.Float("seed", func() {
+//line cmd/ae-mcp/camera_shake_tool.gox:34:1
+			this.Description("Noise seed; the same seed gives the same shake")
+		})
+	})
+//line cmd/ae-mcp/camera_shake_tool.gox:39:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/camera_shake_tool.gox:40:1
+	cameraName := this.Gop_Env("camera_name").(string)
+//line cmd/ae-mcp/camera_shake_tool.gox:42:1
+	var move tools.CameraMove
+//line cmd/ae-mcp/camera_shake_tool.gox:43:1
+	move.Duration = this.Gop_Env("duration").(float64)
+//line cmd/ae-mcp/camera_shake_tool.gox:44:1
+	if this.Gop_Env("intensity") != nil {
+//line cmd/ae-mcp/camera_shake_tool.gox:45:1
+		move.Intensity = this.Gop_Env("intensity").(float64)
+	}
+//line cmd/ae-mcp/camera_shake_tool.gox:47:1
+	if this.Gop_Env("start_time") != nil {
+//line cmd/ae-mcp/camera_shake_tool.gox:48:1
+		startTime := this.Gop_Env("start_time").(float64)
+//line cmd/ae-mcp/camera_shake_tool.gox:49:1
+		move.StartTime = &startTime
+	}
+//line cmd/ae-mcp/camera_shake_tool.gox:51:1
+	if this.Gop_Env("rig") != nil {
+//line cmd/ae-mcp/camera_shake_tool.gox:52:1
+		move.Rig = this.Gop_Env("rig").(bool)
+	}
+//line cmd/ae-mcp/camera_shake_tool.gox:54:1
+	if this.Gop_Env("frequency") != nil {
+//line cmd/ae-mcp/camera_shake_tool.gox:55:1
+		move.Frequency = this.Gop_Env("frequency").(float64)
+	}
+//line cmd/ae-mcp/camera_shake_tool.gox:57:1
+	if this.Gop_Env("seed") != nil {
+//line cmd/ae-mcp/camera_shake_tool.gox:58:1
+		move.Seed = int64(this.Gop_Env("seed").(float64))
+	}
+//line cmd/ae-mcp/camera_shake_tool.gox:61:1
+	// Call the implementation in golang
+	var result tools.LayerInfo
+//line cmd/ae-mcp/camera_shake_tool.gox:63:1
+	result, err := tools.ApplyCameraMove(compName, cameraName, "shake", move)
+//line cmd/ae-mcp/camera_shake_tool.gox:64:1
+	if err != nil {
+//line cmd/ae-mcp/camera_shake_tool.gox:65:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/camera_shake_tool.gox:69:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *camera_shake) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/camera_truck_tool.gox:6
+// Tool for the truck camera move
+func (this *camera_truck) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/camera_shake_tool.gox:69:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/camera_truck_tool.gox:7:1
+	this.Tool("ae_camera_truck", func() {
+//line cmd/ae-mcp/camera_truck_tool.gox:8:1
+		this.Description("Truck a camera sideways, moving its point of interest with it. Writes keyframes on the camera, or with rig builds a 3D null the camera is parented to and animates that instead")
+//line cmd/ae-mcp/camera_truck_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/camera_truck_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/camera_truck_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_truck_tool.gox:13:1
+		this.String("camera_name", func() {
+//line cmd/ae-mcp/camera_truck_tool.gox:14:1
+			this.Description("Name of the camera layer")
+//line cmd/ae-mcp/camera_truck_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_truck_tool.gox:17:1
+		this.Float("duration", func() {
+//line cmd/ae-mcp/camera_truck_tool.gox:18:1
+			this.Description("Length of the move in seconds")
+//line cmd/ae-mcp/camera_truck_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/camera_truck_tool.gox:21:1
+		this.Float("intensity", func() {
+//line cmd/ae-mcp/camera_truck_tool.gox:22:1
+			this.Description("Distance in pixels to the right (default: 400); negative moves left")
+		})
+//line cmd/ae-mcp/camera_truck_tool.gox:24:1
+		this.Float("start_time", func() {
+//line cmd/ae-mcp/camera_truck_tool.gox:25:1
+			this.Description("Start of the move in seconds (default: current composition time)")
+		})
+//line cmd/ae-mcp/camera_truck_tool.gox:27:1
+		this.Bool("rig", func() {
+//line cmd/ae-mcp/camera_truck_tool.gox:28:1
+			this.Description("Animate a new null parent instead of the camera, leaving camera keyframes untouched")
+		})
+//line cmd/ae-mcp/camera_truck_tool.gox:30:1
+		this.Bool("linear", func() {
+//line cmd/ae-mcp/camera_truck_tool.gox:31:1
+			this.Description("Constant speed instead of easing in and out")
+		})
+	})
+//line cmd/ae-mcp/camera_truck_tool.gox:36:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/camera_truck_tool.gox:37:1
+	cameraName := this.Gop_Env("camera_name").(string)
+//line cmd/ae-mcp/camera_truck_tool.gox:39:1
+	var move tools.CameraMove
+//line cmd/ae-mcp/camera_truck_tool.gox:40:1
+	move.Duration = this.Gop_Env("duration").(float64)
+//line cmd/ae-mcp/camera_truck_tool.gox:41:1
+	if this.Gop_Env("intensity") != nil {
+//line cmd/ae-mcp/camera_truck_tool.gox:42:1
+		move.Intensity = this.Gop_Env("intensity").(float64)
+	}
+//line cmd/ae-mcp/camera_truck_tool.gox:44:1
+	if this.Gop_Env("start_time") != nil {
+//line cmd/ae-mcp/camera_truck_tool.gox:45:1
+		startTime := this.Gop_Env("start_time").(float64)
+//line cmd/ae-mcp/camera_truck_tool.gox:46:1
+		move.StartTime = &startTime
+	}
+//line cmd/ae-mcp/camera_truck_tool.gox:48:1
+	if this.Gop_Env("rig") != nil {
+//line cmd/ae-mcp/camera_truck_tool.gox:49:1
+		move.Rig = this.Gop_Env("rig").(bool)
+	}
+//line cmd/ae-mcp/camera_truck_tool.gox:51:1
+	if this.Gop_Env("linear") != nil {
+//line cmd/ae-mcp/camera_truck_tool.gox:52:1
+		move.Linear = this.Gop_Env("linear").(bool)
+	}
+//line cmd/ae-mcp/camera_truck_tool.gox:55:1
+	// Call the implementation in golang
+	var result tools.LayerInfo
+//line cmd/ae-mcp/camera_truck_tool.gox:57:1
+	result, err := tools.ApplyCameraMove(compName, cameraName, "truck", move)
+//line cmd/ae-mcp/camera_truck_tool.gox:58:1
+	if err != nil {
+//line cmd/ae-mcp/camera_truck_tool.gox:59:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/camera_truck_tool.gox:63:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *camera_truck) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/create_composition_tool.gox:6
+// Tool for creating new compositions
+func (this *create_composition) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/camera_truck_tool.gox:63:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/create_composition_tool.gox:7:1
+	this.Tool("ae_create_composition", func() {
+//line cmd/ae-mcp/create_composition_tool.gox:8:1
+		this.Description("Create a new composition in After Effects, optionally with a 3D renderer")
+//line cmd/ae-mcp/create_composition_tool.gox:9:1
+		this.String("name", func() {
+//line cmd/ae-mcp/create_composition_tool.gox:10:1
+			this.Description("Name of the new composition")
+//line cmd/ae-mcp/create_composition_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/create_composition_tool.gox:13:1
+		this.Float("width", func() {
+//line cmd/ae-mcp/create_composition_tool.gox:14:1
+			this.Description("Composition width (pixels)")
+//line cmd/ae-mcp/create_composition_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/create_composition_tool.gox:17:1
+		this.Float("height", func() {
+//line cmd/ae-mcp/create_composition_tool.gox:18:1
+			this.Description("Composition height (pixels)")
+//line cmd/ae-mcp/create_composition_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/create_composition_tool.gox:21:1
+		this.Float("duration", func() {
+//line cmd/ae-mcp/create_composition_tool.gox:22:1
+			this.Description("Composition duration (seconds)")
+//line cmd/ae-mcp/create_composition_tool.gox:23:1
+			this.Required()
+		})
+//line cmd/ae-mcp/create_composition_tool.gox:25:1
+		this.Float("frameRate", func() {
+//line cmd/ae-mcp/create_composition_tool.gox:26:1
+			this.Description("Composition frame rate")
+		})
+//line cmd/ae-mcp/create_composition_tool.gox:28:1
+		this.String("renderer", func() {
+//line cmd/ae-mcp/create_composition_tool.gox:29:1
+			this.Description("3D renderer (Classic 3D, Cinema 4D, Advanced 3D, Ray-traced 3D); Cinema 4D or Advanced 3D are needed for extruded text and shapes")
+		})
+	})
+//line cmd/ae-mcp/create_composition_tool.gox:34:1
+	nameStr := this.Gop_Env("name").(string)
+//line cmd/ae-mcp/create_composition_tool.gox:37:1
+	widthVal := int(this.Gop_Env("width").(float64))
+//line cmd/ae-mcp/create_composition_tool.gox:38:1
+	if widthVal == 0 {
+//line cmd/ae-mcp/create_composition_tool.gox:39:1
+		widthVal = 1920
+	}
+//line cmd/ae-mcp/create_composition_tool.gox:42:1
+	heightVal := int(this.Gop_Env("height").(float64))
+//line cmd/ae-mcp/create_composition_tool.gox:43:1
+	if heightVal == 0 {
+//line cmd/ae-mcp/create_composition_tool.gox:44:1
+		heightVal = 1080
+	}
+//line cmd/ae-mcp/create_composition_tool.gox:47:1
+	durationVal, ok := this.Gop_Env("duration").(float64)
+//line cmd/ae-mcp/create_composition_tool.gox:48:1
+	if !ok {
+//line cmd/ae-mcp/create_composition_tool.gox:49:1
+		durationVal = 60
+	}
+//line cmd/ae-mcp/create_composition_tool.gox:52:1
+	frameRateVal, ok := this.Gop_Env("frameRate").(float64)
+//line cmd/ae-mcp/create_composition_tool.gox:53:1
+	if !ok {
+//line cmd/ae-mcp/create_composition_tool.gox:54:1
+		frameRateVal = 30
+	}
+//line cmd/ae-mcp/create_composition_tool.gox:57:1
+	renderer := ""
+//line cmd/ae-mcp/create_composition_tool.gox:58:1
+	if this.Gop_Env("renderer") != nil {
+//line cmd/ae-mcp/create_composition_tool.gox:59:1
+		renderer = this.Gop_Env("renderer").(string)
+	}
+//line cmd/ae-mcp/create_composition_tool.gox:62:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/create_composition_tool.gox:64:1
+	var err error
+//line cmd/ae-mcp/create_composition_tool.gox:65:1
+	result, err = tools.CreateComposition(nameStr, widthVal, heightVal, durationVal, frameRateVal, renderer)
+//line cmd/ae-mcp/create_composition_tool.gox:66:1
+	if err != nil {
+//line cmd/ae-mcp/create_composition_tool.gox:67:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/create_composition_tool.gox:71:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *create_composition) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/create_folder_tool.gox:6
+// Tool for creating folders
+func (this *create_folder) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/create_composition_tool.gox:71:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/create_folder_tool.gox:7:1
+	this.Tool("ae_create_folder", func() {
+//line cmd/ae-mcp/create_folder_tool.gox:8:1
+		this.Description("Create a folder in the project panel")
+//line cmd/ae-mcp/create_folder_tool.gox:9:1
+		this.String("name", func() {
+//line cmd/ae-mcp/create_folder_tool.gox:10:1
+			this.Description("Name of the new folder")
+//line cmd/ae-mcp/create_folder_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/create_folder_tool.gox:13:1
+		this.Float("parent_folder_id", func() {
+//line cmd/ae-mcp/create_folder_tool.gox:14:1
+			this.Description("Id of the folder to create it in (default: root folder)")
+		})
+	})
+//line cmd/ae-mcp/create_folder_tool.gox:19:1
+	nameStr := this.Gop_Env("name").(string)
+//line cmd/ae-mcp/create_folder_tool.gox:21:1
+	parentID := 0
+//line cmd/ae-mcp/create_folder_tool.gox:22:1
+	if this.Gop_Env("parent_folder_id") != nil {
+//line cmd/ae-mcp/create_folder_tool.gox:23:1
+		parentID = int(this.Gop_Env("parent_folder_id").(float64))
+	}
+//line cmd/ae-mcp/create_folder_tool.gox:26:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/create_folder_tool.gox:28:1
+	var err error
+//line cmd/ae-mcp/create_folder_tool.gox:29:1
+	result, err = tools.CreateFolder(nameStr, parentID)
+//line cmd/ae-mcp/create_folder_tool.gox:30:1
+	if err != nil {
+//line cmd/ae-mcp/create_folder_tool.gox:31:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/create_folder_tool.gox:35:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *create_folder) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/describe_effect_tool.gox:6
+// Tool for describing effect parameters
+func (this *describe_effect) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/create_folder_tool.gox:35:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/describe_effect_tool.gox:7:1
+	this.Tool("ae_describe_effect", func() {
+//line cmd/ae-mcp/describe_effect_tool.gox:8:1
+		this.Description("Describe the parameters of an effect: display name, match name, value type (oneD, twoD, threeD, color, popup, layer, mask, group), min and max, popup menu items and default value")
+//line cmd/ae-mcp/describe_effect_tool.gox:9:1
+		this.String("effect_name", func() {
+//line cmd/ae-mcp/describe_effect_tool.gox:10:1
+			this.Description("Display name or match name of the effect, e.g. Gaussian Blur or ADBE Gaussian Blur 2")
+//line cmd/ae-mcp/describe_effect_tool.gox:11:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/describe_effect_tool.gox:16:1
+	effectName := this.Gop_Env("effect_name").(string)
+//line cmd/ae-mcp/describe_effect_tool.gox:18:1
+	// Call the implementation in golang
+	var result tools.EffectDetails
+//line cmd/ae-mcp/describe_effect_tool.gox:20:1
+	result, err := tools.DescribeEffect(effectName)
+//line cmd/ae-mcp/describe_effect_tool.gox:21:1
+	if err != nil {
+//line cmd/ae-mcp/describe_effect_tool.gox:22:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/describe_effect_tool.gox:26:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *describe_effect) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/duplicate_composition_tool.gox:6
+// Tool for duplicating compositions
+func (this *duplicate_composition) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/describe_effect_tool.gox:26:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/duplicate_composition_tool.gox:7:1
+	this.Tool("ae_duplicate_composition", func() {
+//line cmd/ae-mcp/duplicate_composition_tool.gox:8:1
+		this.Description("Duplicate a composition. A deep duplicate also duplicates all nested compositions")
+//line cmd/ae-mcp/duplicate_composition_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/duplicate_composition_tool.gox:10:1
+			this.Description("Name of the composition to duplicate")
+//line cmd/ae-mcp/duplicate_composition_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/duplicate_composition_tool.gox:13:1
+		this.String("new_name", func() {
+//line cmd/ae-mcp/duplicate_composition_tool.gox:14:1
+			this.Description("Name of the duplicate (default: After Effects' automatic name)")
+		})
+//line cmd/ae-mcp/duplicate_composition_tool.gox:16:1
+		this.Bool("deep", func() {
+//line cmd/ae-mcp/duplicate_composition_tool.gox:17:1
+			this.Description("Also duplicate nested compositions instead of sharing them (default: false)")
+		})
+	})
+//line cmd/ae-mcp/duplicate_composition_tool.gox:22:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/duplicate_composition_tool.gox:24:1
+	newName := ""
+//line cmd/ae-mcp/duplicate_composition_tool.gox:25:1
+	if this.Gop_Env("new_name") != nil {
+//line cmd/ae-mcp/duplicate_composition_tool.gox:26:1
+		newName = this.Gop_Env("new_name").(string)
+	}
+//line cmd/ae-mcp/duplicate_composition_tool.gox:29:1
+	deep := false
+//line cmd/ae-mcp/duplicate_composition_tool.gox:30:1
+	if this.Gop_Env("deep") != nil {
+//line cmd/ae-mcp/duplicate_composition_tool.gox:31:1
+		deep = this.Gop_Env("deep").(bool)
+	}
+//line cmd/ae-mcp/duplicate_composition_tool.gox:34:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/duplicate_composition_tool.gox:36:1
+	var err error
+//line cmd/ae-mcp/duplicate_composition_tool.gox:37:1
+	result, err = tools.DuplicateComposition(compName, newName, deep)
+//line cmd/ae-mcp/duplicate_composition_tool.gox:38:1
+	if err != nil {
+//line cmd/ae-mcp/duplicate_composition_tool.gox:39:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/duplicate_composition_tool.gox:43:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *duplicate_composition) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/duplicate_effect_tool.gox:6
+// Tool for duplicating an effect
+func (this *duplicate_effect) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/duplicate_composition_tool.gox:43:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/duplicate_effect_tool.gox:7:1
+	this.Tool("ae_duplicate_effect", func() {
+//line cmd/ae-mcp/duplicate_effect_tool.gox:8:1
+		this.Description("Duplicate an effect on a layer, with its values and keyframes; the copy is placed directly below the original")
+//line cmd/ae-mcp/duplicate_effect_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/duplicate_effect_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/duplicate_effect_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/duplicate_effect_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/duplicate_effect_tool.gox:14:1
+			this.Description("Name of the layer")
+//line cmd/ae-mcp/duplicate_effect_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/duplicate_effect_tool.gox:17:1
+		this.Object("effect", func() {
+//line cmd/ae-mcp/duplicate_effect_tool.gox:18:1
+			this.Description("Effect to duplicate as {index: 1} (position in the stack) or {name: effect name or match name}")
+//line cmd/ae-mcp/duplicate_effect_tool.gox:19:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/duplicate_effect_tool.gox:24:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/duplicate_effect_tool.gox:25:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/duplicate_effect_tool.gox:27:1
+	effect, err := tools.ParseEffectIdentifier(this.Gop_Env("effect").(map[string]interface{}))
+//line cmd/ae-mcp/duplicate_effect_tool.gox:28:1
+	if err != nil {
+//line cmd/ae-mcp/duplicate_effect_tool.gox:29:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/duplicate_effect_tool.gox:34:1
+	// Call the implementation in golang
+	var result tools.EffectDetails
+//line cmd/ae-mcp/duplicate_effect_tool.gox:36:1
+	result, err = tools.DuplicateEffect(compName, layerName, effect)
+//line cmd/ae-mcp/duplicate_effect_tool.gox:37:1
+	if err != nil {
+//line cmd/ae-mcp/duplicate_effect_tool.gox:38:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/duplicate_effect_tool.gox:42:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *duplicate_effect) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/effect_render_report_tool.gox:6
+// Tool for the effect render report
+func (this *effect_render_report) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/duplicate_effect_tool.gox:42:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/effect_render_report_tool.gox:7:1
+	this.Tool("ae_effect_render_report", func() {
+//line cmd/ae-mcp/effect_render_report_tool.gox:8:1
+		this.Description("List, per composition, the effects that clip high-bit-depth color at the project bit depth and the effects without GPU acceleration that force CPU rendering, before rendering")
+//line cmd/ae-mcp/effect_render_report_tool.gox:9:1
+		this.String("random_string", func() {
+//line cmd/ae-mcp/effect_render_report_tool.gox:10:1
+			this.Description("Dummy parameter for no-parameter tools")
+		})
+	})
+//line cmd/ae-mcp/effect_render_report_tool.gox:14:1
+	// Call the implementation in golang
+	var result tools.EffectDetails
+//line cmd/ae-mcp/effect_render_report_tool.gox:16:1
+	result, err := tools.EffectRenderReport()
+//line cmd/ae-mcp/effect_render_report_tool.gox:17:1
+	if err != nil {
+//line cmd/ae-mcp/effect_render_report_tool.gox:18:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/effect_render_report_tool.gox:22:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *effect_render_report) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/get_effect_categories_tool.gox:6
+// Tool for getting available effect categories
+func (this *get_effect_categories) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/effect_render_report_tool.gox:22:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/get_effect_categories_tool.gox:7:1
+	this.Tool("mcp_aftereffects_ae_get_effect_categories", func() {
+//line cmd/ae-mcp/get_effect_categories_tool.gox:8:1
+		this.Description("Get a list of all available effect categories in After Effects")
+//line cmd/ae-mcp/get_effect_categories_tool.gox:9:1
+		this.String("random_string", func() {
+//line cmd/ae-mcp/get_effect_categories_tool.gox:10:1
+			this.Description("Dummy parameter for no-parameter tools")
+		})
+	})
+//line cmd/ae-mcp/get_effect_categories_tool.gox:15:1
+	result, err := tools.MCPGetEffectCategories(map[string]interface{}{})
+//line cmd/ae-mcp/get_effect_categories_tool.gox:16:1
+	if err != nil {
+//line cmd/ae-mcp/get_effect_categories_tool.gox:17:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/get_effect_categories_tool.gox:21:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *get_effect_categories) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:6
+// Tool for getting effects by category
+func (this *get_effects_by_category) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/get_effect_categories_tool.gox:21:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:7:1
+	this.Tool("mcp_aftereffects_ae_get_effects_by_category", func() {
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:8:1
+		this.Description("Get a list of effects in a specified category")
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:9:1
+		this.String("category", func() {
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:10:1
+			this.Description("Name of the effect category to query")
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:11:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:16:1
+	category := this.Gop_Env("category").(string)
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:19:1
 	result, err := tools.MCPGetEffectsByCategory(map[string]interface{}{"category": category})
 //line cmd/ae-mcp/get_effects_by_category_tool.gox:22:1
 	if err != nil {
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:23:1
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:23:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:27:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *get_effects_by_category) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:6
+// Tool for measuring layer bounds
+func (this *get_layer_bounds) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/get_effects_by_category_tool.gox:27:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:7:1
+	this.Tool("ae_get_layer_bounds", func() {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:8:1
+		this.Description("Get the rendered bounds (sourceRectAtTime) of a layer at a time, in layer space and as a composition-space box, with title-safe checks")
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:13:1
+		this.Object("layer_identifier", func() {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:14:1
+			this.Description("Layer identifier, can be in the format {name: 'layer name'} or {index: 1}")
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:17:1
+		this.Float("time", func() {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:18:1
+			this.Description("Time in seconds to measure at (default: current composition time)")
+		})
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:20:1
+		this.Bool("include_extents", func() {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:21:1
+			this.Description("Include stroke, shadow and other extents in the bounds")
+		})
+	})
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:26:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:27:1
+	layerIdentifier := this.Gop_Env("layer_identifier").(map[string]interface{})
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:29:1
+	// Convert to LayerIdentifier struct
+	var identifier tools.LayerIdentifier
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:31:1
+	if
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:31:1
+	name, ok := layerIdentifier["name"].(string); ok && name != "" {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:32:1
+		identifier.Name = name
+	} else
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:33:1
+	if
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:33:1
+	index, ok := layerIdentifier["index"].(float64); ok && index > 0 {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:34:1
+		identifier.Index = int(index)
+	}
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:37:1
+	var atTime *float64
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:38:1
+	if this.Gop_Env("time") != nil {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:39:1
+		t := this.Gop_Env("time").(float64)
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:40:1
+		atTime = &t
+	}
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:43:1
+	includeExtents := false
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:44:1
+	if this.Gop_Env("include_extents") != nil {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:45:1
+		includeExtents = this.Gop_Env("include_extents").(bool)
+	}
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:48:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:50:1
+	var err error
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:51:1
+	result, err = tools.GetLayerBounds(compName, identifier, atTime, includeExtents)
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:52:1
+	if err != nil {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:53:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:57:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *get_layer_bounds) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:6
+// Tool for inspecting light layers
+func (this *get_light_layer_info) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/get_layer_bounds_tool.gox:57:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:7:1
+	this.Tool("ae_get_light_layer_info", func() {
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:8:1
+		this.Description("Read a light layer: light type, intensity, color, cone angle and feather, falloff, shadow settings, position, point of interest and the layer it aims at. Options that do not apply to the light type are null")
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:14:1
+			this.Description("Name of the light layer")
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:15:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:20:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:21:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:23:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:25:1
+	result, err := tools.GetLightLayerInfo(compName, layerName)
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:26:1
+	if err != nil {
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:27:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:31:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *get_light_layer_info) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:6
+// Tool for getting the project item tree
+func (this *get_project_item_tree) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/get_light_layer_info_tool.gox:31:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:7:1
+	this.Tool("ae_get_project_item_tree", func() {
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:8:1
+		this.Description("Get the project panel as a nested tree of folders and items")
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:9:1
+		this.Float("folder_id", func() {
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:10:1
+			this.Description("Id of the folder to start from (default: root folder)")
+		})
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:12:1
+		this.Float("max_depth", func() {
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:13:1
+			this.Description("Maximum folder depth to descend (default: 32)")
+		})
+	})
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:18:1
+	folderID := 0
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:19:1
+	if this.Gop_Env("folder_id") != nil {
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:20:1
+		folderID = int(this.Gop_Env("folder_id").(float64))
+	}
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:23:1
+	maxDepth := 0
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:24:1
+	if this.Gop_Env("max_depth") != nil {
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:25:1
+		maxDepth = int(this.Gop_Env("max_depth").(float64))
+	}
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:28:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:30:1
+	var err error
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:31:1
+	result, err = tools.GetProjectItemTree(folderID, maxDepth)
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:32:1
+	if err != nil {
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:33:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:37:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *get_project_item_tree) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:6
+// Tool for reading shape layer contents
+func (this *get_shape_layer_tree) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/get_project_item_tree_tool.gox:37:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:7:1
+	this.Tool("ae_get_shape_layer_tree", func() {
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:8:1
+		this.Description("Read a shape layer's contents as nested groups with transforms, paths, fills, strokes and operators, in the same format as the groups parameter of ae_add_custom_shape_layer (gradient stops are not readable)")
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:14:1
+			this.Description("Name of the shape layer")
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:15:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:20:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:21:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:23:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:25:1
+	result, err := tools.GetShapeLayerTree(compName, layerName)
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:26:1
+	if err != nil {
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:27:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:31:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *get_shape_layer_tree) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:6
+// Tool for importing SVG drawings as shape layers
+func (this *import_svg_shape_layer) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/get_shape_layer_tree_tool.gox:31:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:7:1
+	this.Tool("ae_import_svg_shape_layer", func() {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:8:1
+		this.Description("Convert an SVG drawing (path, rect, circle, ellipse, line, polyline, polygon, groups with transforms, fill and stroke) into a shape layer with one group per element")
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:10:1
+			this.Description("Name of the composition to add the layer to")
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:14:1
+			this.Description("Name of the new shape layer")
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:17:1
+		this.String("svg", func() {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:18:1
+			this.Description("SVG markup")
+		})
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:20:1
+		this.String("file_path", func() {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:21:1
+			this.Description("Path to an SVG file, used when svg is not given")
+		})
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:23:1
+		this.Float("scale", func() {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:24:1
+			this.Description("Scale applied to SVG coordinates (default: 1)")
+		})
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:26:1
+		this.Array("offset", func() {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:27:1
+			this.Description("Offset [x, y] added to SVG coordinates after scaling")
+		})
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:29:1
+		this.Bool("center", func() {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:30:1
+			this.Description("Center the drawing in the composition instead of using SVG coordinates as composition coordinates")
+		})
+	})
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:35:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:36:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:38:1
+	options := tools.SVGImportOptions{}
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:39:1
+	if this.Gop_Env("svg") != nil {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:40:1
+		options.SVG = this.Gop_Env("svg").(string)
+	}
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:42:1
+	if this.Gop_Env("file_path") != nil {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:43:1
+		options.FilePath = this.Gop_Env("file_path").(string)
+	}
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:45:1
+	if this.Gop_Env("scale") != nil {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:46:1
+		options.Scale = this.Gop_Env("scale").(float64)
+	}
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:48:1
+	if this.Gop_Env("offset") != nil {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:49:1
+		if
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:49:1
+		offset, ok := this.Gop_Env("offset").([]interface{}); ok && len(offset) >= 2 {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:50:1
+			x, _ := offset[0].(float64)
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:51:1
+			y, _ := offset[1].(float64)
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:52:1
+			options.Offset = [2]float64{x, y}
+		}
+	}
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:55:1
+	if this.Gop_Env("center") != nil {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:56:1
+		options.Center = this.Gop_Env("center").(bool)
+	}
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:59:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:61:1
+	var err error
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:62:1
+	result, err = tools.ImportSVGShapeLayer(compName, layerName, options)
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:63:1
+	if err != nil {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:64:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:68:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *import_svg_shape_layer) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/list_fonts_tool.gox:6
+// Tool for listing installed fonts and resolving font names
+func (this *list_fonts) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/import_svg_shape_layer_tool.gox:68:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/list_fonts_tool.gox:7:1
+	this.Tool("ae_list_fonts", func() {
+//line cmd/ae-mcp/list_fonts_tool.gox:8:1
+		this.Description("List installed fonts with family, style and PostScript name (After Effects 24.0+). Optionally resolve a family and style to a PostScript name")
+//line cmd/ae-mcp/list_fonts_tool.gox:9:1
+		this.String("filter", func() {
+//line cmd/ae-mcp/list_fonts_tool.gox:10:1
+			this.Description("Only list fonts whose family, style, full or PostScript name contains this text")
+		})
+//line cmd/ae-mcp/list_fonts_tool.gox:12:1
+		this.String("resolve_font", func() {
+//line cmd/ae-mcp/list_fonts_tool.gox:13:1
+			this.Description("Font family, full or PostScript name to resolve to an installed PostScript name (typos are fuzzy-matched)")
+		})
+//line cmd/ae-mcp/list_fonts_tool.gox:15:1
+		this.String("resolve_style", func() {
+//line cmd/ae-mcp/list_fonts_tool.gox:16:1
+			this.Description("Style to resolve together with resolve_font, e.g. Bold")
+		})
+//line cmd/ae-mcp/list_fonts_tool.gox:18:1
+		this.Bool("refresh", func() {
+//line cmd/ae-mcp/list_fonts_tool.gox:19:1
+			this.Description("Re-enumerate fonts instead of using the cached list")
+		})
+	})
+//line cmd/ae-mcp/list_fonts_tool.gox:24:1
+	filter := ""
+//line cmd/ae-mcp/list_fonts_tool.gox:25:1
+	if this.Gop_Env("filter") != nil {
+//line cmd/ae-mcp/list_fonts_tool.gox:26:1
+		filter = this.Gop_Env("filter").(string)
+	}
+//line cmd/ae-mcp/list_fonts_tool.gox:29:1
+	refresh := false
+//line cmd/ae-mcp/list_fonts_tool.gox:30:1
+	if this.Gop_Env("refresh") != nil {
+//line cmd/ae-mcp/list_fonts_tool.gox:31:1
+		refresh = this.Gop_Env("refresh").(bool)
+	}
+//line cmd/ae-mcp/list_fonts_tool.gox:35:1
+	fonts, err := tools.ListFonts(filter, refresh)
+//line cmd/ae-mcp/list_fonts_tool.gox:36:1
+	if err != nil {
+//line cmd/ae-mcp/list_fonts_tool.gox:37:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/list_fonts_tool.gox:42:1
+	result := map[string]interface{}{"fonts": fonts, "count": len(fonts)}
+//line cmd/ae-mcp/list_fonts_tool.gox:47:1
+	if this.Gop_Env("resolve_font") != nil {
+//line cmd/ae-mcp/list_fonts_tool.gox:48:1
+		style := ""
+//line cmd/ae-mcp/list_fonts_tool.gox:49:1
+		if this.Gop_Env("resolve_style") != nil {
+//line cmd/ae-mcp/list_fonts_tool.gox:50:1
+			style = this.Gop_Env("resolve_style").(string)
+		}
+//line cmd/ae-mcp/list_fonts_tool.gox:52:1
+		resolution, err := tools.ResolveFont(this.Gop_Env("resolve_font").(string), style)
+//line cmd/ae-mcp/list_fonts_tool.gox:53:1
+		if err != nil {
+//line cmd/ae-mcp/list_fonts_tool.gox:54:1
+			result["resolveError"] = err.Error()
+		} else {
+//line cmd/ae-mcp/list_fonts_tool.gox:56:1
+			result["resolved"] = resolution
+		}
+	}
+//line cmd/ae-mcp/list_fonts_tool.gox:60:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *list_fonts) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/list_layer_effects_tool.gox:6
+// Tool for listing layer effects
+func (this *list_layer_effects) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/list_fonts_tool.gox:60:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/list_layer_effects_tool.gox:7:1
+	this.Tool("ae_list_layer_effects", func() {
+//line cmd/ae-mcp/list_layer_effects_tool.gox:8:1
+		this.Description("List the effects on a layer in stack order with index, name, match name, enabled state and each parameter with its current value, keyframe count and expression")
+//line cmd/ae-mcp/list_layer_effects_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/list_layer_effects_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/list_layer_effects_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/list_layer_effects_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/list_layer_effects_tool.gox:14:1
+			this.Description("Name of the layer")
+//line cmd/ae-mcp/list_layer_effects_tool.gox:15:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/list_layer_effects_tool.gox:20:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/list_layer_effects_tool.gox:21:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/list_layer_effects_tool.gox:23:1
+	// Call the implementation in golang
+	var result tools.EffectDetails
+//line cmd/ae-mcp/list_layer_effects_tool.gox:25:1
+	result, err := tools.ListLayerEffects(compName, layerName)
+//line cmd/ae-mcp/list_layer_effects_tool.gox:26:1
+	if err != nil {
+//line cmd/ae-mcp/list_layer_effects_tool.gox:27:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/list_layer_effects_tool.gox:31:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *list_layer_effects) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/list_project_items_tool.gox:6
+// Tool for listing project items with filtering and pagination
+func (this *list_project_items) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/list_layer_effects_tool.gox:31:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/list_project_items_tool.gox:7:1
+	this.Tool("ae_list_project_items", func() {
+//line cmd/ae-mcp/list_project_items_tool.gox:8:1
+		this.Description("List or search project panel items (folders, footage, solids, compositions) with their parent folders. Supports filtering by type, name and label, and pagination for large projects")
+//line cmd/ae-mcp/list_project_items_tool.gox:9:1
+		this.String("item_type", func() {
+//line cmd/ae-mcp/list_project_items_tool.gox:10:1
+			this.Description("Only list items of this type (Folder, Composition, Footage, Solid, Placeholder)")
+		})
+//line cmd/ae-mcp/list_project_items_tool.gox:12:1
+		this.String("name", func() {
+//line cmd/ae-mcp/list_project_items_tool.gox:13:1
+			this.Description("Only list items whose name contains this text (case-insensitive)")
+		})
+//line cmd/ae-mcp/list_project_items_tool.gox:15:1
+		this.Float("label", func() {
+//line cmd/ae-mcp/list_project_items_tool.gox:16:1
+			this.Description("Only list items with this label color index (0-16)")
+		})
+//line cmd/ae-mcp/list_project_items_tool.gox:18:1
+		this.Float("parent_folder_id", func() {
+//line cmd/ae-mcp/list_project_items_tool.gox:19:1
+			this.Description("Only list items inside the folder with this id")
+		})
+//line cmd/ae-mcp/list_project_items_tool.gox:21:1
+		this.Bool("recursive", func() {
+//line cmd/ae-mcp/list_project_items_tool.gox:22:1
+			this.Description("Include items in subfolders of parent_folder_id")
+		})
+//line cmd/ae-mcp/list_project_items_tool.gox:24:1
+		this.Float("offset", func() {
+//line cmd/ae-mcp/list_project_items_tool.gox:25:1
+			this.Description("Index of the first matching item to return (default: 0)")
+		})
+//line cmd/ae-mcp/list_project_items_tool.gox:27:1
+		this.Float("limit", func() {
+//line cmd/ae-mcp/list_project_items_tool.gox:28:1
+			this.Description("Maximum number of items to return (default: 100)")
+		})
+	})
+//line cmd/ae-mcp/list_project_items_tool.gox:32:1
+	// Convert parameters to a query
+	var query tools.ProjectItemQuery
+//line cmd/ae-mcp/list_project_items_tool.gox:34:1
+	if this.Gop_Env("item_type") != nil {
+//line cmd/ae-mcp/list_project_items_tool.gox:35:1
+		query.Type = this.Gop_Env("item_type").(string)
+	}
+//line cmd/ae-mcp/list_project_items_tool.gox:37:1
+	if this.Gop_Env("name") != nil {
+//line cmd/ae-mcp/list_project_items_tool.gox:38:1
+		query.Name = this.Gop_Env("name").(string)
+	}
+//line cmd/ae-mcp/list_project_items_tool.gox:40:1
+	if this.Gop_Env("label") != nil {
+//line cmd/ae-mcp/list_project_items_tool.gox:41:1
+		label := int(this.Gop_Env("label").(float64))
+//line cmd/ae-mcp/list_project_items_tool.gox:42:1
+		query.Label = &label
+	}
+//line cmd/ae-mcp/list_project_items_tool.gox:44:1
+	if this.Gop_Env("parent_folder_id") != nil {
+//line cmd/ae-mcp/list_project_items_tool.gox:45:1
+		query.ParentFolderID = int(this.Gop_Env("parent_folder_id").(float64))
+	}
+//line cmd/ae-mcp/list_project_items_tool.gox:47:1
+	if this.Gop_Env("recursive") != nil {
+//line cmd/ae-mcp/list_project_items_tool.gox:48:1
+		query.Recursive = this.Gop_Env("recursive").(bool)
+	}
+//line cmd/ae-mcp/list_project_items_tool.gox:50:1
+	if this.Gop_Env("offset") != nil {
+//line cmd/ae-mcp/list_project_items_tool.gox:51:1
+		query.Offset = int(this.Gop_Env("offset").(float64))
+	}
+//line cmd/ae-mcp/list_project_items_tool.gox:53:1
+	if this.Gop_Env("limit") != nil {
+//line cmd/ae-mcp/list_project_items_tool.gox:54:1
+		query.Limit = int(this.Gop_Env("limit").(float64))
+	}
+//line cmd/ae-mcp/list_project_items_tool.gox:57:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/list_project_items_tool.gox:59:1
+	var err error
+//line cmd/ae-mcp/list_project_items_tool.gox:60:1
+	result, err = tools.ListProjectItems(query)
+//line cmd/ae-mcp/list_project_items_tool.gox:61:1
+	if err != nil {
+//line cmd/ae-mcp/list_project_items_tool.gox:62:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/list_project_items_tool.gox:66:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *list_project_items) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/list_recipes_tool.gox:6
+// Tool for listing effect recipes
+func (this *list_recipes) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/list_project_items_tool.gox:66:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/list_recipes_tool.gox:7:1
+	this.Tool("ae_list_recipes", func() {
+//line cmd/ae-mcp/list_recipes_tool.gox:8:1
+		this.Description("List the available effect recipes: built-in recipes and JSON or YAML recipe files in the recipes folder, with their effects and parameters")
+//line cmd/ae-mcp/list_recipes_tool.gox:9:1
+		this.String("random_string", func() {
+//line cmd/ae-mcp/list_recipes_tool.gox:10:1
+			this.Description("Dummy parameter for no-parameter tools")
+		})
+	})
+//line cmd/ae-mcp/list_recipes_tool.gox:15:1
+	recipes, problems, err := tools.ListEffectRecipes()
+//line cmd/ae-mcp/list_recipes_tool.gox:16:1
+	if err != nil {
+//line cmd/ae-mcp/list_recipes_tool.gox:17:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/list_recipes_tool.gox:21:1
+	folder, _ := tools.EffectRecipeDir()
+//line cmd/ae-mcp/list_recipes_tool.gox:22:1
+	return server.Text__1(server.JsonContent{JSON: map[string]interface{}{"recipes": recipes, "folder": folder, "problems": problems}})
+}
+func (this *list_recipes) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/modify_composition_tool.gox:6
+// Tool for modifying composition settings
+func (this *modify_composition) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/list_recipes_tool.gox:22:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/modify_composition_tool.gox:7:1
+	this.Tool("ae_modify_composition", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:8:1
+		this.Description("Change the settings of an existing composition (size, pixel aspect, duration, frame rate, background color, work area, motion blur, 3D renderer, nested resolution)")
+//line cmd/ae-mcp/modify_composition_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:10:1
+			this.Description("Name of the composition to modify")
+//line cmd/ae-mcp/modify_composition_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:13:1
+		this.String("new_name", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:14:1
+			this.Description("New name for the composition")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:16:1
+		this.Float("width", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:17:1
+			this.Description("New width (pixels)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:19:1
+		this.Float("height", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:20:1
+			this.Description("New height (pixels)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:22:1
+		this.String("anchor", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:23:1
+			this.Description("Where existing layers stay pinned when resizing (top-left, top, top-right, left, center, right, bottom-left, bottom, bottom-right)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:25:1
+		this.Float("pixel_aspect", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:26:1
+			this.Description("Pixel aspect ratio (e.g. 1 for square pixels)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:28:1
+		this.Float("duration", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:29:1
+			this.Description("Duration (seconds)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:31:1
+		this.Float("frame_rate", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:32:1
+			this.Description("Frame rate")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:34:1
+		this.Array("bg_color", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:35:1
+			this.Description("Background color as [R, G, B], with values ranging from 0-1")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:37:1
+		this.Float("work_area_start", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:38:1
+			this.Description("Work area start time (seconds)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:40:1
+		this.Float("work_area_duration", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:41:1
+			this.Description("Work area duration (seconds)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:43:1
+		this.Bool("motion_blur", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:44:1
+			this.Description("Enable motion blur for the composition")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:46:1
+		this.Float("shutter_angle", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:47:1
+			this.Description("Motion blur shutter angle (0-720)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:49:1
+		this.Float("shutter_phase", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:50:1
+			this.Description("Motion blur shutter phase (-360 to 360)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:52:1
+		this.String("renderer", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:53:1
+			this.Description("3D renderer (Classic 3D, Cinema 4D, Advanced 3D, Ray-traced 3D, or a renderer match name)")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:55:1
+		this.Bool("preserve_resolution", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:56:1
+			this.Description("Preserve resolution when nested")
+		})
+//line cmd/ae-mcp/modify_composition_tool.gox:58:1
+		this.Bool("preserve_frame_rate", func() {
+//line cmd/ae-mcp/modify_composition_tool.gox:59:1
+			this.Description("Preserve frame rate when nested")
+		})
+	})
+//line cmd/ae-mcp/modify_composition_tool.gox:64:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/modify_composition_tool.gox:66:1
+	var settings tools.CompositionSettings
+//line cmd/ae-mcp/modify_composition_tool.gox:67:1
+	if this.Gop_Env("new_name") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:68:1
+		newName := this.Gop_Env("new_name").(string)
+//line cmd/ae-mcp/modify_composition_tool.gox:69:1
+		settings.Name = &newName
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:71:1
+	if this.Gop_Env("width") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:72:1
+		width := int(this.Gop_Env("width").(float64))
+//line cmd/ae-mcp/modify_composition_tool.gox:73:1
+		settings.Width = &width
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:75:1
+	if this.Gop_Env("height") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:76:1
+		height := int(this.Gop_Env("height").(float64))
+//line cmd/ae-mcp/modify_composition_tool.gox:77:1
+		settings.Height = &height
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:79:1
+	if this.Gop_Env("anchor") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:80:1
+		settings.Anchor = this.Gop_Env("anchor").(string)
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:82:1
+	if this.Gop_Env("pixel_aspect") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:83:1
+		pixelAspect := this.Gop_Env("pixel_aspect").(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:84:1
+		settings.PixelAspect = &pixelAspect
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:86:1
+	if this.Gop_Env("duration") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:87:1
+		duration := this.Gop_Env("duration").(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:88:1
+		settings.Duration = &duration
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:90:1
+	if this.Gop_Env("frame_rate") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:91:1
+		frameRate := this.Gop_Env("frame_rate").(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:92:1
+		settings.FrameRate = &frameRate
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:94:1
+	if this.Gop_Env("bg_color") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:95:1
+		colorArray := this.Gop_Env("bg_color").([]interface{})
+//line cmd/ae-mcp/modify_composition_tool.gox:96:1
+		if len(colorArray) >= 3 {
+//line cmd/ae-mcp/modify_composition_tool.gox:97:1
+			r, _ := colorArray[0].(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:98:1
+			g, _ := colorArray[1].(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:99:1
+			b, _ := colorArray[2].(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:100:1
+			settings.BgColor = &tools.ColorRGB{r, g, b}
+		}
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:103:1
+	if this.Gop_Env("work_area_start") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:104:1
+		workAreaStart := this.Gop_Env("work_area_start").(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:105:1
+		settings.WorkAreaStart = &workAreaStart
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:107:1
+	if this.Gop_Env("work_area_duration") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:108:1
+		workAreaDuration := this.Gop_Env("work_area_duration").(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:109:1
+		settings.WorkAreaDuration = &workAreaDuration
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:111:1
+	if this.Gop_Env("motion_blur") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:112:1
+		motionBlur := this.Gop_Env("motion_blur").(bool)
+//line cmd/ae-mcp/modify_composition_tool.gox:113:1
+		settings.MotionBlur = &motionBlur
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:115:1
+	if this.Gop_Env("shutter_angle") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:116:1
+		shutterAngle := this.Gop_Env("shutter_angle").(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:117:1
+		settings.ShutterAngle = &shutterAngle
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:119:1
+	if this.Gop_Env("shutter_phase") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:120:1
+		shutterPhase := this.Gop_Env("shutter_phase").(float64)
+//line cmd/ae-mcp/modify_composition_tool.gox:121:1
+		settings.ShutterPhase = &shutterPhase
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:123:1
+	if this.Gop_Env("renderer") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:124:1
+		settings.Renderer = this.Gop_Env("renderer").(string)
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:126:1
+	if this.Gop_Env("preserve_resolution") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:127:1
+		preserveResolution := this.Gop_Env("preserve_resolution").(bool)
+//line cmd/ae-mcp/modify_composition_tool.gox:128:1
+		settings.PreserveResolution = &preserveResolution
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:130:1
+	if this.Gop_Env("preserve_frame_rate") != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:131:1
+		preserveFrameRate := this.Gop_Env("preserve_frame_rate").(bool)
+//line cmd/ae-mcp/modify_composition_tool.gox:132:1
+		settings.PreserveFrameRate = &preserveFrameRate
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:135:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/modify_composition_tool.gox:137:1
+	var err error
+//line cmd/ae-mcp/modify_composition_tool.gox:138:1
+	result, err = tools.ModifyComposition(compName, settings)
+//line cmd/ae-mcp/modify_composition_tool.gox:139:1
+	if err != nil {
+//line cmd/ae-mcp/modify_composition_tool.gox:140:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/modify_composition_tool.gox:144:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *modify_composition) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/modify_effect_tool.gox:6
+// Tool for modifying effects
+func (this *modify_effect) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/modify_composition_tool.gox:144:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/modify_effect_tool.gox:7:1
+	this.Tool("ae_modify_effect", func() {
+//line cmd/ae-mcp/modify_effect_tool.gox:8:1
+		this.Description("Change an effect already on a layer: set parameters, keyframe parameters, enable or disable it, or rename it. Parameters are validated and reported as applied or failed")
+//line cmd/ae-mcp/modify_effect_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/modify_effect_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/modify_effect_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_effect_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/modify_effect_tool.gox:14:1
+			this.Description("Name of the layer")
+//line cmd/ae-mcp/modify_effect_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_effect_tool.gox:17:1
+		this.Object("effect", func() {
+//line cmd/ae-mcp/modify_effect_tool.gox:18:1
+			this.Description("Effect to change as {index: 1} (position in the stack) or {name: effect name or match name}")
+//line cmd/ae-mcp/modify_effect_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_effect_tool.gox:21:1
+		this.Object("parameters", func() {
+//line cmd/ae-mcp/modify_effect_tool.gox:22:1
+			this.Description("Parameter values keyed by parameter display name or match name (see ae_describe_effect)")
+		})
+//line cmd/ae-mcp/modify_effect_tool.gox:24:1
+		this.Object("keyframes", func() {
+//line cmd/ae-mcp/modify_effect_tool.gox:25:1
+			this.Description("Keyframes keyed by parameter display name or match name, each a list of {time, value, ease, hold} with time in composition seconds, the same format as layer transform keyframes")
+		})
+//line cmd/ae-mcp/modify_effect_tool.gox:27:1
+		this.Bool("enabled", func() {
+//line cmd/ae-mcp/modify_effect_tool.gox:28:1
+			this.Description("Enable or disable the effect")
+		})
+//line cmd/ae-mcp/modify_effect_tool.gox:30:1
+		this.String("new_name", func() {
+//line cmd/ae-mcp/modify_effect_tool.gox:31:1
+			this.Description("New name for the effect instance")
+		})
+	})
+//line cmd/ae-mcp/modify_effect_tool.gox:36:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/modify_effect_tool.gox:37:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/modify_effect_tool.gox:39:1
+	effect, err := tools.ParseEffectIdentifier(this.Gop_Env("effect").(map[string]interface{}))
+//line cmd/ae-mcp/modify_effect_tool.gox:40:1
+	if err != nil {
+//line cmd/ae-mcp/modify_effect_tool.gox:41:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/modify_effect_tool.gox:46:1
+	var changes tools.EffectChanges
+//line cmd/ae-mcp/modify_effect_tool.gox:47:1
+	if this.Gop_Env("parameters") != nil {
+//line cmd/ae-mcp/modify_effect_tool.gox:48:1
+		changes.Parameters = this.Gop_Env("parameters").(map[string]interface{})
+	}
+//line cmd/ae-mcp/modify_effect_tool.gox:50:1
+	if this.Gop_Env("keyframes") != nil {
+//line cmd/ae-mcp/modify_effect_tool.gox:51:1
+		changes.Keyframes, err = tools.ParsePropertyKeyframes(this.Gop_Env("keyframes").(map[string]interface{}))
+//line cmd/ae-mcp/modify_effect_tool.gox:52:1
+		if err != nil {
+//line cmd/ae-mcp/modify_effect_tool.gox:53:1
+			return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+		}
+	}
+//line cmd/ae-mcp/modify_effect_tool.gox:58:1
+	if this.Gop_Env("enabled") != nil {
+//line cmd/ae-mcp/modify_effect_tool.gox:59:1
+		enabled := this.Gop_Env("enabled").(bool)
+//line cmd/ae-mcp/modify_effect_tool.gox:60:1
+		changes.Enabled = &enabled
+	}
+//line cmd/ae-mcp/modify_effect_tool.gox:62:1
+	if this.Gop_Env("new_name") != nil {
+//line cmd/ae-mcp/modify_effect_tool.gox:63:1
+		changes.Name = this.Gop_Env("new_name").(string)
+	}
+//line cmd/ae-mcp/modify_effect_tool.gox:66:1
+	// Call the implementation in golang
+	var result tools.EffectDetails
+//line cmd/ae-mcp/modify_effect_tool.gox:68:1
+	result, err = tools.ModifyEffect(compName, layerName, effect, changes)
+//line cmd/ae-mcp/modify_effect_tool.gox:69:1
+	if err != nil {
+//line cmd/ae-mcp/modify_effect_tool.gox:70:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/modify_effect_tool.gox:74:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *modify_effect) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/modify_layer_tool.gox:6
+// Tool for modifying layer properties
+func (this *modify_layer) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/modify_effect_tool.gox:74:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/modify_layer_tool.gox:7:1
+	this.Tool("ae_modify_layer", func() {
+//line cmd/ae-mcp/modify_layer_tool.gox:8:1
+		this.Description("Modify properties of an existing layer")
+//line cmd/ae-mcp/modify_layer_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/modify_layer_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/modify_layer_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_layer_tool.gox:13:1
+		this.Object("layer_identifier", func() {
+//line cmd/ae-mcp/modify_layer_tool.gox:14:1
+			this.Description("Layer identifier, can be in the format {name: 'layer name'} or {index: 1}")
+//line cmd/ae-mcp/modify_layer_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_layer_tool.gox:17:1
+		this.Object("properties", func() {
+//line cmd/ae-mcp/modify_layer_tool.gox:18:1
+			this.Description("Properties object to modify: position, scale, rotation, opacity, etc.; keyframes as {position: [{time, value, ease, hold}], ...} for anchorPoint, position, scale, rotation and opacity, with time in composition seconds")
+//line cmd/ae-mcp/modify_layer_tool.gox:19:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/modify_layer_tool.gox:24:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/modify_layer_tool.gox:25:1
+	layerIdentifier := this.Gop_Env("layer_identifier").(map[string]interface{})
+//line cmd/ae-mcp/modify_layer_tool.gox:26:1
+	properties := this.Gop_Env("properties").(map[string]interface{})
+//line cmd/ae-mcp/modify_layer_tool.gox:28:1
+	// Convert to LayerIdentifier struct
+	var identifier tools.LayerIdentifier
+//line cmd/ae-mcp/modify_layer_tool.gox:30:1
+	if
+//line cmd/ae-mcp/modify_layer_tool.gox:30:1
+	name, ok := layerIdentifier["name"].(string); ok && name != "" {
+//line cmd/ae-mcp/modify_layer_tool.gox:31:1
+		identifier.Name = name
+	} else
+//line cmd/ae-mcp/modify_layer_tool.gox:32:1
+	if
+//line cmd/ae-mcp/modify_layer_tool.gox:32:1
+	index, ok := layerIdentifier["index"].(float64); ok && index > 0 {
+//line cmd/ae-mcp/modify_layer_tool.gox:33:1
+		identifier.Index = int(index)
+	}
+//line cmd/ae-mcp/modify_layer_tool.gox:36:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/modify_layer_tool.gox:38:1
+	var err error
+//line cmd/ae-mcp/modify_layer_tool.gox:39:1
+	result, err = tools.ModifyLayer(compName, identifier, properties)
+//line cmd/ae-mcp/modify_layer_tool.gox:40:1
+	if err != nil {
+//line cmd/ae-mcp/modify_layer_tool.gox:41:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/modify_layer_tool.gox:45:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *modify_layer) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/modify_light_tool.gox:6
+// Tool for modifying light layers
+func (this *modify_light) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/modify_layer_tool.gox:45:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/modify_light_tool.gox:7:1
+	this.Tool("ae_modify_light", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:8:1
+		this.Description("Change an existing light layer: light type, intensity, color, cone, falloff, shadows, position and point of interest, including aiming the light at another layer. Options that do not apply to the light type are listed as skipped")
+//line cmd/ae-mcp/modify_light_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/modify_light_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:14:1
+			this.Description("Name of the light layer")
+//line cmd/ae-mcp/modify_light_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:17:1
+		this.String("light_type", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:18:1
+			this.Description("Change the light type (Parallel, Spot, Point, Ambient); applied before the other settings")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:20:1
+		this.Float("intensity", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:21:1
+			this.Description("Intensity in percent (100 is normal)")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:23:1
+		this.Array("color", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:24:1
+			this.Description("RGB color array [R, G, B], with values ranging from 0-1")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:26:1
+		this.Float("cone_angle", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:27:1
+			this.Description("Cone angle in degrees (spot lights)")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:29:1
+		this.Float("cone_feather", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:30:1
+			this.Description("Cone feather in percent (spot lights)")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:32:1
+		this.String("falloff", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:33:1
+			this.Description("Falloff (None, Smooth, Inverse Square Clamped) for spot and point lights")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:35:1
+		this.Float("radius", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:36:1
+			this.Description("Falloff radius in pixels, where falloff starts")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:38:1
+		this.Float("falloff_distance", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:39:1
+			this.Description("Falloff distance in pixels (Smooth falloff)")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:41:1
+		this.Bool("casts_shadows", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:42:1
+			this.Description("Whether the light casts shadows from layers that cast shadows")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:44:1
+		this.Float("shadow_darkness", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:45:1
+			this.Description("Shadow darkness in percent")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:47:1
+		this.Float("shadow_diffusion", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:48:1
+			this.Description("Shadow diffusion (softness) in pixels")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:50:1
+		this.Array("position", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:51:1
+			this.Description("Light position [x, y, z]")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:53:1
+		this.Array("point_of_interest", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:54:1
+			this.Description("Point of interest [x, y, z] for parallel and spot lights")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:56:1
+		this.String("point_of_interest_layer", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:57:1
+			this.Description("Aim the light at this layer by linking its point of interest with an expression; an empty string removes the link")
+		})
+//line cmd/ae-mcp/modify_light_tool.gox:59:1
+		this.Bool("auto_orient", func() {
+//line cmd/ae-mcp/modify_light_tool.gox:60:1
+			this.Description("Orient towards the point of interest (true) or ignore it (false)")
+		})
+	})
+//line cmd/ae-mcp/modify_light_tool.gox:65:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/modify_light_tool.gox:66:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/modify_light_tool.gox:69:1
+	options := map[string]interface{}{}
+//line cmd/ae-mcp/modify_light_tool.gox:70:1
+	if this.Gop_Env("light_type") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:71:1
+		options["lightType"] = this.Gop_Env("light_type")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:73:1
+	if this.Gop_Env("intensity") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:74:1
+		options["intensity"] = this.Gop_Env("intensity")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:76:1
+	if this.Gop_Env("color") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:77:1
+		options["color"] = this.Gop_Env("color")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:79:1
+	if this.Gop_Env("cone_angle") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:80:1
+		options["coneAngle"] = this.Gop_Env("cone_angle")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:82:1
+	if this.Gop_Env("cone_feather") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:83:1
+		options["coneFeather"] = this.Gop_Env("cone_feather")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:85:1
+	if this.Gop_Env("falloff") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:86:1
+		options["falloff"] = this.Gop_Env("falloff")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:88:1
+	if this.Gop_Env("radius") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:89:1
+		options["radius"] = this.Gop_Env("radius")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:91:1
+	if this.Gop_Env("falloff_distance") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:92:1
+		options["falloffDistance"] = this.Gop_Env("falloff_distance")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:94:1
+	if this.Gop_Env("casts_shadows") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:95:1
+		options["castsShadows"] = this.Gop_Env("casts_shadows")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:97:1
+	if this.Gop_Env("shadow_darkness") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:98:1
+		options["shadowDarkness"] = this.Gop_Env("shadow_darkness")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:100:1
+	if this.Gop_Env("shadow_diffusion") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:101:1
+		options["shadowDiffusion"] = this.Gop_Env("shadow_diffusion")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:103:1
+	if this.Gop_Env("position") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:104:1
+		options["position"] = this.Gop_Env("position")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:106:1
+	if this.Gop_Env("point_of_interest") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:107:1
+		options["pointOfInterest"] = this.Gop_Env("point_of_interest")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:109:1
+	if this.Gop_Env("point_of_interest_layer") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:110:1
+		options["pointOfInterestLayer"] = this.Gop_Env("point_of_interest_layer")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:112:1
+	if this.Gop_Env("auto_orient") != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:113:1
+		options["autoOrient"] = this.Gop_Env("auto_orient")
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:116:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/modify_light_tool.gox:118:1
+	result, err := tools.ModifyLightProperties(compName, layerName, options)
+//line cmd/ae-mcp/modify_light_tool.gox:119:1
+	if err != nil {
+//line cmd/ae-mcp/modify_light_tool.gox:120:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/modify_light_tool.gox:124:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *modify_light) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/modify_text_tool.gox:6
+// Tool for modifying text layers
+func (this *modify_text) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/modify_light_tool.gox:124:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/modify_text_tool.gox:7:1
+	this.Tool("ae_modify_text_layer", func() {
+//line cmd/ae-mcp/modify_text_tool.gox:8:1
+		this.Description("Modify an existing text layer in a composition")
+//line cmd/ae-mcp/modify_text_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/modify_text_tool.gox:10:1
+			this.Description("Name of the composition containing the text layer")
+//line cmd/ae-mcp/modify_text_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_text_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/modify_text_tool.gox:14:1
+			this.Description("Name of the text layer to modify")
+//line cmd/ae-mcp/modify_text_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/modify_text_tool.gox:17:1
+		this.Object("modifications", func() {
+//line cmd/ae-mcp/modify_text_tool.gox:18:1
+			this.Description("Text properties to modify (text, fontSize, fontName, color, boxSize, firstLineIndent, spaceBefore, spaceAfter, vertical, path, markup for inline styling like 'Hello <b>World</b>', styles array of character runs {start, length or text, fontName, fontSize, fillColor, fauxBold, ...}, autoFit {mode, box, minFontSize, keepInside} to shrink or wrap into a box or the title-safe area, etc.)")
+//line cmd/ae-mcp/modify_text_tool.gox:19:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/modify_text_tool.gox:24:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/modify_text_tool.gox:25:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/modify_text_tool.gox:26:1
+	modifications := this.Gop_Env("modifications").(map[string]interface{})
+//line cmd/ae-mcp/modify_text_tool.gox:28:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/modify_text_tool.gox:30:1
+	var err error
+//line cmd/ae-mcp/modify_text_tool.gox:31:1
+	result, err = tools.ModifyTextLayer(compName, layerName, modifications)
+//line cmd/ae-mcp/modify_text_tool.gox:32:1
+	if err != nil {
+//line cmd/ae-mcp/modify_text_tool.gox:33:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/modify_text_tool.gox:37:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *modify_text) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/morph_shape_tool.gox:6
+// Tool for keyframing a path morph
+func (this *morph_shape) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/modify_text_tool.gox:37:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/morph_shape_tool.gox:7:1
+	this.Tool("ae_morph_shape", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:8:1
+		this.Description("Animate a shape layer path from one shape into another. Both shapes are subdivided to a common vertex count and the target's winding and starting vertex are aligned to minimize vertex travel before path keyframes are written. Creates a filled shape layer when the layer does not exist")
+//line cmd/ae-mcp/morph_shape_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:10:1
+			this.Description("Name of the composition")
+//line cmd/ae-mcp/morph_shape_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/morph_shape_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:14:1
+			this.Description("Name of the shape layer; created if it does not exist")
+//line cmd/ae-mcp/morph_shape_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/morph_shape_tool.gox:17:1
+		this.Object("from_shape", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:18:1
+			this.Description("Starting shape {vertices: [[x, y], ...], inTangents: [[x, y], ...], outTangents: [[x, y], ...], closed: true}; tangents are relative to their vertex")
+//line cmd/ae-mcp/morph_shape_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/morph_shape_tool.gox:21:1
+		this.Object("to_shape", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:22:1
+			this.Description("Ending shape, in the same format as from_shape")
+//line cmd/ae-mcp/morph_shape_tool.gox:23:1
+			this.Required()
+		})
+//line cmd/ae-mcp/morph_shape_tool.gox:25:1
+		this.Float("start_time", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:26:1
+			this.Description("Time of the first keyframe in seconds")
+//line cmd/ae-mcp/morph_shape_tool.gox:27:1
+			this.Required()
+		})
+//line cmd/ae-mcp/morph_shape_tool.gox:29:1
+		this.Float("end_time", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:30:1
+			this.Description("Time of the last keyframe in seconds")
+//line cmd/ae-mcp/morph_shape_tool.gox:31:1
+			this.Required()
+		})
+//line cmd/ae-mcp/morph_shape_tool.gox:33:1
+		this.Float("path_index", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:34:1
+			this.Description("1-based index of the path on the layer, in contents order (default: 1)")
+		})
+//line cmd/ae-mcp/morph_shape_tool.gox:36:1
+		this.Float("min_vertices", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:37:1
+			this.Description("Subdivide both shapes to at least this many vertices for a smoother morph")
+		})
+//line cmd/ae-mcp/morph_shape_tool.gox:39:1
+		this.Bool("ease", func() {
+//line cmd/ae-mcp/morph_shape_tool.gox:40:1
+			this.Description("Apply easy ease to both keyframes (default: true)")
+		})
+	})
+//line cmd/ae-mcp/morph_shape_tool.gox:45:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/morph_shape_tool.gox:46:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/morph_shape_tool.gox:48:1
+	fromShape, err := tools.ParseShapeData(this.Gop_Env("from_shape").(map[string]interface{}))
+//line cmd/ae-mcp/morph_shape_tool.gox:49:1
+	if err != nil {
+//line cmd/ae-mcp/morph_shape_tool.gox:50:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/morph_shape_tool.gox:54:1
+	toShape, err := tools.ParseShapeData(this.Gop_Env("to_shape").(map[string]interface{}))
+//line cmd/ae-mcp/morph_shape_tool.gox:55:1
+	if err != nil {
+//line cmd/ae-mcp/morph_shape_tool.gox:56:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/morph_shape_tool.gox:61:1
+	options := tools.ShapeMorphOptions{}
+//line cmd/ae-mcp/morph_shape_tool.gox:62:1
+	if this.Gop_Env("path_index") != nil {
+//line cmd/ae-mcp/morph_shape_tool.gox:63:1
+		options.PathIndex = int(this.Gop_Env("path_index").(float64))
+	}
+//line cmd/ae-mcp/morph_shape_tool.gox:65:1
+	if this.Gop_Env("min_vertices") != nil {
+//line cmd/ae-mcp/morph_shape_tool.gox:66:1
+		options.MinVertices = int(this.Gop_Env("min_vertices").(float64))
+	}
+//line cmd/ae-mcp/morph_shape_tool.gox:68:1
+	if this.Gop_Env("ease") != nil {
+//line cmd/ae-mcp/morph_shape_tool.gox:69:1
+		ease := this.Gop_Env("ease").(bool)
+//line cmd/ae-mcp/morph_shape_tool.gox:70:1
+		options.Ease = &ease
+	}
+//line cmd/ae-mcp/morph_shape_tool.gox:73:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/morph_shape_tool.gox:75:1
+	result, err = tools.MorphShapeLayer(compName, layerName, fromShape, toShape, this.Gop_Env("start_time").(float64), this.Gop_Env("end_time").(float64), options)
+//line cmd/ae-mcp/morph_shape_tool.gox:76:1
+	if err != nil {
+//line cmd/ae-mcp/morph_shape_tool.gox:77:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/morph_shape_tool.gox:81:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *morph_shape) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/move_effect_tool.gox:6
+// Tool for moving effects
+func (this *move_effect) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/morph_shape_tool.gox:81:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/move_effect_tool.gox:7:1
+	this.Tool("ae_move_effect", func() {
+//line cmd/ae-mcp/move_effect_tool.gox:8:1
+		this.Description("Move an effect up or down a layer's effect stack, to the top or bottom, or to a given position")
+//line cmd/ae-mcp/move_effect_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/move_effect_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/move_effect_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/move_effect_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/move_effect_tool.gox:14:1
+			this.Description("Name of the layer")
+//line cmd/ae-mcp/move_effect_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/move_effect_tool.gox:17:1
+		this.Object("effect", func() {
+//line cmd/ae-mcp/move_effect_tool.gox:18:1
+			this.Description("Effect to move as {index: 1} (position in the stack) or {name: effect name or match name}")
+//line cmd/ae-mcp/move_effect_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/move_effect_tool.gox:21:1
+		this.String("direction", func() {
+//line cmd/ae-mcp/move_effect_tool.gox:22:1
+			this.Description("up, down, top or bottom")
+		})
+//line cmd/ae-mcp/move_effect_tool.gox:24:1
+		this.Float("to_index", func() {
+//line cmd/ae-mcp/move_effect_tool.gox:25:1
+			this.Description("1-based position to move the effect to, used when direction is not given")
+		})
+	})
+//line cmd/ae-mcp/move_effect_tool.gox:30:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/move_effect_tool.gox:31:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/move_effect_tool.gox:33:1
+	effect, err := tools.ParseEffectIdentifier(this.Gop_Env("effect").(map[string]interface{}))
+//line cmd/ae-mcp/move_effect_tool.gox:34:1
+	if err != nil {
+//line cmd/ae-mcp/move_effect_tool.gox:35:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/move_effect_tool.gox:40:1
+	direction := ""
+//line cmd/ae-mcp/move_effect_tool.gox:41:1
+	if this.Gop_Env("direction") != nil {
+//line cmd/ae-mcp/move_effect_tool.gox:42:1
+		direction = this.Gop_Env("direction").(string)
+	}
+//line cmd/ae-mcp/move_effect_tool.gox:44:1
+	toIndex := 0
+//line cmd/ae-mcp/move_effect_tool.gox:45:1
+	if this.Gop_Env("to_index") != nil {
+//line cmd/ae-mcp/move_effect_tool.gox:46:1
+		toIndex = int(this.Gop_Env("to_index").(float64))
+	}
+//line cmd/ae-mcp/move_effect_tool.gox:49:1
+	// Call the implementation in golang
+	var result tools.EffectDetails
+//line cmd/ae-mcp/move_effect_tool.gox:51:1
+	result, err = tools.MoveEffect(compName, layerName, effect, direction, toIndex)
+//line cmd/ae-mcp/move_effect_tool.gox:52:1
+	if err != nil {
+//line cmd/ae-mcp/move_effect_tool.gox:53:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/move_effect_tool.gox:57:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *move_effect) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/move_project_items_tool.gox:6
+// Tool for moving project items into a folder
+func (this *move_project_items) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/move_effect_tool.gox:57:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/move_project_items_tool.gox:7:1
+	this.Tool("ae_move_project_items", func() {
+//line cmd/ae-mcp/move_project_items_tool.gox:8:1
+		this.Description("Move project items into a folder")
+//line cmd/ae-mcp/move_project_items_tool.gox:9:1
+		this.Array("item_ids", func() {
+//line cmd/ae-mcp/move_project_items_tool.gox:10:1
+			this.Description("Ids of the items to move, e.g. [12, 15]")
+//line cmd/ae-mcp/move_project_items_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/move_project_items_tool.gox:13:1
+		this.Float("folder_id", func() {
+//line cmd/ae-mcp/move_project_items_tool.gox:14:1
+			this.Description("Id of the destination folder (default: root folder)")
+		})
+	})
+//line cmd/ae-mcp/move_project_items_tool.gox:19:1
+	idArray := this.Gop_Env("item_ids").([]interface{})
+//line cmd/ae-mcp/move_project_items_tool.gox:20:1
+	itemIDs := make([]int, 0, len(idArray))
+	for
+//line cmd/ae-mcp/move_project_items_tool.gox:21:1
+	_, id := range idArray {
+//line cmd/ae-mcp/move_project_items_tool.gox:22:1
+		if
+//line cmd/ae-mcp/move_project_items_tool.gox:22:1
+		idVal, ok := id.(float64); ok {
+//line cmd/ae-mcp/move_project_items_tool.gox:23:1
+			itemIDs = append(itemIDs, int(idVal))
+		}
+	}
+//line cmd/ae-mcp/move_project_items_tool.gox:27:1
+	folderID := 0
+//line cmd/ae-mcp/move_project_items_tool.gox:28:1
+	if this.Gop_Env("folder_id") != nil {
+//line cmd/ae-mcp/move_project_items_tool.gox:29:1
+		folderID = int(this.Gop_Env("folder_id").(float64))
+	}
+//line cmd/ae-mcp/move_project_items_tool.gox:32:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/move_project_items_tool.gox:34:1
+	var err error
+//line cmd/ae-mcp/move_project_items_tool.gox:35:1
+	result, err = tools.MoveProjectItems(itemIDs, folderID)
+//line cmd/ae-mcp/move_project_items_tool.gox:36:1
+	if err != nil {
+//line cmd/ae-mcp/move_project_items_tool.gox:37:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/move_project_items_tool.gox:41:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *move_project_items) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:6
+// Tool for offsetting shape paths
+func (this *offset_shape_path) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/move_project_items_tool.gox:41:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/offset_shape_path_tool.gox:7:1
+	this.Tool("ae_offset_shape_path", func() {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:8:1
+		this.Description("Grow (positive distance) or shrink (negative distance) the area of closed shape paths, computed deterministically in Go. Returns the resulting paths and, when composition_name and layer_name are given, draws them as a filled shape layer in composition coordinates")
+//line cmd/ae-mcp/offset_shape_path_tool.gox:9:1
+		this.Array("shapes", func() {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:10:1
+			this.Description("Array of paths, each {vertices: [[x, y], ...], inTangents: [[x, y], ...], outTangents: [[x, y], ...], closed: true}; tangents are relative to their vertex")
+//line cmd/ae-mcp/offset_shape_path_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/offset_shape_path_tool.gox:13:1
+		this.Float("distance", func() {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:14:1
+			this.Description("Offset in pixels: positive outsets, negative insets")
+//line cmd/ae-mcp/offset_shape_path_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/offset_shape_path_tool.gox:17:1
+		this.String("join", func() {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:18:1
+			this.Description("Corner style: round (default), miter or bevel")
+		})
+//line cmd/ae-mcp/offset_shape_path_tool.gox:20:1
+		this.Float("miter_limit", func() {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:21:1
+			this.Description("Miter limit as a multiple of the distance (default: 4)")
+		})
+//line cmd/ae-mcp/offset_shape_path_tool.gox:23:1
+		this.String("fill_rule", func() {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:24:1
+			this.Description("How the input paths define their area: nonzero (default) or evenodd")
+		})
+//line cmd/ae-mcp/offset_shape_path_tool.gox:26:1
+		this.Float("tolerance", func() {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:27:1
+			this.Description("Allowed curve deviation in pixels (default: 0.5)")
+		})
+//line cmd/ae-mcp/offset_shape_path_tool.gox:29:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:30:1
+			this.Description("Composition to draw the result in (optional)")
+		})
+//line cmd/ae-mcp/offset_shape_path_tool.gox:32:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:33:1
+			this.Description("Name of the shape layer to create for the result (optional)")
+		})
+	})
+//line cmd/ae-mcp/offset_shape_path_tool.gox:38:1
+	shapes, err := tools.ParseShapeDataList(this.Gop_Env("shapes").([]interface{}))
+//line cmd/ae-mcp/offset_shape_path_tool.gox:39:1
+	if err != nil {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:40:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:44:1
+	distance := this.Gop_Env("distance").(float64)
+//line cmd/ae-mcp/offset_shape_path_tool.gox:46:1
+	options := tools.ShapeOpOptions{}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:47:1
+	if this.Gop_Env("join") != nil {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:48:1
+		options.Join = this.Gop_Env("join").(string)
+	}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:50:1
+	if this.Gop_Env("miter_limit") != nil {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:51:1
+		options.MiterLimit = this.Gop_Env("miter_limit").(float64)
+	}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:53:1
+	if this.Gop_Env("fill_rule") != nil {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:54:1
+		options.FillRule = this.Gop_Env("fill_rule").(string)
+	}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:56:1
+	if this.Gop_Env("tolerance") != nil {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:57:1
+		options.Tolerance = this.Gop_Env("tolerance").(float64)
+	}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:61:1
+	paths, err := tools.OffsetShapeData(shapes, distance, options)
+//line cmd/ae-mcp/offset_shape_path_tool.gox:62:1
+	if err != nil {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:63:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:68:1
+	result := map[string]interface{}{"distance": distance, "paths": paths}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:72:1
+	if this.Gop_Env("composition_name") != nil && this.Gop_Env("layer_name") != nil {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:73:1
+		layer, err := tools.AddShapePathsLayer(this.Gop_Env("composition_name").(string), this.Gop_Env("layer_name").(string), paths)
+//line cmd/ae-mcp/offset_shape_path_tool.gox:74:1
+		if err != nil {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:75:1
+			return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+		}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:79:1
+		result["layer"] = layer
+	}
+//line cmd/ae-mcp/offset_shape_path_tool.gox:81:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *offset_shape_path) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/project_tool.gox:6
+// Tool for getting project information
+func (this *project) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/offset_shape_path_tool.gox:81:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/project_tool.gox:7:1
+	this.Tool("ae_get_project_info", func() {
+//line cmd/ae-mcp/project_tool.gox:8:1
+		this.Description("Get information about the current After Effects project")
+	})
+//line cmd/ae-mcp/project_tool.gox:13:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/project_tool.gox:15:1
+	var err error
+//line cmd/ae-mcp/project_tool.gox:16:1
+	result, err = tools.GetProjectInfo()
+//line cmd/ae-mcp/project_tool.gox:17:1
+	if err != nil {
+//line cmd/ae-mcp/project_tool.gox:18:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/project_tool.gox:22:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *project) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:6
+// Tool for refreshing the effect catalog
+func (this *refresh_effect_catalog) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/project_tool.gox:22:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:7:1
+	this.Tool("ae_refresh_effect_catalog", func() {
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:8:1
+		this.Description("Discover every effect installed in the running After Effects, including third-party plugins, and merge it into the on-disk effect catalog for this AE version, with display names recorded under the running AE language so effects can be applied by their name in any scanned language; effect category and effect list tools read from this catalog")
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:9:1
+		this.String("random_string", func() {
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:10:1
+			this.Description("Dummy parameter for no-parameter tools")
+		})
+	})
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:15:1
+	result, err := tools.RefreshEffectCatalog()
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:16:1
+	if err != nil {
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:17:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:21:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *refresh_effect_catalog) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/remove_effect_tool.gox:6
+// Tool for removing an effect
+func (this *remove_effect) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/refresh_effect_catalog_tool.gox:21:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/remove_effect_tool.gox:7:1
+	this.Tool("ae_remove_effect", func() {
+//line cmd/ae-mcp/remove_effect_tool.gox:8:1
+		this.Description("Remove an effect from a layer")
+//line cmd/ae-mcp/remove_effect_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/remove_effect_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/remove_effect_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/remove_effect_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/remove_effect_tool.gox:14:1
+			this.Description("Name of the layer")
+//line cmd/ae-mcp/remove_effect_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/remove_effect_tool.gox:17:1
+		this.Object("effect", func() {
+//line cmd/ae-mcp/remove_effect_tool.gox:18:1
+			this.Description("Effect to remove as {index: 1} (position in the stack) or {name: effect name or match name}")
+//line cmd/ae-mcp/remove_effect_tool.gox:19:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/remove_effect_tool.gox:24:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/remove_effect_tool.gox:25:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/remove_effect_tool.gox:27:1
+	effect, err := tools.ParseEffectIdentifier(this.Gop_Env("effect").(map[string]interface{}))
+//line cmd/ae-mcp/remove_effect_tool.gox:28:1
+	if err != nil {
+//line cmd/ae-mcp/remove_effect_tool.gox:29:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/remove_effect_tool.gox:34:1
+	// Call the implementation in golang
+	var result tools.EffectDetails
+//line cmd/ae-mcp/remove_effect_tool.gox:36:1
+	result, err = tools.RemoveEffect(compName, layerName, effect)
+//line cmd/ae-mcp/remove_effect_tool.gox:37:1
+	if err != nil {
+//line cmd/ae-mcp/remove_effect_tool.gox:38:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/remove_effect_tool.gox:42:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *remove_effect) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/remove_unused_items_tool.gox:6
+// Tool for removing unused footage, chosen compositions and empty folders
+func (this *remove_unused_items) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/remove_effect_tool.gox:42:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/remove_unused_items_tool.gox:7:1
+	this.Tool("ae_remove_unused_items", func() {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:8:1
+		this.Description("Delete footage and solids not used in any composition, optionally with chosen compositions and empty folders. Use dry_run to preview")
+//line cmd/ae-mcp/remove_unused_items_tool.gox:9:1
+		this.Array("composition_ids", func() {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:10:1
+			this.Description("Ids of compositions to delete as well, e.g. [3, 8]; a composition nested in another composition, queued for rendering or active is skipped")
+		})
+//line cmd/ae-mcp/remove_unused_items_tool.gox:12:1
+		this.Bool("remove_empty_folders", func() {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:13:1
+			this.Description("Also delete folders that are empty after the cleanup")
+		})
+//line cmd/ae-mcp/remove_unused_items_tool.gox:15:1
+		this.Bool("dry_run", func() {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:16:1
+			this.Description("Only report what would be removed (default: true)")
+		})
+	})
+//line cmd/ae-mcp/remove_unused_items_tool.gox:21:1
+	compositionIDs := []int{}
+//line cmd/ae-mcp/remove_unused_items_tool.gox:22:1
+	if this.Gop_Env("composition_ids") != nil {
+		for
+//line cmd/ae-mcp/remove_unused_items_tool.gox:23:1
+		_, id := range this.Gop_Env("composition_ids").([]interface{}) {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:24:1
+			if
+//line cmd/ae-mcp/remove_unused_items_tool.gox:24:1
+			idVal, ok := id.(float64); ok {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:25:1
+				compositionIDs = append(compositionIDs, int(idVal))
+			}
+		}
+	}
+//line cmd/ae-mcp/remove_unused_items_tool.gox:30:1
+	removeEmptyFolders := false
+//line cmd/ae-mcp/remove_unused_items_tool.gox:31:1
+	if this.Gop_Env("remove_empty_folders") != nil {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:32:1
+		removeEmptyFolders = this.Gop_Env("remove_empty_folders").(bool)
+	}
+//line cmd/ae-mcp/remove_unused_items_tool.gox:35:1
+	dryRun := true
+//line cmd/ae-mcp/remove_unused_items_tool.gox:36:1
+	if this.Gop_Env("dry_run") != nil {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:37:1
+		dryRun = this.Gop_Env("dry_run").(bool)
+	}
+//line cmd/ae-mcp/remove_unused_items_tool.gox:40:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/remove_unused_items_tool.gox:42:1
+	var err error
+//line cmd/ae-mcp/remove_unused_items_tool.gox:43:1
+	result, err = tools.RemoveUnusedItems(compositionIDs, removeEmptyFolders, dryRun)
+//line cmd/ae-mcp/remove_unused_items_tool.gox:44:1
+	if err != nil {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:45:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/remove_unused_items_tool.gox:49:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *remove_unused_items) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/rename_project_item_tool.gox:6
+// Tool for renaming a project item
+func (this *rename_project_item) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/remove_unused_items_tool.gox:49:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/rename_project_item_tool.gox:7:1
+	this.Tool("ae_rename_project_item", func() {
+//line cmd/ae-mcp/rename_project_item_tool.gox:8:1
+		this.Description("Rename a project item (folder, footage, solid or composition)")
+//line cmd/ae-mcp/rename_project_item_tool.gox:9:1
+		this.Float("item_id", func() {
+//line cmd/ae-mcp/rename_project_item_tool.gox:10:1
+			this.Description("Id of the item to rename")
+//line cmd/ae-mcp/rename_project_item_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/rename_project_item_tool.gox:13:1
+		this.String("new_name", func() {
+//line cmd/ae-mcp/rename_project_item_tool.gox:14:1
+			this.Description("New name of the item")
+//line cmd/ae-mcp/rename_project_item_tool.gox:15:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/rename_project_item_tool.gox:20:1
+	itemID := int(this.Gop_Env("item_id").(float64))
+//line cmd/ae-mcp/rename_project_item_tool.gox:21:1
+	newName := this.Gop_Env("new_name").(string)
+//line cmd/ae-mcp/rename_project_item_tool.gox:23:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/rename_project_item_tool.gox:25:1
+	var err error
+//line cmd/ae-mcp/rename_project_item_tool.gox:26:1
+	result, err = tools.RenameProjectItem(itemID, newName)
+//line cmd/ae-mcp/rename_project_item_tool.gox:27:1
+	if err != nil {
+//line cmd/ae-mcp/rename_project_item_tool.gox:28:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/rename_project_item_tool.gox:32:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *rename_project_item) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/save_recipe_tool.gox:6
+// Tool for saving effect recipes
+func (this *save_recipe) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/rename_project_item_tool.gox:32:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/save_recipe_tool.gox:7:1
+	this.Tool("ae_save_recipe", func() {
+//line cmd/ae-mcp/save_recipe_tool.gox:8:1
+		this.Description("Save the current effect stack of a layer, with its parameter values, as a named recipe file in the recipes folder")
+//line cmd/ae-mcp/save_recipe_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/save_recipe_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/save_recipe_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/save_recipe_tool.gox:13:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/save_recipe_tool.gox:14:1
+			this.Description("Name of the layer whose effects are saved")
+//line cmd/ae-mcp/save_recipe_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/save_recipe_tool.gox:17:1
+		this.String("recipe_name", func() {
+//line cmd/ae-mcp/save_recipe_tool.gox:18:1
+			this.Description("Name of the new recipe")
+//line cmd/ae-mcp/save_recipe_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/save_recipe_tool.gox:21:1
+		this.String("description", func() {
+//line cmd/ae-mcp/save_recipe_tool.gox:22:1
+			this.Description("Short description of the look")
+		})
+//line cmd/ae-mcp/save_recipe_tool.gox:24:1
+		this.String("format", func() {
+//line cmd/ae-mcp/save_recipe_tool.gox:25:1
+			this.Description("File format: json (default) or yaml")
+		})
+//line cmd/ae-mcp/save_recipe_tool.gox:27:1
+		this.Bool("overwrite", func() {
+//line cmd/ae-mcp/save_recipe_tool.gox:28:1
+			this.Description("Replace an existing recipe file with the same name")
+		})
+	})
+//line cmd/ae-mcp/save_recipe_tool.gox:33:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/save_recipe_tool.gox:34:1
+	layerName := this.Gop_Env("layer_name").(string)
+//line cmd/ae-mcp/save_recipe_tool.gox:35:1
+	recipeName := this.Gop_Env("recipe_name").(string)
+//line cmd/ae-mcp/save_recipe_tool.gox:37:1
+	recipeDescription := ""
+//line cmd/ae-mcp/save_recipe_tool.gox:38:1
+	if this.Gop_Env("description") != nil {
+//line cmd/ae-mcp/save_recipe_tool.gox:39:1
+		recipeDescription = this.Gop_Env("description").(string)
+	}
+//line cmd/ae-mcp/save_recipe_tool.gox:41:1
+	format := ""
+//line cmd/ae-mcp/save_recipe_tool.gox:42:1
+	if this.Gop_Env("format") != nil {
+//line cmd/ae-mcp/save_recipe_tool.gox:43:1
+		format = this.Gop_Env("format").(string)
+	}
+//line cmd/ae-mcp/save_recipe_tool.gox:45:1
+	overwrite := false
+//line cmd/ae-mcp/save_recipe_tool.gox:46:1
+	if this.Gop_Env("overwrite") != nil {
+//line cmd/ae-mcp/save_recipe_tool.gox:47:1
+		overwrite = this.Gop_Env("overwrite").(bool)
+	}
+//line cmd/ae-mcp/save_recipe_tool.gox:50:1
+	// Call the implementation in golang
+	var result tools.EffectDetails
+//line cmd/ae-mcp/save_recipe_tool.gox:52:1
+	result, err := tools.SaveLayerEffectsAsRecipe(compName, layerName, recipeName, recipeDescription, format, overwrite)
+//line cmd/ae-mcp/save_recipe_tool.gox:53:1
+	if err != nil {
+//line cmd/ae-mcp/save_recipe_tool.gox:54:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/save_recipe_tool.gox:58:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *save_recipe) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/script_tool.gox:9
+// Tool for executing JavaScript code in After Effects
+// The After Effects Object Model provides programmatic access to the entire AE application structure:
+// - Application object (app): The global entry point to access AE settings and objects
+// - Project: Represents an AE project containing compositions, items, and render queue
+// - CompItem: Represents compositions that contain layers and can be rendered
+// - Layer objects: Various types (AVLayer, CameraLayer, LightLayer, ShapeLayer, TextLayer)
+// - Property objects: Represent AE properties on layers that can be animated with keyframes
+// - RenderQueue: Controls rendering operations and output modules
+// - Sources: Represent different types of footage (files, placeholders, solids)
+//
+// The hierarchy follows the UI structure: Application contains Projects, which contain
+// Compositions with Layers, which have Properties containing Markers and Keyframes.
+// The RenderQueue contains render settings and output modules.
+//
+// For complete documentation, refer to: https://ae-scripting.docsforadobe.dev/
+func (this *script) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/save_recipe_tool.gox:58:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/script_tool.gox:24:1
+	this.Tool("ae_execute_script", func() {
+//line cmd/ae-mcp/script_tool.gox:25:1
+		this.Description("Execute arbitrary JavaScript code in After Effects to interact with any object in the AE object model hierarchy including: Application, Project, Items (Compositions, Footage, Folders), Layers (AV, Camera, Light, Shape, Text), Properties, RenderQueue, Sources, and more. Scripts have full access to create, modify, and automate all aspects of After Effects projects. Full documentation: https://ae-scripting.docsforadobe.dev/")
+//line cmd/ae-mcp/script_tool.gox:26:1
+		this.String("script", func() {
+//line cmd/ae-mcp/script_tool.gox:27:1
+			this.Description("JavaScript code to execute. Can use the entire After Effects scripting object model including: app (Application), app.project (Project), CompItem, Layer objects, Property objects, and utility functions for time conversion and debugging. See https://ae-scripting.docsforadobe.dev/ for complete reference.")
+//line cmd/ae-mcp/script_tool.gox:28:1
+			this.Required()
+		})
+	})
+//line cmd/ae-mcp/script_tool.gox:33:1
+	scriptCode := this.Gop_Env("script").(string)
+//line cmd/ae-mcp/script_tool.gox:35:1
+	// Call the implementation in golang
+	var result map[string]interface{}
+//line cmd/ae-mcp/script_tool.gox:37:1
+	var err error
+//line cmd/ae-mcp/script_tool.gox:38:1
+	result, err = tools.ExecuteScript(scriptCode)
+//line cmd/ae-mcp/script_tool.gox:39:1
+	if err != nil {
+//line cmd/ae-mcp/script_tool.gox:40:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:27:1
+//line cmd/ae-mcp/script_tool.gox:44:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
-func (this *get_effects_by_category) Classclone() server.ToolProto {
+func (this *script) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
-//line cmd/ae-mcp/modify_layer_tool.gox:6
-// Tool for modifying layer properties
-func (this *modify_layer) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
-//line cmd/ae-mcp/get_effects_by_category_tool.gox:27:1
+//line cmd/ae-mcp/search_effects_tool.gox:6
+// Tool for searching effects
+func (this *search_effects) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/script_tool.gox:44:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
-//line cmd/ae-mcp/modify_layer_tool.gox:7:1
-	this.Tool("ae_modify_layer", func() {
-//line cmd/ae-mcp/modify_layer_tool.gox:8:1
-		this.Description("Modify properties of an existing layer")
-//line cmd/ae-mcp/modify_layer_tool.gox:9:1
+//line cmd/ae-mcp/search_effects_tool.gox:7:1
+	this.Tool("ae_search_effects", func() {
+//line cmd/ae-mcp/search_effects_tool.gox:8:1
+		this.Description("Search effects by keyword with ranked fuzzy matching over display names, match names, localized names (e.g. Chinese), categories and common synonyms (for example blur finds Gaussian Blur, Fast Box Blur and Camera Lens Blur); tolerates typos")
+//line cmd/ae-mcp/search_effects_tool.gox:9:1
+		this.String("query", func() {
+//line cmd/ae-mcp/search_effects_tool.gox:10:1
+			this.Description("What to look for, e.g. blur, film grain, glow or a misspelled effect name")
+		})
+//line cmd/ae-mcp/search_effects_tool.gox:12:1
+		this.String("category", func() {
+//line cmd/ae-mcp/search_effects_tool.gox:13:1
+			this.Description("Only return effects in this category")
+		})
+//line cmd/ae-mcp/search_effects_tool.gox:15:1
+		this.Float("limit", func() {
+//line cmd/ae-mcp/search_effects_tool.gox:16:1
+			this.Description("Maximum number of results (default: 10)")
+		})
+	})
+//line cmd/ae-mcp/search_effects_tool.gox:20:1
+	query := ""
+//line cmd/ae-mcp/search_effects_tool.gox:21:1
+	if this.Gop_Env("query") != nil {
+//line cmd/ae-mcp/search_effects_tool.gox:22:1
+		query = this.Gop_Env("query").(string)
+	}
+//line cmd/ae-mcp/search_effects_tool.gox:24:1
+	category := ""
+//line cmd/ae-mcp/search_effects_tool.gox:25:1
+	if this.Gop_Env("category") != nil {
+//line cmd/ae-mcp/search_effects_tool.gox:26:1
+		category = this.Gop_Env("category").(string)
+	}
+//line cmd/ae-mcp/search_effects_tool.gox:28:1
+	limit := 0
+//line cmd/ae-mcp/search_effects_tool.gox:29:1
+	if this.Gop_Env("limit") != nil {
+//line cmd/ae-mcp/search_effects_tool.gox:30:1
+		limit = int(this.Gop_Env("limit").(float64))
+	}
+//line cmd/ae-mcp/search_effects_tool.gox:34:1
+	results := tools.SearchEffects(query, category, limit)
+//line cmd/ae-mcp/search_effects_tool.gox:35:1
+	return server.Text__1(server.JsonContent{JSON: map[string]interface{}{"effects": results, "count": len(results)}})
+}
+func (this *search_effects) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/search_presets_tool.gox:6
+// Tool for searching animation presets
+func (this *search_presets) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/search_effects_tool.gox:35:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/search_presets_tool.gox:7:1
+	this.Tool("ae_search_presets", func() {
+//line cmd/ae-mcp/search_presets_tool.gox:8:1
+		this.Description("Search the animation preset (.ffx) library by keyword. Presets are indexed from the After Effects Presets and User Presets folders and the directories in the AE_MCP_PRESET_DIRS environment variable; the category is the folder path")
+//line cmd/ae-mcp/search_presets_tool.gox:9:1
+		this.String("query", func() {
+//line cmd/ae-mcp/search_presets_tool.gox:10:1
+			this.Description("Keywords matched against preset names and categories, e.g. typewriter or fade in")
+		})
+//line cmd/ae-mcp/search_presets_tool.gox:12:1
+		this.String("category", func() {
+//line cmd/ae-mcp/search_presets_tool.gox:13:1
+			this.Description("Only return presets in this category folder and its subfolders, e.g. Text/Animate In")
+		})
+//line cmd/ae-mcp/search_presets_tool.gox:15:1
+		this.Float("limit", func() {
+//line cmd/ae-mcp/search_presets_tool.gox:16:1
+			this.Description("Maximum number of results (default: 20)")
+		})
+//line cmd/ae-mcp/search_presets_tool.gox:18:1
+		this.Bool("reindex", func() {
+//line cmd/ae-mcp/search_presets_tool.gox:19:1
+			this.Description("Rescan the preset directories before searching")
+		})
+//line cmd/ae-mcp/search_presets_tool.gox:21:1
+		this.Array("directories", func() {
+//line cmd/ae-mcp/search_presets_tool.gox:22:1
+			this.Description("Directories to index instead of the configured ones (implies reindex)")
+		})
+	})
+//line cmd/ae-mcp/search_presets_tool.gox:26:1
+	query := ""
+//line cmd/ae-mcp/search_presets_tool.gox:27:1
+	if this.Gop_Env("query") != nil {
+//line cmd/ae-mcp/search_presets_tool.gox:28:1
+		query = this.Gop_Env("query").(string)
+	}
+//line cmd/ae-mcp/search_presets_tool.gox:30:1
+	category := ""
+//line cmd/ae-mcp/search_presets_tool.gox:31:1
+	if this.Gop_Env("category") != nil {
+//line cmd/ae-mcp/search_presets_tool.gox:32:1
+		category = this.Gop_Env("category").(string)
+	}
+//line cmd/ae-mcp/search_presets_tool.gox:34:1
+	limit := 0
+//line cmd/ae-mcp/search_presets_tool.gox:35:1
+	if this.Gop_Env("limit") != nil {
+//line cmd/ae-mcp/search_presets_tool.gox:36:1
+		limit = int(this.Gop_Env("limit").(float64))
+	}
+//line cmd/ae-mcp/search_presets_tool.gox:39:1
+	var dirs []string
+//line cmd/ae-mcp/search_presets_tool.gox:40:1
+	if this.Gop_Env("directories") != nil {
+		for
+//line cmd/ae-mcp/search_presets_tool.gox:41:1
+		_, dir := range this.Gop_Env("directories").([]interface{}) {
+//line cmd/ae-mcp/search_presets_tool.gox:42:1
+			if
+//line cmd/ae-mcp/search_presets_tool.gox:42:1
+			s, ok := dir.(string); ok {
+//line cmd/ae-mcp/search_presets_tool.gox:43:1
+				dirs = append(dirs, s)
+			}
+		}
+	}
+//line cmd/ae-mcp/search_presets_tool.gox:49:1
+	if len(dirs) > 0 || this.Gop_Env("reindex") != nil && this.Gop_Env("reindex").(bool) {
+//line cmd/ae-mcp/search_presets_tool.gox:50:1
+		if
+//line cmd/ae-mcp/search_presets_tool.gox:50:1
+		_, err := tools.IndexPresets(dirs); err != nil {
+//line cmd/ae-mcp/search_presets_tool.gox:51:1
+			return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+		}
+	}
+//line cmd/ae-mcp/search_presets_tool.gox:58:1
+	presets, err := tools.SearchPresets(query, category, limit)
+//line cmd/ae-mcp/search_presets_tool.gox:59:1
+	if err != nil {
+//line cmd/ae-mcp/search_presets_tool.gox:60:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/search_presets_tool.gox:64:1
+	return server.Text__1(server.JsonContent{JSON: map[string]interface{}{"presets": presets, "count": len(presets)}})
+}
+func (this *search_presets) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:6
+// Tool for setting extrusion and bevels
+func (this *set_layer_extrusion) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/search_presets_tool.gox:64:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:7:1
+	this.Tool("ae_set_layer_extrusion", func() {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:8:1
+		this.Description("Extrude a text or shape layer and set its bevel, making it 3D if needed. Extrusion only renders with the Cinema 4D or Advanced 3D renderer (see ae_modify_composition or the renderer of ae_create_composition)")
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:9:1
 		this.String("composition_name", func() {
-//line cmd/ae-mcp/modify_layer_tool.gox:10:1
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:10:1
 			this.Description("Name of the composition containing the layer")
-//line cmd/ae-mcp/modify_layer_tool.gox:11:1
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:11:1
 			this.Required()
 		})
-//line cmd/ae-mcp/modify_layer_tool.gox:13:1
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:13:1
 		this.Object("layer_identifier", func() {
-//line cmd/ae-mcp/modify_layer_tool.gox:14:1
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:14:1
 			this.Description("Layer identifier, can be in the format {name: 'layer name'} or {index: 1}")
-//line cmd/ae-mcp/modify_layer_tool.gox:15:1
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:15:1
 			this.Required()
 		})
-//line cmd/ae-mcp/modify_layer_tool.gox:17:1
-		this.Object("properties", func() {
-//line cmd/ae-mcp/modify_layer_tool.gox:18:1
-			this.Description("Properties object to modify: position, scale, rotation, opacity, etc.")
-//line cmd/ae-mcp/modify_layer_tool.gox:19:1
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:17:1
+		this.Float("extrusion_depth", func() {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:18:1
+			this.Description("Extrusion depth in pixels")
+		})
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:20:1
+		this.String("bevel_style", func() {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:21:1
+			this.Description("Bevel style (none, angular, concave, convex)")
+		})
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:23:1
+		this.Float("bevel_depth", func() {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:24:1
+			this.Description("Bevel depth in pixels")
+		})
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:26:1
+		this.Float("hole_bevel_depth", func() {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:27:1
+			this.Description("Bevel depth of holes, such as the inside of an O, in percent of the bevel depth")
+		})
+	})
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:32:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:33:1
+	layerIdentifier := this.Gop_Env("layer_identifier").(map[string]interface{})
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:35:1
+	// Convert to LayerIdentifier struct
+	var identifier tools.LayerIdentifier
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:37:1
+	if
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:37:1
+	name, ok := layerIdentifier["name"].(string); ok && name != "" {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:38:1
+		identifier.Name = name
+	} else
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:39:1
+	if
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:39:1
+	index, ok := layerIdentifier["index"].(float64); ok && index > 0 {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:40:1
+		identifier.Index = int(index)
+	}
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:43:1
+	var extrusion tools.LayerExtrusion
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:44:1
+	if this.Gop_Env("extrusion_depth") != nil {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:45:1
+		depth := this.Gop_Env("extrusion_depth").(float64)
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:46:1
+		extrusion.ExtrusionDepth = &depth
+	}
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:48:1
+	if this.Gop_Env("bevel_style") != nil {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:49:1
+		extrusion.BevelStyle = this.Gop_Env("bevel_style").(string)
+	}
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:51:1
+	if this.Gop_Env("bevel_depth") != nil {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:52:1
+		depth := this.Gop_Env("bevel_depth").(float64)
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:53:1
+		extrusion.BevelDepth = &depth
+	}
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:55:1
+	if this.Gop_Env("hole_bevel_depth") != nil {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:56:1
+		depth := this.Gop_Env("hole_bevel_depth").(float64)
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:57:1
+		extrusion.HoleBevelDepth = &depth
+	}
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:60:1
+	// Call the implementation in golang
+	var result tools.LayerInfo
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:62:1
+	result, err := tools.SetLayerExtrusion(compName, identifier, extrusion)
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:63:1
+	if err != nil {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:64:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:68:1
+	return server.Text__1(server.JsonContent{JSON: result})
+}
+func (this *set_layer_extrusion) Classclone() server.ToolProto {
+	_gop_ret := *this
+	return &_gop_ret
+}
+//line cmd/ae-mcp/set_layer_material_tool.gox:6
+// Tool for setting layer material options
+func (this *set_layer_material) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/set_layer_extrusion_tool.gox:68:1
+	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
+//line cmd/ae-mcp/set_layer_material_tool.gox:7:1
+	this.Tool("ae_set_layer_material", func() {
+//line cmd/ae-mcp/set_layer_material_tool.gox:8:1
+		this.Description("Set the Material Options of a layer (shadows, lights, reflections, shading, transparency, index of refraction), making it 3D if needed. Each option is reported as applied or failed, with a warning for options the Classic 3D renderer ignores")
+//line cmd/ae-mcp/set_layer_material_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/set_layer_material_tool.gox:10:1
+			this.Description("Name of the composition containing the layer")
+//line cmd/ae-mcp/set_layer_material_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/set_layer_material_tool.gox:13:1
+		this.Object("layer_identifier", func() {
+//line cmd/ae-mcp/set_layer_material_tool.gox:14:1
+			this.Description("Layer identifier, can be in the format {name: 'layer name'} or {index: 1}")
+//line cmd/ae-mcp/set_layer_material_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/set_layer_material_tool.gox:17:1
+		this.Object("material", func() {
+//line cmd/ae-mcp/set_layer_material_tool.gox:18:1
+			this.Description("Material options to change: castsShadows, acceptsShadows and appearsInReflections (on, off or only), acceptsLights (bool), and percentages lightTransmission, ambient, diffuse, specularIntensity, specularShininess, metal, reflectionIntensity, reflectionSharpness, reflectionRolloff, transparency, transparencyRolloff, plus indexOfRefraction (1-3)")
+//line cmd/ae-mcp/set_layer_material_tool.gox:19:1
 			this.Required()
 		})
 	})
-//line cmd/ae-mcp/modify_layer_tool.gox:24:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:24:1
 	compName := this.Gop_Env("composition_name").(string)
-//line cmd/ae-mcp/modify_layer_tool.gox:25:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:25:1
 	layerIdentifier := this.Gop_Env("layer_identifier").(map[string]interface{})
-//line cmd/ae-mcp/modify_layer_tool.gox:26:1
-	properties := this.Gop_Env("properties").(map[string]interface{})
-//line cmd/ae-mcp/modify_layer_tool.gox:28:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:27:1
 	// Convert to LayerIdentifier struct
 	var identifier tools.LayerIdentifier
-//line cmd/ae-mcp/modify_layer_tool.gox:30:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:29:1
 	if
-//line cmd/ae-mcp/modify_layer_tool.gox:30:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:29:1
 	name, ok := layerIdentifier["name"].(string); ok && name != "" {
-//line cmd/ae-mcp/modify_layer_tool.gox:31:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:30:1
 		identifier.Name = name
 	} else
-//line cmd/ae-mcp/modify_layer_tool.gox:32:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:31:1
 	if
-//line cmd/ae-mcp/modify_layer_tool.gox:32:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:31:1
 	index, ok := layerIdentifier["index"].(float64); ok && index > 0 {
-//line cmd/ae-mcp/modify_layer_tool.gox:33:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:32:1
 		identifier.Index = int(index)
 	}
-//line cmd/ae-mcp/modify_layer_tool.gox:36:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:35:1
+	material, err := tools.ParseLayerMaterial(this.Gop_Env("material").(map[string]interface{}))
+//line cmd/ae-mcp/set_layer_material_tool.gox:36:1
+	if err != nil {
+//line cmd/ae-mcp/set_layer_material_tool.gox:37:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/set_layer_material_tool.gox:42:1
 	// Call the implementation in golang
-	var result map[string]interface{}
-//line cmd/ae-mcp/modify_layer_tool.gox:38:1
-	var err error
-//line cmd/ae-mcp/modify_layer_tool.gox:39:1
-	result, err = tools.ModifyLayer(compName, identifier, properties)
-//line cmd/ae-mcp/modify_layer_tool.gox:40:1
+	var result tools.LayerInfo
+//line cmd/ae-mcp/set_layer_material_tool.gox:44:1
+	result, err = tools.SetLayerMaterial(compName, identifier, material)
+//line cmd/ae-mcp/set_layer_material_tool.gox:45:1
 	if err != nil {
-//line cmd/ae-mcp/modify_layer_tool.gox:41:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:46:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/modify_layer_tool.gox:45:1
+//line cmd/ae-mcp/set_layer_material_tool.gox:50:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
-func (this *modify_layer) Classclone() server.ToolProto {
+func (this *set_layer_material) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
-//line cmd/ae-mcp/modify_text_tool.gox:6
-// Tool for modifying text layers
-func (this *modify_text) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
-//line cmd/ae-mcp/modify_layer_tool.gox:45:1
+//line cmd/ae-mcp/set_text_path_tool.gox:6
+// Tool for binding a text layer to a mask path
+func (this *set_text_path) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/set_layer_material_tool.gox:50:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
-//line cmd/ae-mcp/modify_text_tool.gox:7:1
-	this.Tool("ae_modify_text_layer", func() {
-//line cmd/ae-mcp/modify_text_tool.gox:8:1
-		this.Description("Modify an existing text layer in a composition")
-//line cmd/ae-mcp/modify_text_tool.gox:9:1
+//line cmd/ae-mcp/set_text_path_tool.gox:7:1
+	this.Tool("ae_set_text_path", func() {
+//line cmd/ae-mcp/set_text_path_tool.gox:8:1
+		this.Description("Bind a text layer to a mask path, either an existing mask or a new one created from shape data")
+//line cmd/ae-mcp/set_text_path_tool.gox:9:1
 		this.String("composition_name", func() {
-//line cmd/ae-mcp/modify_text_tool.gox:10:1
+//line cmd/ae-mcp/set_text_path_tool.gox:10:1
 			this.Description("Name of the composition containing the text layer")
-//line cmd/ae-mcp/modify_text_tool.gox:11:1
+//line cmd/ae-mcp/set_text_path_tool.gox:11:1
 			this.Required()
 		})
-//line cmd/ae-mcp/modify_text_tool.gox:13:1
+//line cmd/ae-mcp/set_text_path_tool.gox:13:1
 		this.String("layer_name", func() {
-//line cmd/ae-mcp/modify_text_tool.gox:14:1
-			this.Description("Name of the text layer to modify")
-//line cmd/ae-mcp/modify_text_tool.gox:15:1
+//line cmd/ae-mcp/set_text_path_tool.gox:14:1
+			this.Description("Name of the text layer")
+//line cmd/ae-mcp/set_text_path_tool.gox:15:1
 			this.Required()
 		})
-//line cmd/ae-mcp/modify_text_tool.gox:17:1
-		this.Object("modifications", func() {
-//line cmd/ae-mcp/modify_text_tool.gox:18:1
-			this.Description("Text properties to modify (text, fontSize, fontName, color, etc.)")
-//line cmd/ae-mcp/modify_text_tool.gox:19:1
-			this.Required()
+//line cmd/ae-mcp/set_text_path_tool.gox:17:1
+		this.String("mask_name", func() {
+//line cmd/ae-mcp/set_text_path_tool.gox:18:1
+			this.Description("Name of an existing mask on the layer, or the name for the new mask when shape is given (default: first mask)")
+		})
+//line cmd/ae-mcp/set_text_path_tool.gox:20:1
+		this.Object("shape", func() {
+//line cmd/ae-mcp/set_text_path_tool.gox:21:1
+			this.Description("Shape data for a new mask path in composition space (vertices, inTangents, outTangents, closed)")
+		})
+//line cmd/ae-mcp/set_text_path_tool.gox:23:1
+		this.Float("first_margin", func() {
+//line cmd/ae-mcp/set_text_path_tool.gox:24:1
+			this.Description("First margin along the path in pixels")
+		})
+//line cmd/ae-mcp/set_text_path_tool.gox:26:1
+		this.Float("last_margin", func() {
+//line cmd/ae-mcp/set_text_path_tool.gox:27:1
+			this.Description("Last margin along the path in pixels")
+		})
+//line cmd/ae-mcp/set_text_path_tool.gox:29:1
+		this.Bool("perpendicular_to_path", func() {
+//line cmd/ae-mcp/set_text_path_tool.gox:30:1
+			this.Description("Rotate characters perpendicular to the path")
+		})
+//line cmd/ae-mcp/set_text_path_tool.gox:32:1
+		this.Bool("force_alignment", func() {
+//line cmd/ae-mcp/set_text_path_tool.gox:33:1
+			this.Description("Spread characters between the first and last margins")
+		})
+//line cmd/ae-mcp/set_text_path_tool.gox:35:1
+		this.Bool("reverse_path", func() {
+//line cmd/ae-mcp/set_text_path_tool.gox:36:1
+			this.Description("Reverse the direction of the path")
 		})
 	})
-//line cmd/ae-mcp/modify_text_tool.gox:24:1
+//line cmd/ae-mcp/set_text_path_tool.gox:41:1
 	compName := this.Gop_Env("composition_name").(string)
-//line cmd/ae-mcp/modify_text_tool.gox:25:1
+//line cmd/ae-mcp/set_text_path_tool.gox:42:1
 	layerName := this.Gop_Env("layer_name").(string)
-//line cmd/ae-mcp/modify_text_tool.gox:26:1
-	modifications := this.Gop_Env("modifications").(map[string]interface{})
-//line cmd/ae-mcp/modify_text_tool.gox:28:1
+//line cmd/ae-mcp/set_text_path_tool.gox:44:1
+	pathMap := map[string]interface{}{}
+//line cmd/ae-mcp/set_text_path_tool.gox:45:1
+	if this.Gop_Env("mask_name") != nil {
+//line cmd/ae-mcp/set_text_path_tool.gox:46:1
+		pathMap["maskName"] = this.Gop_Env("mask_name")
+	}
+//line cmd/ae-mcp/set_text_path_tool.gox:48:1
+	if this.Gop_Env("shape") != nil {
+//line cmd/ae-mcp/set_text_path_tool.gox:49:1
+		pathMap["shape"] = this.Gop_Env("shape")
+	}
+//line cmd/ae-mcp/set_text_path_tool.gox:51:1
+	if this.Gop_Env("first_margin") != nil {
+//line cmd/ae-mcp/set_text_path_tool.gox:52:1
+		pathMap["firstMargin"] = this.Gop_Env("first_margin")
+	}
+//line cmd/ae-mcp/set_text_path_tool.gox:54:1
+	if this.Gop_Env("last_margin") != nil {
+//line cmd/ae-mcp/set_text_path_tool.gox:55:1
+		pathMap["lastMargin"] = this.Gop_Env("last_margin")
+	}
+//line cmd/ae-mcp/set_text_path_tool.gox:57:1
+	if this.Gop_Env("perpendicular_to_path") != nil {
+//line cmd/ae-mcp/set_text_path_tool.gox:58:1
+		pathMap["perpendicularToPath"] = this.Gop_Env("perpendicular_to_path")
+	}
+//line cmd/ae-mcp/set_text_path_tool.gox:60:1
+	if this.Gop_Env("force_alignment") != nil {
+//line cmd/ae-mcp/set_text_path_tool.gox:61:1
+		pathMap["forceAlignment"] = this.Gop_Env("force_alignment")
+	}
+//line cmd/ae-mcp/set_text_path_tool.gox:63:1
+	if this.Gop_Env("reverse_path") != nil {
+//line cmd/ae-mcp/set_text_path_tool.gox:64:1
+		pathMap["reversePath"] = this.Gop_Env("reverse_path")
+	}
+//line cmd/ae-mcp/set_text_path_tool.gox:67:1
+	pathSettings, err := tools.ParseTextPathSettings(pathMap)
+//line cmd/ae-mcp/set_text_path_tool.gox:68:1
+	if err != nil {
+//line cmd/ae-mcp/set_text_path_tool.gox:69:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/set_text_path_tool.gox:74:1
 	// Call the implementation in golang
 	var result map[string]interface{}
-//line cmd/ae-mcp/modify_text_tool.gox:30:1
-	var err error
-//line cmd/ae-mcp/modify_text_tool.gox:31:1
-	result, err = tools.ModifyTextLayer(compName, layerName, modifications)
-//line cmd/ae-mcp/modify_text_tool.gox:32:1
+//line cmd/ae-mcp/set_text_path_tool.gox:76:1
+	result, err = tools.SetTextPath(compName, layerName, *pathSettings)
+//line cmd/ae-mcp/set_text_path_tool.gox:77:1
 	if err != nil {
-//line cmd/ae-mcp/modify_text_tool.gox:33:1
+//line cmd/ae-mcp/set_text_path_tool.gox:78:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/modify_text_tool.gox:37:1
+//line cmd/ae-mcp/set_text_path_tool.gox:82:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
-func (this *modify_text) Classclone() server.ToolProto {
+func (this *set_text_path) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
-//line cmd/ae-mcp/project_tool.gox:6
-// Tool for getting project information
-func (this *project) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
-//line cmd/ae-mcp/modify_text_tool.gox:37:1
+//line cmd/ae-mcp/shape_boolean_tool.gox:6
+// Tool for combining shape paths with union, intersect, difference or xor
+func (this *shape_boolean) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/set_text_path_tool.gox:82:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
-//line cmd/ae-mcp/project_tool.gox:7:1
-	this.Tool("ae_get_project_info", func() {
-//line cmd/ae-mcp/project_tool.gox:8:1
-		this.Description("Get information about the current After Effects project")
+//line cmd/ae-mcp/shape_boolean_tool.gox:7:1
+	this.Tool("ae_shape_boolean", func() {
+//line cmd/ae-mcp/shape_boolean_tool.gox:8:1
+		this.Description("Combine two sets of closed shape paths with union, intersect, difference (a minus b) or xor, computed deterministically instead of with Merge Paths. Returns the resulting paths and, when composition_name and layer_name are given, draws them as a filled shape layer in composition coordinates")
+//line cmd/ae-mcp/shape_boolean_tool.gox:9:1
+		this.String("operation", func() {
+//line cmd/ae-mcp/shape_boolean_tool.gox:10:1
+			this.Description("Boolean operation: union, intersect, difference or xor")
+//line cmd/ae-mcp/shape_boolean_tool.gox:11:1
+			this.Required()
+		})
+//line cmd/ae-mcp/shape_boolean_tool.gox:13:1
+		this.Array("shapes_a", func() {
+//line cmd/ae-mcp/shape_boolean_tool.gox:14:1
+			this.Description("First operand: array of paths, each {vertices: [[x, y], ...], inTangents: [[x, y], ...], outTangents: [[x, y], ...], closed: true}; tangents are relative to their vertex")
+//line cmd/ae-mcp/shape_boolean_tool.gox:15:1
+			this.Required()
+		})
+//line cmd/ae-mcp/shape_boolean_tool.gox:17:1
+		this.Array("shapes_b", func() {
+//line cmd/ae-mcp/shape_boolean_tool.gox:18:1
+			this.Description("Second operand, in the same format as shapes_a")
+//line cmd/ae-mcp/shape_boolean_tool.gox:19:1
+			this.Required()
+		})
+//line cmd/ae-mcp/shape_boolean_tool.gox:21:1
+		this.String("fill_rule", func() {
+//line cmd/ae-mcp/shape_boolean_tool.gox:22:1
+			this.Description("How the input paths define their area: nonzero (default) or evenodd")
+		})
+//line cmd/ae-mcp/shape_boolean_tool.gox:24:1
+		this.Float("tolerance", func() {
+//line cmd/ae-mcp/shape_boolean_tool.gox:25:1
+			this.Description("Allowed curve deviation in pixels (default: 0.5)")
+		})
+//line cmd/ae-mcp/shape_boolean_tool.gox:27:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/shape_boolean_tool.gox:28:1
+			this.Description("Composition to draw the result in (optional)")
+		})
+//line cmd/ae-mcp/shape_boolean_tool.gox:30:1
+		this.String("layer_name", func() {
+//line cmd/ae-mcp/shape_boolean_tool.gox:31:1
+			this.Description("Name of the shape layer to create for the result (optional)")
+		})
 	})
-//line cmd/ae-mcp/project_tool.gox:13:1
-	// Call the implementation in golang
-	var result map[string]interface{}
-//line cmd/ae-mcp/project_tool.gox:15:1
-	var err error
-//line cmd/ae-mcp/project_tool.gox:16:1
-	result, err = tools.GetProjectInfo()
-//line cmd/ae-mcp/project_tool.gox:17:1
+//line cmd/ae-mcp/shape_boolean_tool.gox:36:1
+	operation := this.Gop_Env("operation").(string)
+//line cmd/ae-mcp/shape_boolean_tool.gox:38:1
+	shapesA, err := tools.ParseShapeDataList(this.Gop_Env("shapes_a").([]interface{}))
+//line cmd/ae-mcp/shape_boolean_tool.gox:39:1
 	if err != nil {
-//line cmd/ae-mcp/project_tool.gox:18:1
+//line cmd/ae-mcp/shape_boolean_tool.gox:40:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/project_tool.gox:22:1
+//line cmd/ae-mcp/shape_boolean_tool.gox:44:1
+	shapesB, err := tools.ParseShapeDataList(this.Gop_Env("shapes_b").([]interface{}))
+//line cmd/ae-mcp/shape_boolean_tool.gox:45:1
+	if err != nil {
+//line cmd/ae-mcp/shape_boolean_tool.gox:46:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/shape_boolean_tool.gox:51:1
+	options := tools.ShapeOpOptions{}
+//line cmd/ae-mcp/shape_boolean_tool.gox:52:1
+	if this.Gop_Env("fill_rule") != nil {
+//line cmd/ae-mcp/shape_boolean_tool.gox:53:1
+		options.FillRule = this.Gop_Env("fill_rule").(string)
+	}
+//line cmd/ae-mcp/shape_boolean_tool.gox:55:1
+	if this.Gop_Env("tolerance") != nil {
+//line cmd/ae-mcp/shape_boolean_tool.gox:56:1
+		options.Tolerance = this.Gop_Env("tolerance").(float64)
+	}
+//line cmd/ae-mcp/shape_boolean_tool.gox:60:1
+	paths, err := tools.ShapeBoolean(operation, shapesA, shapesB, options)
+//line cmd/ae-mcp/shape_boolean_tool.gox:61:1
+	if err != nil {
+//line cmd/ae-mcp/shape_boolean_tool.gox:62:1
+		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+	}
+//line cmd/ae-mcp/shape_boolean_tool.gox:67:1
+	result := map[string]interface{}{"operation": operation, "paths": paths}
+//line cmd/ae-mcp/shape_boolean_tool.gox:71:1
+	if this.Gop_Env("composition_name") != nil && this.Gop_Env("layer_name") != nil {
+//line cmd/ae-mcp/shape_boolean_tool.gox:72:1
+		layer, err := tools.AddShapePathsLayer(this.Gop_Env("composition_name").(string), this.Gop_Env("layer_name").(string), paths)
+//line cmd/ae-mcp/shape_boolean_tool.gox:73:1
+		if err != nil {
+//line cmd/ae-mcp/shape_boolean_tool.gox:74:1
+			return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
+		}
+//line cmd/ae-mcp/shape_boolean_tool.gox:78:1
+		result["layer"] = layer
+	}
+//line cmd/ae-mcp/shape_boolean_tool.gox:80:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
-func (this *project) Classclone() server.ToolProto {
+func (this *shape_boolean) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
-//line cmd/ae-mcp/script_tool.gox:9
-// Tool for executing JavaScript code in After Effects
-// The After Effects Object Model provides programmatic access to the entire AE application structure:
-// - Application object (app): The global entry point to access AE settings and objects
-// - Project: Represents an AE project containing compositions, items, and render queue
-// - CompItem: Represents compositions that contain layers and can be rendered
-// - Layer objects: Various types (AVLayer, CameraLayer, LightLayer, ShapeLayer, TextLayer)
-// - Property objects: Represent AE properties on layers that can be animated with keyframes
-// - RenderQueue: Controls rendering operations and output modules
-// - Sources: Represent different types of footage (files, placeholders, solids)
-//
-// The hierarchy follows the UI structure: Application contains Projects, which contain
-// Compositions with Layers, which have Properties containing Markers and Keyframes.
-// The RenderQueue contains render settings and output modules.
-//
-// For complete documentation, refer to: https://ae-scripting.docsforadobe.dev/
-func (this *script) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
-//line cmd/ae-mcp/project_tool.gox:22:1
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:6
+// Tool for trimming a composition to its work area
+func (this *trim_comp_to_work_area) Main(_gop_arg0 context.Context, _gop_arg1 mcp.CallToolRequest, _gop_arg2 *server.ToolAppProto) mcp.Content {
+//line cmd/ae-mcp/shape_boolean_tool.gox:80:1
 	this.ToolApp.Main(_gop_arg0, _gop_arg1, _gop_arg2)
-//line cmd/ae-mcp/script_tool.gox:24:1
-	this.Tool("ae_execute_script", func() {
-//line cmd/ae-mcp/script_tool.gox:25:1
-		this.Description("Execute arbitrary JavaScript code in After Effects to interact with any object in the AE object model hierarchy including: Application, Project, Items (Compositions, Footage, Folders), Layers (AV, Camera, Light, Shape, Text), Properties, RenderQueue, Sources, and more. Scripts have full access to create, modify, and automate all aspects of After Effects projects. Full documentation: https://ae-scripting.docsforadobe.dev/")
-//line cmd/ae-mcp/script_tool.gox:26:1
-		this.String("script", func() {
-//line cmd/ae-mcp/script_tool.gox:27:1
-			this.Description("JavaScript code to execute. Can use the entire After Effects scripting object model including: app (Application), app.project (Project), CompItem, Layer objects, Property objects, and utility functions for time conversion and debugging. See https://ae-scripting.docsforadobe.dev/ for complete reference.")
-//line cmd/ae-mcp/script_tool.gox:28:1
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:7:1
+	this.Tool("ae_trim_comp_to_work_area", func() {
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:8:1
+		this.Description("Trim a composition to its work area, moving the work area start to time zero")
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:9:1
+		this.String("composition_name", func() {
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:10:1
+			this.Description("Name of the composition to trim")
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:11:1
 			this.Required()
 		})
 	})
-//line cmd/ae-mcp/script_tool.gox:33:1
-	scriptCode := this.Gop_Env("script").(string)
-//line cmd/ae-mcp/script_tool.gox:35:1
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:16:1
+	compName := this.Gop_Env("composition_name").(string)
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:18:1
 	// Call the implementation in golang
 	var result map[string]interface{}
-//line cmd/ae-mcp/script_tool.gox:37:1
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:20:1
 	var err error
-//line cmd/ae-mcp/script_tool.gox:38:1
-	result, err = tools.ExecuteScript(scriptCode)
-//line cmd/ae-mcp/script_tool.gox:39:1
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:21:1
+	result, err = tools.TrimCompToWorkArea(compName)
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:22:1
 	if err != nil {
-//line cmd/ae-mcp/script_tool.gox:40:1
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:23:1
 		return server.Text__1(server.JsonContent{JSON: map[string]string{"error": err.Error()}})
 	}
-//line cmd/ae-mcp/script_tool.gox:44:1
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:27:1
 	return server.Text__1(server.JsonContent{JSON: result})
 }
-func (this *script) Classclone() server.ToolProto {
+func (this *trim_comp_to_work_area) Classclone() server.ToolProto {
 	_gop_ret := *this
 	return &_gop_ret
 }
 func main() {
-//line cmd/ae-mcp/script_tool.gox:44:1
+//line cmd/ae-mcp/trim_comp_to_work_area_tool.gox:27:1
 	new(MCPApp).Main()
 }
@@ -49,6 +49,7 @@ func main() {
 		1080,           // height
 		180.0,          // duration (3 minutes)
 		60.0,           // frameRate
+		"",             // renderer
 	)
 	if err != nil {
 		log.Fatalf("Failed to create composition: %v", err)
@@ -19,7 +19,7 @@ func main() {
 	duration := 10.0 // 10 seconds
 	frameRate := 30.0
 
-	_, err := tools.CreateComposition(compName, width, height, duration, frameRate)
+	_, err := tools.CreateComposition(compName, width, height, duration, frameRate, "")
 	if err != nil {
 		log.Fatalf("Error creating composition: %v", err)
 	}
@@ -26,7 +26,7 @@ func main() {
 	duration := 10.0 // 10 seconds
 	frameRate := 30.0
 
-	compResult, err := tools.CreateComposition(compName, width, height, duration, frameRate)
+	compResult, err := tools.CreateComposition(compName, width, height, duration, frameRate, "")
 	if err != nil {
 		log.Fatalf("Error creating composition: %v", err)
 	}
@@ -18,7 +18,7 @@ func main() {
 	duration := 30.0
 	frameRate := 30.0
 
-	_, err := tools.CreateComposition(compName, width, height, duration, frameRate)
+	_, err := tools.CreateComposition(compName, width, height, duration, frameRate, "")
 	if err != nil {
 		log.Fatalf("Error creating composition: %v", err)
 	}
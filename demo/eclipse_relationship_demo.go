@@ -22,7 +22,7 @@ func main() {
 func Eclipse3DDemo() error {
 
 	// Create main composition
-	comp, err := tools.CreateComposition("Eclipse Animation", 1920, 1080, 20, 30)
+	comp, err := tools.CreateComposition("Eclipse Animation", 1920, 1080, 20, 30, "")
 	if err != nil {
 		return fmt.Errorf("failed to create composition: %w", err)
 	}
@@ -2,7 +2,10 @@ module github.com/sunqirui1987/ae-mcp
 
 go 1.23
 
-require github.com/mark3labs/mcp-go v0.22.0
+require (
+	github.com/mark3labs/mcp-go v0.22.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
 
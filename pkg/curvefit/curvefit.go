@@ -0,0 +1,418 @@
+// Package curvefit turns point sequences such as plotted functions, traced outlines and
+// hand-drawn strokes into smooth cubic Bezier paths.
+//
+// Fitting follows Philip J. Schneider's algorithm from Graphics Gems ("An Algorithm for
+// Automatically Fitting Digitized Curves"): each run of points between corners is fitted with
+// a least-squares cubic, reparameterized with Newton-Raphson, and split at the point of
+// maximum error until every point lies within the tolerance. Dense input can first be
+// thinned with Ramer-Douglas-Peucker simplification.
+package curvefit
+
+import (
+	"math"
+)
+
+// Point is an x, y coordinate
+type Point [2]float64
+
+// Path is a Bezier path. Vertices are absolute; tangents are relative to their vertex,
+// matching After Effects' Shape object.
+type Path struct {
+	Vertices    []Point
+	InTangents  []Point
+	OutTangents []Point
+	Closed      bool
+}
+
+// Options controls fitting
+type Options struct {
+	// Tolerance is the largest allowed distance between an input point and the fitted curve,
+	// default 1
+	Tolerance float64
+	// Simplify is the Ramer-Douglas-Peucker epsilon applied before fitting; 0 disables it
+	Simplify float64
+	// CornerAngle is the turn in degrees above which a point is kept as a sharp corner,
+	// default 70
+	CornerAngle float64
+	// Closed fits the points as a loop
+	Closed bool
+}
+
+// maxReparameterizations bounds the Newton-Raphson passes before a span is split
+const maxReparameterizations = 4
+
+// Fit fits cubic Beziers through points
+func Fit(points []Point, options Options) Path {
+	tolerance := options.Tolerance
+	if tolerance <= 0 {
+		tolerance = 1
+	}
+	cornerAngle := options.CornerAngle
+	if cornerAngle <= 0 {
+		cornerAngle = 70
+	}
+
+	points = dedupe(points, options.Closed)
+	if options.Simplify > 0 {
+		if options.Closed {
+			points = SimplifyClosed(points, options.Simplify)
+		} else {
+			points = Simplify(points, options.Simplify)
+		}
+	}
+
+	path := Path{Closed: options.Closed}
+	if len(points) < 2 {
+		for _, p := range points {
+			path.Vertices = append(path.Vertices, p)
+			path.InTangents = append(path.InTangents, Point{})
+			path.OutTangents = append(path.OutTangents, Point{})
+		}
+		return path
+	}
+
+	corners := findCorners(points, options.Closed, cornerAngle*math.Pi/180)
+	f := &fitter{tolerance: tolerance}
+
+	switch {
+	case !options.Closed:
+		spans := append([]int{0}, corners...)
+		spans = append(spans, len(points)-1)
+		for i := 0; i+1 < len(spans); i++ {
+			f.fitSpan(points[spans[i] : spans[i+1]+1])
+		}
+	case len(corners) == 0:
+		// A smooth loop: start anywhere and make the tangent continuous where it closes
+		loop := append(append([]Point{}, points...), points[0])
+		tangent := centerTangent(points[len(points)-1], points[1])
+		f.fitCubic(loop, tangent, scale(tangent, -1))
+	default:
+		// Rotate the loop to start on a corner and fit between consecutive corners
+		start := corners[0]
+		loop := append(append([]Point{}, points[start:]...), points[:start+1]...)
+		spans := []int{0}
+		for _, c := range corners[1:] {
+			spans = append(spans, c-start)
+		}
+		spans = append(spans, len(loop)-1)
+		for i := 0; i+1 < len(spans); i++ {
+			f.fitSpan(loop[spans[i] : spans[i+1]+1])
+		}
+	}
+
+	return f.path(options.Closed)
+}
+
+// Simplify removes points that lie within epsilon of the polyline through their neighbors,
+// using the Ramer-Douglas-Peucker algorithm. The first and last points are always kept.
+func Simplify(points []Point, epsilon float64) []Point {
+	if len(points) < 3 {
+		return append([]Point{}, points...)
+	}
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+	rdp(points, 0, len(points)-1, epsilon, keep)
+
+	simplified := make([]Point, 0, len(points))
+	for i, p := range points {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+	return simplified
+}
+
+// SimplifyClosed simplifies a closed polygon. The loop is split at the point farthest from
+// the first point so both halves are simplified as open polylines.
+func SimplifyClosed(points []Point, epsilon float64) []Point {
+	if len(points) < 4 {
+		return append([]Point{}, points...)
+	}
+	far, farDistance := 0, -1.0
+	for i, p := range points {
+		if d := distance(p, points[0]); d > farDistance {
+			far, farDistance = i, d
+		}
+	}
+
+	first := Simplify(points[:far+1], epsilon)
+	second := Simplify(append(append([]Point{}, points[far:]...), points[0]), epsilon)
+	simplified := append(first, second[1:len(second)-1]...)
+	return simplified
+}
+
+// rdp marks the points between first and last that must be kept
+func rdp(points []Point, first int, last int, epsilon float64, keep []bool) {
+	index, maxDistance := -1, epsilon
+	for i := first + 1; i < last; i++ {
+		if d := segmentDistance(points[i], points[first], points[last]); d > maxDistance {
+			index, maxDistance = i, d
+		}
+	}
+	if index < 0 {
+		return
+	}
+	keep[index] = true
+	rdp(points, first, index, epsilon, keep)
+	rdp(points, index, last, epsilon, keep)
+}
+
+// fitter accumulates fitted cubic segments
+type fitter struct {
+	tolerance float64
+	segments  [][4]Point
+}
+
+// fitSpan fits the points between two corners or path ends, using one-sided end tangents so
+// the spans meeting at a corner keep their own directions
+func (f *fitter) fitSpan(points []Point) {
+	n := len(points)
+	f.fitCubic(points, normalize(sub(points[1], points[0])), normalize(sub(points[n-2], points[n-1])))
+}
+
+// fitCubic fits a Bezier to points with the given unit end tangents, splitting as needed
+func (f *fitter) fitCubic(points []Point, tHat1 Point, tHat2 Point) {
+	n := len(points)
+	if n == 2 {
+		d := distance(points[0], points[1]) / 3
+		f.segments = append(f.segments, [4]Point{
+			points[0],
+			add(points[0], scale(tHat1, d)),
+			add(points[1], scale(tHat2, d)),
+			points[1],
+		})
+		return
+	}
+
+	u := chordLengthParameterize(points)
+	bezier := generateBezier(points, u, tHat1, tHat2)
+	maxError, split := maxFitError(points, bezier, u)
+	if maxError <= f.tolerance {
+		f.segments = append(f.segments, bezier)
+		return
+	}
+
+	// Close misses are often fixed by reparameterizing instead of splitting
+	if maxError <= f.tolerance*4 {
+		for i := 0; i < maxReparameterizations; i++ {
+			u = reparameterize(points, u, bezier)
+			bezier = generateBezier(points, u, tHat1, tHat2)
+			maxError, split = maxFitError(points, bezier, u)
+			if maxError <= f.tolerance {
+				f.segments = append(f.segments, bezier)
+				return
+			}
+		}
+	}
+
+	center := centerTangent(points[split-1], points[split+1])
+	f.fitCubic(points[:split+1], tHat1, scale(center, -1))
+	f.fitCubic(points[split:], center, tHat2)
+}
+
+// path joins the fitted segments into a path with relative tangents
+func (f *fitter) path(closed bool) Path {
+	path := Path{Closed: closed}
+	if len(f.segments) == 0 {
+		return path
+	}
+
+	path.Vertices = append(path.Vertices, f.segments[0][0])
+	path.InTangents = append(path.InTangents, Point{})
+	path.OutTangents = append(path.OutTangents, Point{})
+	for _, segment := range f.segments {
+		last := len(path.Vertices) - 1
+		path.OutTangents[last] = sub(segment[1], segment[0])
+		path.Vertices = append(path.Vertices, segment[3])
+		path.InTangents = append(path.InTangents, sub(segment[2], segment[3]))
+		path.OutTangents = append(path.OutTangents, Point{})
+	}
+
+	// The closing vertex repeats the first; fold its incoming handle into the first vertex
+	if closed && len(path.Vertices) > 1 {
+		last := len(path.Vertices) - 1
+		path.InTangents[0] = path.InTangents[last]
+		path.Vertices = path.Vertices[:last]
+		path.InTangents = path.InTangents[:last]
+		path.OutTangents = path.OutTangents[:last]
+	}
+	return path
+}
+
+// generateBezier finds the least-squares handle lengths along the end tangents
+func generateBezier(points []Point, u []float64, tHat1 Point, tHat2 Point) [4]Point {
+	n := len(points)
+	first, last := points[0], points[n-1]
+
+	var c [2][2]float64
+	var x [2]float64
+	for i := 0; i < n; i++ {
+		b0, b1, b2, b3 := bernstein(u[i])
+		a1, a2 := scale(tHat1, b1), scale(tHat2, b2)
+		c[0][0] += dot(a1, a1)
+		c[0][1] += dot(a1, a2)
+		c[1][1] += dot(a2, a2)
+		tmp := sub(points[i], add(scale(first, b0+b1), scale(last, b2+b3)))
+		x[0] += dot(a1, tmp)
+		x[1] += dot(a2, tmp)
+	}
+	c[1][0] = c[0][1]
+
+	detC0C1 := c[0][0]*c[1][1] - c[1][0]*c[0][1]
+	detC0X := c[0][0]*x[1] - c[1][0]*x[0]
+	detXC1 := x[0]*c[1][1] - x[1]*c[0][1]
+	alpha1, alpha2 := 0.0, 0.0
+	if detC0C1 != 0 {
+		alpha1, alpha2 = detXC1/detC0C1, detC0X/detC0C1
+	}
+
+	// Degenerate or negative handles fall back to a third of the chord, as in the paper
+	segmentLength := distance(first, last)
+	epsilon := 1e-6 * segmentLength
+	if alpha1 < epsilon || alpha2 < epsilon {
+		alpha1, alpha2 = segmentLength/3, segmentLength/3
+	}
+
+	return [4]Point{first, add(first, scale(tHat1, alpha1)), add(last, scale(tHat2, alpha2)), last}
+}
+
+// reparameterize improves each point's curve parameter with one Newton-Raphson step
+func reparameterize(points []Point, u []float64, bezier [4]Point) []float64 {
+	improved := make([]float64, len(u))
+	for i, p := range points {
+		improved[i] = newtonRaphson(bezier, p, u[i])
+	}
+	return improved
+}
+
+// newtonRaphson moves u toward the curve point closest to p
+func newtonRaphson(bezier [4]Point, p Point, u float64) float64 {
+	q := evaluate(bezier, u)
+	d1 := derivative(bezier, u)
+	d2 := secondDerivative(bezier, u)
+	diff := sub(q, p)
+	numerator := dot(diff, d1)
+	denominator := dot(d1, d1) + dot(diff, d2)
+	if denominator == 0 {
+		return u
+	}
+	return math.Max(0, math.Min(1, u-numerator/denominator))
+}
+
+// maxFitError returns the largest distance from a point to the curve and that point's index
+func maxFitError(points []Point, bezier [4]Point, u []float64) (float64, int) {
+	n := len(points)
+	split := n / 2
+	maxDistance := 0.0
+	for i := 1; i < n-1; i++ {
+		if d := distance(evaluate(bezier, u[i]), points[i]); d >= maxDistance {
+			maxDistance, split = d, i
+		}
+	}
+	return maxDistance, split
+}
+
+// chordLengthParameterize assigns each point a parameter proportional to the distance along
+// the polyline
+func chordLengthParameterize(points []Point) []float64 {
+	u := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		u[i] = u[i-1] + distance(points[i], points[i-1])
+	}
+	total := u[len(u)-1]
+	for i := range u {
+		if total > 0 {
+			u[i] /= total
+		} else {
+			u[i] = float64(i) / float64(len(u)-1)
+		}
+	}
+	return u
+}
+
+// findCorners returns the indices of points where the polyline turns more than maxTurn.
+// Path ends of open paths are not reported.
+func findCorners(points []Point, closed bool, maxTurn float64) []int {
+	n := len(points)
+	var corners []int
+	for i := 0; i < n; i++ {
+		if !closed && (i == 0 || i == n-1) {
+			continue
+		}
+		prev, next := points[(i+n-1)%n], points[(i+1)%n]
+		a, b := normalize(sub(points[i], prev)), normalize(sub(next, points[i]))
+		if math.Acos(math.Max(-1, math.Min(1, dot(a, b)))) > maxTurn {
+			corners = append(corners, i)
+		}
+	}
+	return corners
+}
+
+// dedupe drops consecutive duplicate points, including a closing point that repeats the first
+func dedupe(points []Point, closed bool) []Point {
+	unique := make([]Point, 0, len(points))
+	for _, p := range points {
+		if len(unique) == 0 || distance(p, unique[len(unique)-1]) > 1e-9 {
+			unique = append(unique, p)
+		}
+	}
+	if closed && len(unique) > 1 && distance(unique[0], unique[len(unique)-1]) <= 1e-9 {
+		unique = unique[:len(unique)-1]
+	}
+	return unique
+}
+
+// centerTangent is the unit direction from a point's predecessor to its successor
+func centerTangent(prev Point, next Point) Point {
+	return normalize(sub(next, prev))
+}
+
+func bernstein(u float64) (float64, float64, float64, float64) {
+	mu := 1 - u
+	return mu * mu * mu, 3 * u * mu * mu, 3 * u * u * mu, u * u * u
+}
+
+func evaluate(b [4]Point, u float64) Point {
+	b0, b1, b2, b3 := bernstein(u)
+	return Point{
+		b0*b[0][0] + b1*b[1][0] + b2*b[2][0] + b3*b[3][0],
+		b0*b[0][1] + b1*b[1][1] + b2*b[2][1] + b3*b[3][1],
+	}
+}
+
+func derivative(b [4]Point, u float64) Point {
+	mu := 1 - u
+	d0, d1, d2 := scale(sub(b[1], b[0]), 3), scale(sub(b[2], b[1]), 3), scale(sub(b[3], b[2]), 3)
+	return add(add(scale(d0, mu*mu), scale(d1, 2*u*mu)), scale(d2, u*u))
+}
+
+func secondDerivative(b [4]Point, u float64) Point {
+	e0 := scale(add(sub(b[2], scale(b[1], 2)), b[0]), 6)
+	e1 := scale(add(sub(b[3], scale(b[2], 2)), b[1]), 6)
+	return add(scale(e0, 1-u), scale(e1, u))
+}
+
+// segmentDistance is the distance from p to the segment a-b
+func segmentDistance(p Point, a Point, b Point) float64 {
+	ab := sub(b, a)
+	lengthSquared := dot(ab, ab)
+	if lengthSquared == 0 {
+		return distance(p, a)
+	}
+	t := math.Max(0, math.Min(1, dot(sub(p, a), ab)/lengthSquared))
+	return distance(p, add(a, scale(ab, t)))
+}
+
+func add(a Point, b Point) Point        { return Point{a[0] + b[0], a[1] + b[1]} }
+func sub(a Point, b Point) Point        { return Point{a[0] - b[0], a[1] - b[1]} }
+func scale(a Point, s float64) Point    { return Point{a[0] * s, a[1] * s} }
+func dot(a Point, b Point) float64      { return a[0]*b[0] + a[1]*b[1] }
+func distance(a Point, b Point) float64 { return math.Hypot(a[0]-b[0], a[1]-b[1]) }
+
+func normalize(a Point) Point {
+	l := math.Hypot(a[0], a[1])
+	if l == 0 {
+		return a
+	}
+	return Point{a[0] / l, a[1] / l}
+}
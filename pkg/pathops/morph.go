@@ -0,0 +1,176 @@
+package pathops
+
+import (
+	"fmt"
+	"math"
+)
+
+// Match prepares two paths for morphing. Both are subdivided, without changing their
+// shape, to a common vertex count of at least minVertices; to is then reversed if it winds
+// the other way and rotated so its start vertex minimizes the total distance each vertex
+// travels. Both paths must be closed or both open.
+func Match(from Path, to Path, minVertices int) (Path, Path, error) {
+	if len(from.Vertices) < 2 || len(to.Vertices) < 2 {
+		return from, to, fmt.Errorf("morphing needs paths with at least 2 vertices: %w", ErrInvalidOptions)
+	}
+	if from.Closed != to.Closed {
+		return from, to, fmt.Errorf("cannot morph between an open and a closed path: %w", ErrInvalidOptions)
+	}
+
+	from, to = withTangents(from), withTangents(to)
+	count := len(from.Vertices)
+	if len(to.Vertices) > count {
+		count = len(to.Vertices)
+	}
+	if minVertices > count {
+		count = minVertices
+	}
+	from, to = subdivideTo(from, count), subdivideTo(to, count)
+
+	if !from.Closed {
+		// Open paths keep their ends; only the direction can change
+		if travel(from, reversePath(to), 0) < travel(from, to, 0) {
+			to = reversePath(to)
+		}
+		return from, to, nil
+	}
+
+	if (pathArea(from) < 0) != (pathArea(to) < 0) {
+		to = reversePath(to)
+	}
+	best, bestTravel := 0, math.Inf(1)
+	for shift := 0; shift < count; shift++ {
+		if t := travel(from, to, shift); t < bestTravel {
+			best, bestTravel = shift, t
+		}
+	}
+	return from, rotatePath(to, best), nil
+}
+
+// withTangents copies a path, filling in missing tangents with zeros
+func withTangents(path Path) Path {
+	n := len(path.Vertices)
+	copied := Path{Closed: path.Closed, Vertices: append([]Point{}, path.Vertices...)}
+	copied.InTangents = make([]Point, n)
+	copied.OutTangents = make([]Point, n)
+	copy(copied.InTangents, path.InTangents)
+	copy(copied.OutTangents, path.OutTangents)
+	return copied
+}
+
+// subdivideTo splits the longest segments in half until the path has count vertices. Ties
+// are broken round-robin from the last split so equal segments are split evenly.
+func subdivideTo(path Path, count int) Path {
+	start := 0
+	for len(path.Vertices) < count {
+		segments := len(path.Vertices)
+		if !path.Closed {
+			segments--
+		}
+		longest, longestLength := 0, -1.0
+		for k := 0; k < segments; k++ {
+			i := (start + k) % segments
+			p0, c1, c2, p1 := segment(path, i)
+			// Average of chord and control polygon lengths approximates the arc length
+			l := (length(sub(p1, p0)) + length(sub(c1, p0)) + length(sub(c2, c1)) + length(sub(p1, c2))) / 2
+			if l > longestLength+1e-9 {
+				longest, longestLength = i, l
+			}
+		}
+		path = splitSegment(path, longest)
+		start = longest + 2
+	}
+	return path
+}
+
+// segment returns the absolute control points of the segment starting at vertex i
+func segment(path Path, i int) (Point, Point, Point, Point) {
+	j := (i + 1) % len(path.Vertices)
+	p0, p1 := path.Vertices[i], path.Vertices[j]
+	return p0, add(p0, path.OutTangents[i]), add(p1, path.InTangents[j]), p1
+}
+
+// splitSegment inserts a vertex halfway along the segment starting at vertex i
+func splitSegment(path Path, i int) Path {
+	j := (i + 1) % len(path.Vertices)
+	p0, c1, c2, p1 := segment(path, i)
+	if path.OutTangents[i] == (Point{}) && path.InTangents[j] == (Point{}) {
+		// Straight segments stay straight
+		insert := i + 1
+		path.Vertices = insertPoint(path.Vertices, insert, mid(p0, p1))
+		path.InTangents = insertPoint(path.InTangents, insert, Point{})
+		path.OutTangents = insertPoint(path.OutTangents, insert, Point{})
+		return path
+	}
+
+	// de Casteljau at t = 0.5
+	a, b, c := mid(p0, c1), mid(c1, c2), mid(c2, p1)
+	d, e := mid(a, b), mid(b, c)
+	m := mid(d, e)
+
+	path.OutTangents[i] = sub(a, p0)
+	path.InTangents[j] = sub(c, p1)
+
+	insert := i + 1
+	path.Vertices = insertPoint(path.Vertices, insert, m)
+	path.InTangents = insertPoint(path.InTangents, insert, sub(d, m))
+	path.OutTangents = insertPoint(path.OutTangents, insert, sub(e, m))
+	return path
+}
+
+func insertPoint(points []Point, index int, p Point) []Point {
+	points = append(points, Point{})
+	copy(points[index+1:], points[index:])
+	points[index] = p
+	return points
+}
+
+// reversePath reverses a path's direction, swapping in and out tangents
+func reversePath(path Path) Path {
+	n := len(path.Vertices)
+	reversed := Path{Closed: path.Closed}
+	for i := n - 1; i >= 0; i-- {
+		reversed.Vertices = append(reversed.Vertices, path.Vertices[i])
+		reversed.InTangents = append(reversed.InTangents, path.OutTangents[i])
+		reversed.OutTangents = append(reversed.OutTangents, path.InTangents[i])
+	}
+	return reversed
+}
+
+// rotatePath makes vertex shift the first vertex of a closed path
+func rotatePath(path Path, shift int) Path {
+	n := len(path.Vertices)
+	rotated := Path{Closed: path.Closed}
+	for i := 0; i < n; i++ {
+		k := (i + shift) % n
+		rotated.Vertices = append(rotated.Vertices, path.Vertices[k])
+		rotated.InTangents = append(rotated.InTangents, path.InTangents[k])
+		rotated.OutTangents = append(rotated.OutTangents, path.OutTangents[k])
+	}
+	return rotated
+}
+
+// travel sums the squared distances between from's vertices and to's vertices offset by shift
+func travel(from Path, to Path, shift int) float64 {
+	n := len(from.Vertices)
+	total := 0.0
+	for i := 0; i < n; i++ {
+		d := sub(from.Vertices[i], to.Vertices[(i+shift)%n])
+		total += dot(d, d)
+	}
+	return total
+}
+
+// pathArea is the signed area of a path's control polygon, whose sign gives the winding
+func pathArea(path Path) float64 {
+	var points []Point
+	for i := range path.Vertices {
+		p0, c1, c2, _ := segment(path, i)
+		points = append(points, p0, c1, c2)
+	}
+	return signedArea(points)
+}
+
+func mid(a Point, b Point) Point {
+	return Point{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2}
+}
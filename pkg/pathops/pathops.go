@@ -0,0 +1,684 @@
+// Package pathops performs boolean operations and offsets on closed Bezier paths, and matches
+// paths up for morphing.
+//
+// Paths are flattened to polygons, combined by splitting every edge at its intersections and
+// keeping the edges that separate the inside of the result from the outside, then chained
+// into contours. Curved runs are refitted with cubic Beziers; straight edges stay straight.
+// Results are oriented so holes wind opposite to their outer contour and draw correctly with
+// the nonzero fill rule.
+package pathops
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/curvefit"
+)
+
+// Point is an x, y coordinate
+type Point = curvefit.Point
+
+// Path is a Bezier path with tangents relative to their vertex. Open paths are treated as
+// closed.
+type Path = curvefit.Path
+
+// Op is a boolean operation
+type Op string
+
+// Boolean operations
+const (
+	Union      Op = "union"
+	Intersect  Op = "intersect"
+	Difference Op = "difference"
+	Xor        Op = "xor"
+)
+
+// Options controls flattening, fill rules and joins
+type Options struct {
+	// FillRule decides which areas of the input paths are inside: "nonzero" (default) or
+	// "evenodd"
+	FillRule string
+	// Tolerance is the allowed deviation in pixels when flattening and refitting curves,
+	// default 0.5
+	Tolerance float64
+	// Join is the corner style of offsets: "round" (default), "miter" or "bevel"
+	Join string
+	// MiterLimit caps miter joins as a multiple of the distance, default 4
+	MiterLimit float64
+}
+
+// ErrInvalidOptions reports an unknown operation, fill rule or join
+var ErrInvalidOptions = errors.New("invalid path operation options")
+
+// Boolean combines the areas of a and b
+func Boolean(op Op, a []Path, b []Path, options Options) ([]Path, error) {
+	keep, err := opFunc(op)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := fillRule(options.FillRule)
+	if err != nil {
+		return nil, err
+	}
+	tolerance := defaultTolerance(options.Tolerance)
+
+	ringsA := flattenPaths(a, tolerance/4)
+	ringsB := flattenPaths(b, tolerance/4)
+	inside := func(p Point) bool {
+		return keep(rule(winding(ringsA, p)), rule(winding(ringsB, p)))
+	}
+	return combine(append(append([]ring{}, ringsA...), ringsB...), inside, tolerance), nil
+}
+
+// Offset grows (positive distance) or shrinks (negative distance) the area of paths
+func Offset(paths []Path, distance float64, options Options) ([]Path, error) {
+	rule, err := fillRule(options.FillRule)
+	if err != nil {
+		return nil, err
+	}
+	join := options.Join
+	if join == "" {
+		join = "round"
+	}
+	if join != "round" && join != "miter" && join != "bevel" {
+		return nil, fmt.Errorf("unknown join %q (valid: round, miter, bevel): %w", join, ErrInvalidOptions)
+	}
+	miterLimit := options.MiterLimit
+	if miterLimit <= 0 {
+		miterLimit = 4
+	}
+	tolerance := defaultTolerance(options.Tolerance)
+
+	rings := flattenPaths(paths, tolerance/4)
+	if distance == 0 {
+		return combine(rings, func(p Point) bool { return rule(winding(rings, p)) }, tolerance), nil
+	}
+
+	// The band swept by a disk of the offset radius along every edge: grown areas add it,
+	// shrunk areas remove it
+	d := math.Abs(distance)
+	band := strokeBand(rings, d, join, miterLimit, tolerance/4)
+	inside := func(p Point) bool {
+		inOriginal := rule(winding(rings, p))
+		inBand := winding(band, p) != 0
+		if distance > 0 {
+			return inOriginal || inBand
+		}
+		return inOriginal && !inBand
+	}
+	return combine(append(append([]ring{}, rings...), band...), inside, tolerance), nil
+}
+
+// opFunc returns the inside test of a boolean operation
+func opFunc(op Op) (func(bool, bool) bool, error) {
+	switch op {
+	case Union:
+		return func(a, b bool) bool { return a || b }, nil
+	case Intersect:
+		return func(a, b bool) bool { return a && b }, nil
+	case Difference:
+		return func(a, b bool) bool { return a && !b }, nil
+	case Xor:
+		return func(a, b bool) bool { return a != b }, nil
+	}
+	return nil, fmt.Errorf("unknown operation %q (valid: union, intersect, difference, xor): %w", op, ErrInvalidOptions)
+}
+
+// fillRule returns the inside test for a winding number
+func fillRule(name string) (func(int) bool, error) {
+	switch name {
+	case "", "nonzero":
+		return func(w int) bool { return w != 0 }, nil
+	case "evenodd":
+		return func(w int) bool { return w%2 != 0 }, nil
+	}
+	return nil, fmt.Errorf("unknown fill rule %q (valid: nonzero, evenodd): %w", name, ErrInvalidOptions)
+}
+
+func defaultTolerance(tolerance float64) float64 {
+	if tolerance <= 0 {
+		return 0.5
+	}
+	return tolerance
+}
+
+// ring is a closed polygon; curved[i] marks the edge from points[i] to the next point as part
+// of a flattened curve
+type ring struct {
+	points []Point
+	curved []bool
+}
+
+// edge is a directed polygon edge
+type edge struct {
+	a, b   Point
+	curved bool
+}
+
+// flattenPaths converts paths into polygons within tolerance of their curves
+func flattenPaths(paths []Path, tolerance float64) []ring {
+	var rings []ring
+	for _, path := range paths {
+		n := len(path.Vertices)
+		if n < 2 {
+			continue
+		}
+		var r ring
+		for i := 0; i < n; i++ {
+			j := (i + 1) % n
+			p0, p1 := path.Vertices[i], path.Vertices[j]
+			c1, c2 := p0, p1
+			if i < len(path.OutTangents) {
+				c1 = add(p0, path.OutTangents[i])
+			}
+			if j < len(path.InTangents) {
+				c2 = add(p1, path.InTangents[j])
+			}
+
+			if isStraight(p0, c1, c2, p1) {
+				r.points = append(r.points, p0)
+				r.curved = append(r.curved, false)
+				continue
+			}
+			steps := flattenSteps(p0, c1, c2, p1, tolerance)
+			for s := 0; s < steps; s++ {
+				r.points = append(r.points, cubicPoint(p0, c1, c2, p1, float64(s)/float64(steps)))
+				r.curved = append(r.curved, true)
+			}
+		}
+		if r = cleanRing(r); len(r.points) >= 3 {
+			rings = append(rings, r)
+		}
+	}
+	return rings
+}
+
+// isStraight reports whether a cubic's handles lie on its chord
+func isStraight(p0, c1, c2, p1 Point) bool {
+	if c1 == p0 && c2 == p1 {
+		return true
+	}
+	chord := sub(p1, p0)
+	chordLength := length(chord)
+	if chordLength == 0 {
+		return false
+	}
+	d1 := math.Abs(cross(chord, sub(c1, p0))) / chordLength
+	d2 := math.Abs(cross(chord, sub(c2, p0))) / chordLength
+	return d1 < 1e-6 && d2 < 1e-6 && dot(chord, sub(c1, p0)) >= 0 && dot(chord, sub(c2, p1)) <= 0
+}
+
+// flattenSteps uses Wang's formula to pick a segment count for a cubic
+func flattenSteps(p0, c1, c2, p1 Point, tolerance float64) int {
+	m := math.Max(length(add(sub(p0, scale(c1, 2)), c2)), length(add(sub(c1, scale(c2, 2)), p1)))
+	steps := int(math.Ceil(math.Sqrt(0.75 * m / tolerance)))
+	if steps < 2 {
+		steps = 2
+	}
+	if steps > 256 {
+		steps = 256
+	}
+	return steps
+}
+
+// cleanRing drops repeated points
+func cleanRing(r ring) ring {
+	var cleaned ring
+	for i, p := range r.points {
+		if len(cleaned.points) > 0 && samePoint(p, cleaned.points[len(cleaned.points)-1]) {
+			continue
+		}
+		cleaned.points = append(cleaned.points, p)
+		cleaned.curved = append(cleaned.curved, r.curved[i])
+	}
+	for len(cleaned.points) > 1 && samePoint(cleaned.points[0], cleaned.points[len(cleaned.points)-1]) {
+		cleaned.points = cleaned.points[:len(cleaned.points)-1]
+		cleaned.curved = cleaned.curved[:len(cleaned.curved)-1]
+	}
+	return cleaned
+}
+
+// winding returns the winding number of the rings around p
+func winding(rings []ring, p Point) int {
+	w := 0
+	for _, r := range rings {
+		n := len(r.points)
+		for i := 0; i < n; i++ {
+			a, b := r.points[i], r.points[(i+1)%n]
+			if a[1] <= p[1] {
+				if b[1] > p[1] && cross(sub(b, a), sub(p, a)) > 0 {
+					w++
+				}
+			} else if b[1] <= p[1] && cross(sub(b, a), sub(p, a)) < 0 {
+				w--
+			}
+		}
+	}
+	return w
+}
+
+// combine splits the ring edges at their intersections, keeps the edges that separate inside
+// from outside, and chains them into refitted paths
+func combine(rings []ring, inside func(Point) bool, tolerance float64) []Path {
+	var edges []edge
+	for _, r := range rings {
+		n := len(r.points)
+		for i := 0; i < n; i++ {
+			edges = append(edges, edge{a: r.points[i], b: r.points[(i+1)%n], curved: r.curved[i]})
+		}
+	}
+
+	var kept []edge
+	seen := map[[2]pointKey]bool{}
+	for _, e := range splitEdges(edges) {
+		ka, kb := keyOf(e.a), keyOf(e.b)
+		if ka == kb {
+			continue
+		}
+		// Coincident edges from different contours are classified once
+		pair := [2]pointKey{ka, kb}
+		if kb.less(ka) {
+			pair = [2]pointKey{kb, ka}
+		}
+		if seen[pair] {
+			continue
+		}
+		seen[pair] = true
+
+		dir := sub(e.b, e.a)
+		l := length(dir)
+		normal := Point{-dir[1] / l, dir[0] / l}
+		eps := math.Min(1e-3, l*0.1)
+		mid := scale(add(e.a, e.b), 0.5)
+		left := inside(add(mid, scale(normal, eps)))
+		right := inside(sub(mid, scale(normal, eps)))
+		if left == right {
+			continue
+		}
+		// Orient every kept edge with the inside on its left
+		if right {
+			e.a, e.b = e.b, e.a
+		}
+		kept = append(kept, e)
+	}
+
+	var paths []Path
+	for _, r := range chainEdges(kept) {
+		if r = mergeCollinear(r); len(r.points) >= 3 {
+			paths = append(paths, refit(r, tolerance))
+		}
+	}
+	return paths
+}
+
+// pointKey identifies a point for matching edge endpoints
+type pointKey [2]int64
+
+func keyOf(p Point) pointKey {
+	return pointKey{int64(math.Round(p[0] * 1e6)), int64(math.Round(p[1] * 1e6))}
+}
+
+func (k pointKey) less(o pointKey) bool {
+	return k[0] < o[0] || (k[0] == o[0] && k[1] < o[1])
+}
+
+func samePoint(a Point, b Point) bool {
+	return keyOf(a) == keyOf(b)
+}
+
+// splitEdges splits every edge at the points where other edges cross or touch it
+func splitEdges(edges []edge) []edge {
+	n := len(edges)
+	cuts := make([][]Point, n)
+	boxes := make([][4]float64, n)
+	for i, e := range edges {
+		boxes[i] = [4]float64{math.Min(e.a[0], e.b[0]), math.Min(e.a[1], e.b[1]), math.Max(e.a[0], e.b[0]), math.Max(e.a[1], e.b[1])}
+	}
+
+	// Sweep in x order so only edges with overlapping x ranges are compared
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(x, y int) bool { return boxes[order[x]][0] < boxes[order[y]][0] })
+
+	const slack = 1e-9
+	for oi, i := range order {
+		for _, j := range order[oi+1:] {
+			if boxes[j][0] > boxes[i][2]+slack {
+				break
+			}
+			if boxes[j][1] > boxes[i][3]+slack || boxes[j][3] < boxes[i][1]-slack {
+				continue
+			}
+			for _, p := range intersections(edges[i], edges[j]) {
+				cuts[i] = append(cuts[i], p)
+				cuts[j] = append(cuts[j], p)
+			}
+		}
+	}
+
+	var split []edge
+	for i, e := range edges {
+		points := cuts[i]
+		dir := sub(e.b, e.a)
+		sort.Slice(points, func(x, y int) bool { return dot(sub(points[x], e.a), dir) < dot(sub(points[y], e.a), dir) })
+		start := e.a
+		for _, p := range points {
+			if samePoint(p, start) || samePoint(p, e.b) {
+				continue
+			}
+			split = append(split, edge{a: start, b: p, curved: e.curved})
+			start = p
+		}
+		split = append(split, edge{a: start, b: e.b, curved: e.curved})
+	}
+	return split
+}
+
+// intersections returns the points where two edges meet, snapped to endpoints when they touch
+func intersections(e edge, f edge) []Point {
+	r, s := sub(e.b, e.a), sub(f.b, f.a)
+	denom := cross(r, s)
+	qp := sub(f.a, e.a)
+	lr, ls := length(r), length(s)
+	if lr == 0 || ls == 0 {
+		return nil
+	}
+
+	const eps = 1e-9
+	if math.Abs(denom) <= eps*lr*ls {
+		// Parallel: only collinear overlaps matter, where each edge's endpoints cut the other
+		if math.Abs(cross(qp, r))/lr > 1e-7 {
+			return nil
+		}
+		var points []Point
+		for _, p := range []Point{f.a, f.b} {
+			if t := dot(sub(p, e.a), r) / (lr * lr); t > eps && t < 1-eps {
+				points = append(points, p)
+			}
+		}
+		for _, p := range []Point{e.a, e.b} {
+			if t := dot(sub(p, f.a), s) / (ls * ls); t > eps && t < 1-eps {
+				points = append(points, p)
+			}
+		}
+		return points
+	}
+
+	t := cross(qp, s) / denom
+	u := cross(qp, r) / denom
+	tEps, uEps := 1e-9*math.Max(1, 1/lr), 1e-9*math.Max(1, 1/ls)
+	if t < -tEps || t > 1+tEps || u < -uEps || u > 1+uEps {
+		return nil
+	}
+	switch {
+	case t <= tEps:
+		return []Point{e.a}
+	case t >= 1-tEps:
+		return []Point{e.b}
+	case u <= uEps:
+		return []Point{f.a}
+	case u >= 1-uEps:
+		return []Point{f.b}
+	}
+	return []Point{add(e.a, scale(r, t))}
+}
+
+// chainEdges joins oriented edges into closed rings. Where several edges leave a vertex, the
+// one turning most sharply left is taken so touching contours stay separate.
+func chainEdges(edges []edge) []ring {
+	outgoing := map[pointKey][]int{}
+	for i, e := range edges {
+		k := keyOf(e.a)
+		outgoing[k] = append(outgoing[k], i)
+	}
+
+	used := make([]bool, len(edges))
+	var rings []ring
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+		var r ring
+		current := start
+		startKey := keyOf(edges[start].a)
+		for {
+			used[current] = true
+			e := edges[current]
+			r.points = append(r.points, e.a)
+			r.curved = append(r.curved, e.curved)
+			endKey := keyOf(e.b)
+			if endKey == startKey {
+				break
+			}
+
+			next := -1
+			bestTurn := math.Inf(1)
+			incoming := sub(e.b, e.a)
+			for _, candidate := range outgoing[endKey] {
+				if used[candidate] {
+					continue
+				}
+				out := sub(edges[candidate].b, edges[candidate].a)
+				turn := -math.Atan2(cross(incoming, out), dot(incoming, out))
+				if turn < bestTurn {
+					next, bestTurn = candidate, turn
+				}
+			}
+			if next < 0 {
+				// An unclosed chain means the input was degenerate; drop it
+				r = ring{}
+				break
+			}
+			current = next
+		}
+		if len(r.points) >= 3 {
+			rings = append(rings, r)
+		}
+	}
+	return rings
+}
+
+// mergeCollinear removes vertices between straight edges that continue in the same direction
+func mergeCollinear(r ring) ring {
+	n := len(r.points)
+	if n < 4 {
+		return r
+	}
+	var merged ring
+	for i := 0; i < n; i++ {
+		prev, next := r.points[(i+n-1)%n], r.points[(i+1)%n]
+		prevCurved := r.curved[(i+n-1)%n]
+		if !prevCurved && !r.curved[i] {
+			a, b := sub(r.points[i], prev), sub(next, r.points[i])
+			if math.Abs(cross(a, b)) <= 1e-9*length(a)*length(b) && dot(a, b) > 0 {
+				continue
+			}
+		}
+		merged.points = append(merged.points, r.points[i])
+		merged.curved = append(merged.curved, r.curved[i])
+	}
+	return merged
+}
+
+// refit converts a ring back into a Bezier path, fitting curves through curved runs
+func refit(r ring, tolerance float64) Path {
+	n := len(r.points)
+	allCurved := true
+	for _, c := range r.curved {
+		allCurved = allCurved && c
+	}
+	if allCurved {
+		return curvefit.Fit(r.points, curvefit.Options{Tolerance: tolerance, Closed: true})
+	}
+
+	// Start at a vertex entered by a straight edge so curved runs never wrap around
+	start := 0
+	for i := 0; i < n; i++ {
+		if !r.curved[(i+n-1)%n] {
+			start = i
+			break
+		}
+	}
+
+	path := Path{Closed: true}
+	var pendingIn Point
+	i := 0
+	for i < n {
+		index := (start + i) % n
+		path.Vertices = append(path.Vertices, r.points[index])
+		path.InTangents = append(path.InTangents, pendingIn)
+		path.OutTangents = append(path.OutTangents, Point{})
+		pendingIn = Point{}
+
+		if !r.curved[index] {
+			i++
+			continue
+		}
+
+		// Collect the curved run and fit it
+		run := []Point{r.points[index]}
+		j := i
+		for j < n && r.curved[(start+j)%n] {
+			j++
+			run = append(run, r.points[(start+j)%n])
+		}
+		fitted := curvefit.Fit(run, curvefit.Options{Tolerance: tolerance})
+		last := len(fitted.Vertices) - 1
+		path.OutTangents[len(path.OutTangents)-1] = fitted.OutTangents[0]
+		for k := 1; k < last; k++ {
+			path.Vertices = append(path.Vertices, fitted.Vertices[k])
+			path.InTangents = append(path.InTangents, fitted.InTangents[k])
+			path.OutTangents = append(path.OutTangents, fitted.OutTangents[k])
+		}
+		pendingIn = fitted.InTangents[last]
+		i = j
+	}
+	path.InTangents[0] = add(path.InTangents[0], pendingIn)
+	return path
+}
+
+// strokeBand covers the area within d of every ring edge with positively oriented pieces:
+// a rectangle per edge plus a join piece per vertex
+func strokeBand(rings []ring, d float64, join string, miterLimit float64, tolerance float64) []ring {
+	var band []ring
+	addPiece := func(points []Point, curved bool) {
+		piece := ring{points: points, curved: make([]bool, len(points))}
+		for i := range piece.curved {
+			piece.curved[i] = curved
+		}
+		if signedArea(points) < 0 {
+			for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+				piece.points[i], piece.points[j] = piece.points[j], piece.points[i]
+			}
+		}
+		band = append(band, piece)
+	}
+
+	for _, r := range rings {
+		n := len(r.points)
+		for i := 0; i < n; i++ {
+			a, b := r.points[i], r.points[(i+1)%n]
+			dir := sub(b, a)
+			l := length(dir)
+			if l == 0 {
+				continue
+			}
+			normal := Point{-dir[1] / l * d, dir[0] / l * d}
+			piece := ring{
+				points: []Point{add(a, normal), add(b, normal), sub(b, normal), sub(a, normal)},
+				curved: []bool{r.curved[i], false, r.curved[i], false},
+			}
+			if signedArea(piece.points) < 0 {
+				piece.points[1], piece.points[3] = piece.points[3], piece.points[1]
+				piece.curved = []bool{false, r.curved[i], false, r.curved[i]}
+			}
+			band = append(band, piece)
+		}
+
+		// Fill the wedge left on the outer side of each turn
+		for i := 0; i < n; i++ {
+			prev, p, next := r.points[(i+n-1)%n], r.points[i], r.points[(i+1)%n]
+			u, v := sub(p, prev), sub(next, p)
+			lu, lv := length(u), length(v)
+			if lu == 0 || lv == 0 {
+				continue
+			}
+			turn := math.Atan2(cross(u, v), dot(u, v))
+			if math.Abs(turn) < 1e-9 {
+				continue
+			}
+			// The gap opens on the side away from the turn
+			side := -1.0
+			if turn < 0 {
+				side = 1
+			}
+			n1 := Point{-u[1] / lu * d * side, u[0] / lu * d * side}
+			n2 := Point{-v[1] / lv * d * side, v[0] / lv * d * side}
+			smooth := r.curved[(i+n-1)%n] && r.curved[i] && math.Abs(turn) < math.Pi/18
+
+			switch {
+			case join == "round" && !smooth:
+				addPiece(circle(p, d, tolerance), true)
+			case join == "bevel":
+				addPiece([]Point{p, add(p, n1), add(p, n2)}, false)
+			default:
+				// Miter point where the two offset edges meet
+				bisector := add(n1, n2)
+				bl := length(bisector)
+				miter := d / math.Cos(math.Abs(turn)/2)
+				if join == "round" || miter/d <= miterLimit {
+					addPiece([]Point{p, add(p, n1), add(p, scale(bisector, miter/bl)), add(p, n2)}, smooth)
+				} else {
+					addPiece([]Point{p, add(p, n1), add(p, n2)}, false)
+				}
+			}
+		}
+	}
+	return band
+}
+
+// circle returns a polygon approximating a circle within tolerance
+func circle(c Point, r float64, tolerance float64) []Point {
+	steps := 8
+	if tolerance < r {
+		steps = int(math.Ceil(math.Pi / math.Acos(1-tolerance/r)))
+	}
+	if steps < 8 {
+		steps = 8
+	}
+	if steps > 128 {
+		steps = 128
+	}
+	points := make([]Point, steps)
+	for i := range points {
+		a := 2 * math.Pi * float64(i) / float64(steps)
+		points[i] = Point{c[0] + r*math.Cos(a), c[1] + r*math.Sin(a)}
+	}
+	return points
+}
+
+// signedArea is positive for rings that turn toward positive angles
+func signedArea(points []Point) float64 {
+	area := 0.0
+	for i := range points {
+		area += cross(points[i], points[(i+1)%len(points)])
+	}
+	return area / 2
+}
+
+func cubicPoint(p0, c1, c2, p1 Point, t float64) Point {
+	mt := 1 - t
+	a, b, c, d := mt*mt*mt, 3*mt*mt*t, 3*mt*t*t, t*t*t
+	return Point{a*p0[0] + b*c1[0] + c*c2[0] + d*p1[0], a*p0[1] + b*c1[1] + c*c2[1] + d*p1[1]}
+}
+
+func add(a Point, b Point) Point     { return Point{a[0] + b[0], a[1] + b[1]} }
+func sub(a Point, b Point) Point     { return Point{a[0] - b[0], a[1] - b[1]} }
+func scale(a Point, s float64) Point { return Point{a[0] * s, a[1] * s} }
+func dot(a Point, b Point) float64   { return a[0]*b[0] + a[1]*b[1] }
+func cross(a Point, b Point) float64 { return a[0]*b[1] - a[1]*b[0] }
+func length(a Point) float64         { return math.Hypot(a[0], a[1]) }
@@ -0,0 +1,122 @@
+package svg
+
+import (
+	"strconv"
+	"strings"
+)
+
+// namedColors maps the common CSS color keywords to 0-255 RGB
+var namedColors = map[string][3]uint8{
+	"black":     {0, 0, 0},
+	"white":     {255, 255, 255},
+	"red":       {255, 0, 0},
+	"lime":      {0, 255, 0},
+	"green":     {0, 128, 0},
+	"blue":      {0, 0, 255},
+	"yellow":    {255, 255, 0},
+	"cyan":      {0, 255, 255},
+	"aqua":      {0, 255, 255},
+	"magenta":   {255, 0, 255},
+	"fuchsia":   {255, 0, 255},
+	"silver":    {192, 192, 192},
+	"gray":      {128, 128, 128},
+	"grey":      {128, 128, 128},
+	"maroon":    {128, 0, 0},
+	"olive":     {128, 128, 0},
+	"purple":    {128, 0, 128},
+	"teal":      {0, 128, 128},
+	"navy":      {0, 0, 128},
+	"orange":    {255, 165, 0},
+	"pink":      {255, 192, 203},
+	"brown":     {165, 42, 42},
+	"gold":      {255, 215, 0},
+	"indigo":    {75, 0, 130},
+	"violet":    {238, 130, 238},
+	"coral":     {255, 127, 80},
+	"salmon":    {250, 128, 114},
+	"tomato":    {255, 99, 71},
+	"crimson":   {220, 20, 60},
+	"khaki":     {240, 230, 140},
+	"beige":     {245, 245, 220},
+	"ivory":     {255, 255, 240},
+	"lavender":  {230, 230, 250},
+	"turquoise": {64, 224, 208},
+	"skyblue":   {135, 206, 235},
+	"steelblue": {70, 130, 180},
+	"darkgray":  {169, 169, 169},
+	"darkgrey":  {169, 169, 169},
+	"lightgray": {211, 211, 211},
+	"lightgrey": {211, 211, 211},
+	"dimgray":   {105, 105, 105},
+	"dimgrey":   {105, 105, 105},
+}
+
+// parseColor parses #rgb, #rrggbb, rgb(), rgba() (alpha ignored) and named colors
+// into 0-1 components
+func parseColor(s string) ([3]float64, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if rgb, ok := namedColors[s]; ok {
+		return [3]float64{float64(rgb[0]) / 255, float64(rgb[1]) / 255, float64(rgb[2]) / 255}, true
+	}
+
+	if strings.HasPrefix(s, "#") {
+		hex := s[1:]
+		if len(hex) == 3 || len(hex) == 4 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) == 8 {
+			hex = hex[:6]
+		}
+		if len(hex) != 6 {
+			return [3]float64{}, false
+		}
+		var c [3]float64
+		for i := 0; i < 3; i++ {
+			v, err := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+			if err != nil {
+				return [3]float64{}, false
+			}
+			c[i] = float64(v) / 255
+		}
+		return c, true
+	}
+
+	if strings.HasPrefix(s, "rgb") {
+		open, closing := strings.IndexByte(s, '('), strings.IndexByte(s, ')')
+		if open < 0 || closing < open {
+			return [3]float64{}, false
+		}
+		parts := strings.FieldsFunc(s[open+1:closing], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '/'
+		})
+		if len(parts) < 3 {
+			return [3]float64{}, false
+		}
+		var c [3]float64
+		for i := 0; i < 3; i++ {
+			part := parts[i]
+			if strings.HasSuffix(part, "%") {
+				v, err := strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64)
+				if err != nil {
+					return [3]float64{}, false
+				}
+				c[i] = v / 100
+			} else {
+				v, err := strconv.ParseFloat(part, 64)
+				if err != nil {
+					return [3]float64{}, false
+				}
+				c[i] = v / 255
+			}
+			if c[i] < 0 {
+				c[i] = 0
+			} else if c[i] > 1 {
+				c[i] = 1
+			}
+		}
+		return c, true
+	}
+
+	return [3]float64{}, false
+}
@@ -0,0 +1,479 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// kappa is the handle length, relative to the radius, of a cubic quarter circle
+const kappa = 0.5522847498
+
+// absSubpath is a contour with absolute control points, before conversion to Subpath
+type absSubpath struct {
+	vertices []Point
+	in       []Point // Incoming control point of each vertex
+	out      []Point // Outgoing control point of each vertex
+	closed   bool
+}
+
+// transform applies an affine matrix to every point of the contour
+func (sp absSubpath) transform(m matrix) absSubpath {
+	result := absSubpath{closed: sp.closed}
+	for i := range sp.vertices {
+		result.vertices = append(result.vertices, m.apply(sp.vertices[i]))
+		result.in = append(result.in, m.apply(sp.in[i]))
+		result.out = append(result.out, m.apply(sp.out[i]))
+	}
+	return result
+}
+
+// relative converts control points to tangents relative to their vertex
+func (sp absSubpath) relative() Subpath {
+	result := Subpath{Closed: sp.closed}
+	for i, v := range sp.vertices {
+		result.Vertices = append(result.Vertices, v)
+		result.InTangents = append(result.InTangents, Point{sp.in[i][0] - v[0], sp.in[i][1] - v[1]})
+		result.OutTangents = append(result.OutTangents, Point{sp.out[i][0] - v[0], sp.out[i][1] - v[1]})
+	}
+	return result
+}
+
+// pathBuilder accumulates contours from drawing commands
+type pathBuilder struct {
+	subpaths []absSubpath
+	current  *absSubpath
+	point    Point // Current point
+}
+
+// moveTo starts a new contour
+func (b *pathBuilder) moveTo(p Point) {
+	b.flush()
+	b.current = &absSubpath{
+		vertices: []Point{p},
+		in:       []Point{p},
+		out:      []Point{p},
+	}
+	b.point = p
+}
+
+// lineTo adds a straight segment
+func (b *pathBuilder) lineTo(p Point) {
+	b.ensureStarted()
+	b.current.vertices = append(b.current.vertices, p)
+	b.current.in = append(b.current.in, p)
+	b.current.out = append(b.current.out, p)
+	b.point = p
+}
+
+// cubicTo adds a cubic Bezier segment
+func (b *pathBuilder) cubicTo(c1 Point, c2 Point, p Point) {
+	b.ensureStarted()
+	b.current.out[len(b.current.out)-1] = c1
+	b.current.vertices = append(b.current.vertices, p)
+	b.current.in = append(b.current.in, c2)
+	b.current.out = append(b.current.out, p)
+	b.point = p
+}
+
+// close closes the current contour. A final vertex that duplicates the first is merged
+// into it so the closing segment keeps its curve.
+func (b *pathBuilder) close() {
+	if b.current == nil {
+		return
+	}
+	sp := b.current
+	b.point = sp.vertices[0]
+	last := len(sp.vertices) - 1
+	if last > 0 && nearlyEqual(sp.vertices[last], sp.vertices[0]) {
+		sp.in[0] = sp.in[last]
+		sp.vertices = sp.vertices[:last]
+		sp.in = sp.in[:last]
+		sp.out = sp.out[:last]
+	}
+	sp.closed = true
+	b.flush()
+}
+
+// ellipse adds a closed ellipse made of four cubic segments
+func (b *pathBuilder) ellipse(cx, cy, rx, ry float64) {
+	kx, ky := rx*kappa, ry*kappa
+	b.moveTo(Point{cx + rx, cy})
+	b.cubicTo(Point{cx + rx, cy + ky}, Point{cx + kx, cy + ry}, Point{cx, cy + ry})
+	b.cubicTo(Point{cx - kx, cy + ry}, Point{cx - rx, cy + ky}, Point{cx - rx, cy})
+	b.cubicTo(Point{cx - rx, cy - ky}, Point{cx - kx, cy - ry}, Point{cx, cy - ry})
+	b.cubicTo(Point{cx + kx, cy - ry}, Point{cx + rx, cy - ky}, Point{cx + rx, cy})
+	b.close()
+}
+
+// currentPoint returns the pen position
+func (b *pathBuilder) currentPoint() Point {
+	return b.point
+}
+
+// ensureStarted begins a contour at the current point when drawing follows a close
+func (b *pathBuilder) ensureStarted() {
+	if b.current == nil {
+		b.moveTo(b.currentPoint())
+	}
+}
+
+// flush stores the current contour if it has any segments
+func (b *pathBuilder) flush() {
+	if b.current != nil && len(b.current.vertices) > 1 {
+		b.subpaths = append(b.subpaths, *b.current)
+	}
+	b.current = nil
+}
+
+// finish returns all contours
+func (b *pathBuilder) finish() []absSubpath {
+	b.flush()
+	return b.subpaths
+}
+
+// nearlyEqual reports whether two points coincide
+func nearlyEqual(a, b Point) bool {
+	return math.Abs(a[0]-b[0]) < 1e-6 && math.Abs(a[1]-b[1]) < 1e-6
+}
+
+// scanner tokenizes path data and number lists
+type scanner struct {
+	s   string
+	pos int
+}
+
+func (sc *scanner) done() bool {
+	return sc.pos >= len(sc.s)
+}
+
+// skipSeparators skips whitespace and at most one comma
+func (sc *scanner) skipSeparators() {
+	comma := false
+	for !sc.done() {
+		c := sc.s[sc.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' {
+			sc.pos++
+		} else if c == ',' && !comma {
+			comma = true
+			sc.pos++
+		} else {
+			break
+		}
+	}
+}
+
+// number reads a number. "1.5.5" is two numbers and "1-2" is two numbers, as in SVG.
+func (sc *scanner) number() (float64, error) {
+	sc.skipSeparators()
+	start := sc.pos
+	if !sc.done() && (sc.s[sc.pos] == '-' || sc.s[sc.pos] == '+') {
+		sc.pos++
+	}
+	digits := false
+	for !sc.done() && sc.s[sc.pos] >= '0' && sc.s[sc.pos] <= '9' {
+		sc.pos++
+		digits = true
+	}
+	if !sc.done() && sc.s[sc.pos] == '.' {
+		sc.pos++
+		for !sc.done() && sc.s[sc.pos] >= '0' && sc.s[sc.pos] <= '9' {
+			sc.pos++
+			digits = true
+		}
+	}
+	if !digits {
+		return 0, fmt.Errorf("expected number at offset %d", start)
+	}
+	if !sc.done() && (sc.s[sc.pos] == 'e' || sc.s[sc.pos] == 'E') {
+		mark := sc.pos
+		sc.pos++
+		if !sc.done() && (sc.s[sc.pos] == '-' || sc.s[sc.pos] == '+') {
+			sc.pos++
+		}
+		expDigits := false
+		for !sc.done() && sc.s[sc.pos] >= '0' && sc.s[sc.pos] <= '9' {
+			sc.pos++
+			expDigits = true
+		}
+		if !expDigits {
+			sc.pos = mark
+		}
+	}
+	return strconv.ParseFloat(sc.s[start:sc.pos], 64)
+}
+
+// flag reads an arc flag, which may be written without a separator
+func (sc *scanner) flag() (bool, error) {
+	sc.skipSeparators()
+	if sc.done() || (sc.s[sc.pos] != '0' && sc.s[sc.pos] != '1') {
+		return false, fmt.Errorf("expected arc flag at offset %d", sc.pos)
+	}
+	v := sc.s[sc.pos] == '1'
+	sc.pos++
+	return v, nil
+}
+
+// point reads an x, y pair
+func (sc *scanner) point() (Point, error) {
+	x, err := sc.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := sc.number()
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{x, y}, nil
+}
+
+// parsePathData parses SVG path data into the builder
+func parsePathData(b *pathBuilder, d string) error {
+	sc := &scanner{s: d}
+	var cmd byte
+	var lastControl Point // Last cubic or quadratic control point, for S and T
+	var lastCmd byte
+
+	for {
+		sc.skipSeparators()
+		if sc.done() {
+			break
+		}
+
+		c := sc.s[sc.pos]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			cmd = c
+			sc.pos++
+		} else if cmd == 0 {
+			return fmt.Errorf("path data must start with a command")
+		} else if cmd == 'Z' || cmd == 'z' {
+			return fmt.Errorf("unexpected number after close command at offset %d", sc.pos)
+		}
+		// Otherwise the previous command repeats with new arguments
+
+		relative := cmd >= 'a' && cmd <= 'z'
+		cur := b.currentPoint()
+		offset := func(p Point) Point {
+			if relative {
+				return Point{p[0] + cur[0], p[1] + cur[1]}
+			}
+			return p
+		}
+
+		upper := cmd &^ 0x20
+		switch upper {
+		case 'M':
+			p, err := sc.point()
+			if err != nil {
+				return err
+			}
+			p = offset(p)
+			b.moveTo(p)
+			// Further pairs are implicit line commands
+			if relative {
+				cmd = 'l'
+			} else {
+				cmd = 'L'
+			}
+
+		case 'L':
+			p, err := sc.point()
+			if err != nil {
+				return err
+			}
+			b.lineTo(offset(p))
+
+		case 'H':
+			x, err := sc.number()
+			if err != nil {
+				return err
+			}
+			if relative {
+				x += cur[0]
+			}
+			b.lineTo(Point{x, cur[1]})
+
+		case 'V':
+			y, err := sc.number()
+			if err != nil {
+				return err
+			}
+			if relative {
+				y += cur[1]
+			}
+			b.lineTo(Point{cur[0], y})
+
+		case 'C', 'S':
+			var c1 Point
+			if upper == 'C' {
+				p, err := sc.point()
+				if err != nil {
+					return err
+				}
+				c1 = offset(p)
+			} else if lastCmd == 'C' || lastCmd == 'S' {
+				c1 = Point{2*cur[0] - lastControl[0], 2*cur[1] - lastControl[1]}
+			} else {
+				c1 = cur
+			}
+			c2, err := sc.point()
+			if err != nil {
+				return err
+			}
+			p, err := sc.point()
+			if err != nil {
+				return err
+			}
+			c2, p = offset(c2), offset(p)
+			b.cubicTo(c1, c2, p)
+			lastControl = c2
+
+		case 'Q', 'T':
+			var q Point
+			if upper == 'Q' {
+				p, err := sc.point()
+				if err != nil {
+					return err
+				}
+				q = offset(p)
+			} else if lastCmd == 'Q' || lastCmd == 'T' {
+				q = Point{2*cur[0] - lastControl[0], 2*cur[1] - lastControl[1]}
+			} else {
+				q = cur
+			}
+			p, err := sc.point()
+			if err != nil {
+				return err
+			}
+			p = offset(p)
+			// Elevate the quadratic to a cubic
+			c1 := Point{cur[0] + 2.0/3.0*(q[0]-cur[0]), cur[1] + 2.0/3.0*(q[1]-cur[1])}
+			c2 := Point{p[0] + 2.0/3.0*(q[0]-p[0]), p[1] + 2.0/3.0*(q[1]-p[1])}
+			b.cubicTo(c1, c2, p)
+			lastControl = q
+
+		case 'A':
+			rx, err := sc.number()
+			if err != nil {
+				return err
+			}
+			ry, err := sc.number()
+			if err != nil {
+				return err
+			}
+			rotation, err := sc.number()
+			if err != nil {
+				return err
+			}
+			largeArc, err := sc.flag()
+			if err != nil {
+				return err
+			}
+			sweep, err := sc.flag()
+			if err != nil {
+				return err
+			}
+			p, err := sc.point()
+			if err != nil {
+				return err
+			}
+			arcTo(b, cur, rx, ry, rotation, largeArc, sweep, offset(p))
+
+		case 'Z':
+			b.close()
+
+		default:
+			return fmt.Errorf("unknown path command %q", string(cmd))
+		}
+
+		lastCmd = upper
+	}
+
+	return nil
+}
+
+// arcTo converts an SVG elliptical arc to cubic segments of at most 90 degrees
+// (SVG implementation notes, F.6.5 endpoint to center parameterization)
+func arcTo(b *pathBuilder, from Point, rx, ry, rotationDeg float64, largeArc, sweep bool, to Point) {
+	if nearlyEqual(from, to) {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		b.lineTo(to)
+		return
+	}
+
+	phi := rotationDeg * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+
+	dx, dy := (from[0]-to[0])/2, (from[1]-to[1])/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	// Scale radii up when they can't span the endpoints
+	lambda := (x1*x1)/(rx*rx) + (y1*y1)/(ry*ry)
+	if lambda > 1 {
+		s := math.Sqrt(lambda)
+		rx *= s
+		ry *= s
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := 0.0
+	if den != 0 && num > 0 {
+		coef = math.Sqrt(num / den)
+	}
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+
+	cx := cosPhi*cx1 - sinPhi*cy1 + (from[0]+to[0])/2
+	cy := sinPhi*cx1 + cosPhi*cy1 + (from[1]+to[1])/2
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta1 := angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	segments := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(segments)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+
+	ellipsePoint := func(t float64) (Point, Point) {
+		cosT, sinT := math.Cos(t), math.Sin(t)
+		p := Point{
+			cx + rx*cosT*cosPhi - ry*sinT*sinPhi,
+			cy + rx*cosT*sinPhi + ry*sinT*cosPhi,
+		}
+		// Derivative with respect to t
+		d := Point{
+			-rx*sinT*cosPhi - ry*cosT*sinPhi,
+			-rx*sinT*sinPhi + ry*cosT*cosPhi,
+		}
+		return p, d
+	}
+
+	t := theta1
+	p0, d0 := ellipsePoint(t)
+	for i := 0; i < segments; i++ {
+		t += step
+		p1, d1 := ellipsePoint(t)
+		if i == segments-1 {
+			p1 = to
+		}
+		c1 := Point{p0[0] + k*d0[0], p0[1] + k*d0[1]}
+		c2 := Point{p1[0] - k*d1[0], p1[1] - k*d1[1]}
+		b.cubicTo(c1, c2, p1)
+		p0, d0 = p1, d1
+	}
+}
@@ -0,0 +1,522 @@
+// Package svg converts SVG drawings into Bezier paths that After Effects shape layers can draw.
+//
+// Supported elements are <path>, <rect>, <circle>, <ellipse>, <line>, <polyline>, <polygon>
+// and nested <g> groups with transforms. Fill and stroke are read from presentation
+// attributes and the style attribute, with inheritance through groups.
+package svg
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Point is an x, y coordinate
+type Point [2]float64
+
+// Subpath is a single contour. Vertices are absolute; tangents are relative to their vertex,
+// matching After Effects' Shape object.
+type Subpath struct {
+	Vertices    []Point
+	InTangents  []Point
+	OutTangents []Point
+	Closed      bool
+}
+
+// Paint is a solid color with opacity, both in the range 0-1
+type Paint struct {
+	Color   [3]float64
+	Opacity float64
+}
+
+// Shape is one drawable element with its resolved fill and stroke
+type Shape struct {
+	ID          string
+	Element     string
+	Subpaths    []Subpath
+	Fill        *Paint // nil when the element isn't filled
+	Stroke      *Paint // nil when the element isn't stroked
+	StrokeWidth float64
+	FillRule    string // "nonzero" or "evenodd"
+	LineCap     string // "butt", "round" or "square"
+	LineJoin    string // "miter", "round" or "bevel"
+}
+
+// Document is a parsed SVG drawing in user units after the viewBox transform
+type Document struct {
+	Width    float64
+	Height   float64
+	Shapes   []Shape
+	Warnings []string
+}
+
+// node is a generic XML element
+type node struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []node     `xml:",any"`
+}
+
+// attr returns the value of an attribute by local name
+func (n *node) attr(name string) (string, bool) {
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return strings.TrimSpace(a.Value), true
+		}
+	}
+	return "", false
+}
+
+// style holds the inheritable presentation properties
+type style struct {
+	fill          string
+	fillOpacity   float64
+	stroke        string
+	strokeOpacity float64
+	strokeWidth   float64
+	opacity       float64
+	fillRule      string
+	lineCap       string
+	lineJoin      string
+	display       string
+	visibility    string
+}
+
+// defaultStyle is the SVG initial value of each property
+var defaultStyle = style{
+	fill:          "black",
+	fillOpacity:   1,
+	stroke:        "none",
+	strokeOpacity: 1,
+	strokeWidth:   1,
+	opacity:       1,
+	fillRule:      "nonzero",
+	lineCap:       "butt",
+	lineJoin:      "miter",
+	display:       "inline",
+	visibility:    "visible",
+}
+
+// parser carries state while walking the element tree
+type parser struct {
+	doc       *Document
+	gradients map[string]Paint // Gradient paint servers approximated by their first stop
+}
+
+// ParseFile reads and parses an SVG file
+func ParseFile(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SVG file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses SVG markup
+func Parse(data []byte) (*Document, error) {
+	var root node
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid SVG: %w", err)
+	}
+	if root.XMLName.Local != "svg" {
+		return nil, fmt.Errorf("invalid SVG: root element is <%s>, not <svg>", root.XMLName.Local)
+	}
+
+	p := &parser{doc: &Document{}, gradients: map[string]Paint{}}
+	p.collectGradients(&root)
+
+	// Map the viewBox onto the viewport
+	m := identity()
+	width, hasWidth := parseLengthAttr(&root, "width")
+	height, hasHeight := parseLengthAttr(&root, "height")
+	if vb, ok := root.attr("viewBox"); ok {
+		nums, err := parseNumberList(vb)
+		if err != nil || len(nums) != 4 || nums[2] <= 0 || nums[3] <= 0 {
+			return nil, fmt.Errorf("invalid SVG viewBox: %q", vb)
+		}
+		if !hasWidth {
+			width = nums[2]
+		}
+		if !hasHeight {
+			height = nums[3]
+		}
+		sx, sy := width/nums[2], height/nums[3]
+		if preserve, _ := root.attr("preserveAspectRatio"); !strings.HasPrefix(preserve, "none") {
+			// xMidYMid meet
+			s := math.Min(sx, sy)
+			m = translate((width-nums[2]*s)/2, (height-nums[3]*s)/2).multiply(scale(s, s))
+		} else {
+			m = scale(sx, sy)
+		}
+		m = m.multiply(translate(-nums[0], -nums[1]))
+	}
+	p.doc.Width = width
+	p.doc.Height = height
+
+	p.walk(&root, m, p.applyStyle(&root, defaultStyle))
+
+	if len(p.doc.Shapes) == 0 {
+		p.doc.Warnings = append(p.doc.Warnings, "SVG contains no drawable shapes")
+	}
+	return p.doc, nil
+}
+
+// collectGradients records gradient paint servers anywhere in the tree
+func (p *parser) collectGradients(n *node) {
+	switch n.XMLName.Local {
+	case "linearGradient", "radialGradient":
+		if id, ok := n.attr("id"); ok {
+			for i := range n.Children {
+				stop := &n.Children[i]
+				if stop.XMLName.Local != "stop" {
+					continue
+				}
+				props := map[string]string{}
+				for _, a := range stop.Attrs {
+					props[a.Name.Local] = a.Value
+				}
+				if styleAttr, ok := stop.attr("style"); ok {
+					for k, v := range parseStyleAttr(styleAttr) {
+						props[k] = v
+					}
+				}
+				color, ok := parseColor(props["stop-color"])
+				if !ok {
+					color = [3]float64{0, 0, 0}
+				}
+				opacity := 1.0
+				if v, err := strconv.ParseFloat(strings.TrimSpace(props["stop-opacity"]), 64); err == nil {
+					opacity = v
+				}
+				p.gradients[id] = Paint{Color: color, Opacity: opacity}
+				break
+			}
+		}
+	}
+	for i := range n.Children {
+		p.collectGradients(&n.Children[i])
+	}
+}
+
+// walk converts an element and its children
+func (p *parser) walk(n *node, m matrix, st style) {
+	for i := range n.Children {
+		child := &n.Children[i]
+		name := child.XMLName.Local
+
+		switch name {
+		case "defs", "clipPath", "mask", "symbol", "marker", "pattern", "linearGradient", "radialGradient",
+			"title", "desc", "metadata", "style", "script":
+			continue
+		case "use", "image", "text", "foreignObject":
+			p.doc.Warnings = append(p.doc.Warnings, fmt.Sprintf("<%s> elements are not supported and were skipped", name))
+			continue
+		}
+
+		childStyle := p.applyStyle(child, st)
+		if childStyle.display == "none" {
+			continue
+		}
+
+		childMatrix := m
+		if t, ok := child.attr("transform"); ok {
+			local, err := parseTransform(t)
+			if err != nil {
+				p.doc.Warnings = append(p.doc.Warnings, err.Error())
+			} else {
+				childMatrix = m.multiply(local)
+			}
+		}
+
+		switch name {
+		case "g", "svg", "a", "switch":
+			p.walk(child, childMatrix, childStyle)
+		default:
+			subpaths, err := elementSubpaths(child)
+			if err != nil {
+				p.doc.Warnings = append(p.doc.Warnings, fmt.Sprintf("<%s>: %v", name, err))
+				continue
+			}
+			if subpaths == nil || childStyle.visibility == "hidden" {
+				continue
+			}
+			p.addShape(child, subpaths, childMatrix, childStyle)
+		}
+	}
+}
+
+// addShape transforms an element's contours and resolves its paint
+func (p *parser) addShape(n *node, subpaths []absSubpath, m matrix, st style) {
+	shape := Shape{
+		Element:  n.XMLName.Local,
+		FillRule: st.fillRule,
+		LineCap:  st.lineCap,
+		LineJoin: st.lineJoin,
+	}
+	shape.ID, _ = n.attr("id")
+
+	for _, sp := range subpaths {
+		shape.Subpaths = append(shape.Subpaths, sp.transform(m).relative())
+	}
+
+	// Lines and polylines are never filled in practice, even though SVG allows it
+	if shape.Element != "line" {
+		shape.Fill = p.resolvePaint(st.fill, st.fillOpacity*st.opacity)
+	}
+	shape.Stroke = p.resolvePaint(st.stroke, st.strokeOpacity*st.opacity)
+	shape.StrokeWidth = st.strokeWidth * m.scaleFactor()
+
+	if shape.Fill == nil && shape.Stroke == nil {
+		return
+	}
+	p.doc.Shapes = append(p.doc.Shapes, shape)
+}
+
+// resolvePaint converts a fill or stroke value into a paint, or nil for none
+func (p *parser) resolvePaint(value string, opacity float64) *Paint {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "none" || value == "transparent" {
+		return nil
+	}
+	if strings.HasPrefix(value, "url(") {
+		end := strings.IndexByte(value, ')')
+		if end > 0 {
+			id := strings.TrimPrefix(strings.Trim(value[4:end], `"' `), "#")
+			if g, ok := p.gradients[id]; ok {
+				p.doc.Warnings = append(p.doc.Warnings, fmt.Sprintf("Gradient %q approximated by its first stop color", id))
+				return &Paint{Color: g.Color, Opacity: g.Opacity * opacity}
+			}
+		}
+		// Use the fallback color after the reference, if any
+		fallback := strings.TrimSpace(value[end+1:])
+		p.doc.Warnings = append(p.doc.Warnings, fmt.Sprintf("Unsupported paint %q", value))
+		if fallback == "" {
+			return nil
+		}
+		value = fallback
+	}
+	color, ok := parseColor(value)
+	if !ok {
+		p.doc.Warnings = append(p.doc.Warnings, fmt.Sprintf("Unknown color %q, using black", value))
+		color = [3]float64{0, 0, 0}
+	}
+	return &Paint{Color: color, Opacity: opacity}
+}
+
+// applyStyle returns the style of an element given its parent's style
+func (p *parser) applyStyle(n *node, parent style) style {
+	st := parent
+	// opacity is not inherited; it multiplies down the tree instead
+	props := map[string]string{}
+	for _, a := range n.Attrs {
+		props[a.Name.Local] = a.Value
+	}
+	if styleAttr, ok := n.attr("style"); ok {
+		for k, v := range parseStyleAttr(styleAttr) {
+			props[k] = v
+		}
+	}
+
+	for key, value := range props {
+		value = strings.TrimSpace(value)
+		if value == "inherit" {
+			continue
+		}
+		switch key {
+		case "fill":
+			st.fill = value
+		case "stroke":
+			st.stroke = value
+		case "fill-opacity":
+			st.fillOpacity = parseOpacity(value, st.fillOpacity)
+		case "stroke-opacity":
+			st.strokeOpacity = parseOpacity(value, st.strokeOpacity)
+		case "opacity":
+			st.opacity = parent.opacity * parseOpacity(value, 1)
+		case "stroke-width":
+			if w, err := parseLength(value); err == nil {
+				st.strokeWidth = w
+			}
+		case "fill-rule":
+			st.fillRule = value
+		case "stroke-linecap":
+			st.lineCap = value
+		case "stroke-linejoin":
+			st.lineJoin = value
+		case "display":
+			st.display = value
+		case "visibility":
+			st.visibility = value
+		}
+	}
+
+	// currentColor resolves against the color property
+	if color, ok := props["color"]; ok {
+		if st.fill == "currentColor" {
+			st.fill = color
+		}
+		if st.stroke == "currentColor" {
+			st.stroke = color
+		}
+	}
+	return st
+}
+
+// parseStyleAttr splits a CSS declaration list
+func parseStyleAttr(s string) map[string]string {
+	props := map[string]string{}
+	for _, decl := range strings.Split(s, ";") {
+		if colon := strings.IndexByte(decl, ':'); colon > 0 {
+			props[strings.TrimSpace(decl[:colon])] = strings.TrimSpace(decl[colon+1:])
+		}
+	}
+	return props
+}
+
+// parseOpacity parses a number or percentage opacity, clamped to 0-1
+func parseOpacity(s string, fallback float64) float64 {
+	s = strings.TrimSpace(s)
+	percent := strings.HasSuffix(s, "%")
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		return fallback
+	}
+	if percent {
+		v /= 100
+	}
+	return math.Max(0, math.Min(1, v))
+}
+
+// parseLength parses a length, ignoring px units
+func parseLength(s string) (float64, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "px"))
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseLengthAttr reads a length attribute; percentages and other units are ignored
+func parseLengthAttr(n *node, name string) (float64, bool) {
+	value, ok := n.attr(name)
+	if !ok {
+		return 0, false
+	}
+	v, err := parseLength(value)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// numberAttr reads a numeric attribute, defaulting to 0
+func numberAttr(n *node, name string) float64 {
+	v, _ := parseLengthAttr(n, name)
+	return v
+}
+
+// elementSubpaths builds the contours of a basic shape or path element.
+// It returns nil for elements that don't draw anything.
+func elementSubpaths(n *node) ([]absSubpath, error) {
+	b := &pathBuilder{}
+
+	switch n.XMLName.Local {
+	case "path":
+		d, _ := n.attr("d")
+		if err := parsePathData(b, d); err != nil {
+			return nil, err
+		}
+
+	case "rect":
+		x, y := numberAttr(n, "x"), numberAttr(n, "y")
+		w, h := numberAttr(n, "width"), numberAttr(n, "height")
+		if w <= 0 || h <= 0 {
+			return nil, nil
+		}
+		rx, hasRx := parseLengthAttr(n, "rx")
+		ry, hasRy := parseLengthAttr(n, "ry")
+		if hasRx && !hasRy {
+			ry = rx
+		} else if hasRy && !hasRx {
+			rx = ry
+		}
+		rx = math.Min(math.Max(rx, 0), w/2)
+		ry = math.Min(math.Max(ry, 0), h/2)
+		if rx == 0 || ry == 0 {
+			b.moveTo(Point{x, y})
+			b.lineTo(Point{x + w, y})
+			b.lineTo(Point{x + w, y + h})
+			b.lineTo(Point{x, y + h})
+			b.close()
+		} else {
+			kx, ky := rx*kappa, ry*kappa
+			b.moveTo(Point{x + rx, y})
+			b.lineTo(Point{x + w - rx, y})
+			b.cubicTo(Point{x + w - rx + kx, y}, Point{x + w, y + ry - ky}, Point{x + w, y + ry})
+			b.lineTo(Point{x + w, y + h - ry})
+			b.cubicTo(Point{x + w, y + h - ry + ky}, Point{x + w - rx + kx, y + h}, Point{x + w - rx, y + h})
+			b.lineTo(Point{x + rx, y + h})
+			b.cubicTo(Point{x + rx - kx, y + h}, Point{x, y + h - ry + ky}, Point{x, y + h - ry})
+			b.lineTo(Point{x, y + ry})
+			b.cubicTo(Point{x, y + ry - ky}, Point{x + rx - kx, y}, Point{x + rx, y})
+			b.close()
+		}
+
+	case "circle":
+		r := numberAttr(n, "r")
+		if r <= 0 {
+			return nil, nil
+		}
+		b.ellipse(numberAttr(n, "cx"), numberAttr(n, "cy"), r, r)
+
+	case "ellipse":
+		rx, ry := numberAttr(n, "rx"), numberAttr(n, "ry")
+		if rx <= 0 || ry <= 0 {
+			return nil, nil
+		}
+		b.ellipse(numberAttr(n, "cx"), numberAttr(n, "cy"), rx, ry)
+
+	case "line":
+		b.moveTo(Point{numberAttr(n, "x1"), numberAttr(n, "y1")})
+		b.lineTo(Point{numberAttr(n, "x2"), numberAttr(n, "y2")})
+
+	case "polyline", "polygon":
+		points, _ := n.attr("points")
+		nums, err := parseNumberList(points)
+		if err != nil {
+			return nil, err
+		}
+		if len(nums) < 4 {
+			return nil, nil
+		}
+		b.moveTo(Point{nums[0], nums[1]})
+		for i := 2; i+1 < len(nums); i += 2 {
+			b.lineTo(Point{nums[i], nums[i+1]})
+		}
+		if n.XMLName.Local == "polygon" {
+			b.close()
+		}
+
+	default:
+		return nil, nil
+	}
+
+	return b.finish(), nil
+}
+
+// parseNumberList parses whitespace or comma separated numbers
+func parseNumberList(s string) ([]float64, error) {
+	sc := &scanner{s: s}
+	var nums []float64
+	for {
+		sc.skipSeparators()
+		if sc.done() {
+			return nums, nil
+		}
+		v, err := sc.number()
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, v)
+	}
+}
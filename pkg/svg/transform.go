@@ -0,0 +1,96 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// matrix is an affine transform [a b c d e f], mapping (x, y) to (ax + cy + e, bx + dy + f)
+type matrix [6]float64
+
+func identity() matrix {
+	return matrix{1, 0, 0, 1, 0, 0}
+}
+
+func translate(tx, ty float64) matrix {
+	return matrix{1, 0, 0, 1, tx, ty}
+}
+
+func scale(sx, sy float64) matrix {
+	return matrix{sx, 0, 0, sy, 0, 0}
+}
+
+func rotate(deg float64) matrix {
+	r := deg * math.Pi / 180
+	return matrix{math.Cos(r), math.Sin(r), -math.Sin(r), math.Cos(r), 0, 0}
+}
+
+// multiply returns m × n, which applies n first and then m
+func (m matrix) multiply(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+// apply transforms a point
+func (m matrix) apply(p Point) Point {
+	return Point{m[0]*p[0] + m[2]*p[1] + m[4], m[1]*p[0] + m[3]*p[1] + m[5]}
+}
+
+// scaleFactor is the average scale of the transform, used for stroke widths
+func (m matrix) scaleFactor() float64 {
+	return math.Sqrt(math.Abs(m[0]*m[3] - m[1]*m[2]))
+}
+
+// parseTransform parses a transform attribute such as "translate(10 20) rotate(45)"
+func parseTransform(s string) (matrix, error) {
+	result := identity()
+	rest := strings.TrimSpace(s)
+
+	for rest != "" {
+		open := strings.IndexByte(rest, '(')
+		closing := strings.IndexByte(rest, ')')
+		if open < 0 || closing < open {
+			return identity(), fmt.Errorf("invalid transform: %q", s)
+		}
+		name := strings.TrimSpace(rest[:open])
+		args, err := parseNumberList(rest[open+1 : closing])
+		if err != nil {
+			return identity(), fmt.Errorf("invalid transform: %q", s)
+		}
+		rest = strings.TrimLeft(rest[closing+1:], " \t\r\n,")
+
+		var m matrix
+		switch {
+		case name == "matrix" && len(args) == 6:
+			m = matrix{args[0], args[1], args[2], args[3], args[4], args[5]}
+		case name == "translate" && len(args) == 1:
+			m = translate(args[0], 0)
+		case name == "translate" && len(args) == 2:
+			m = translate(args[0], args[1])
+		case name == "scale" && len(args) == 1:
+			m = scale(args[0], args[0])
+		case name == "scale" && len(args) == 2:
+			m = scale(args[0], args[1])
+		case name == "rotate" && len(args) == 1:
+			m = rotate(args[0])
+		case name == "rotate" && len(args) == 3:
+			m = translate(args[1], args[2]).multiply(rotate(args[0])).multiply(translate(-args[1], -args[2]))
+		case name == "skewX" && len(args) == 1:
+			m = matrix{1, 0, math.Tan(args[0] * math.Pi / 180), 1, 0, 0}
+		case name == "skewY" && len(args) == 1:
+			m = matrix{1, math.Tan(args[0] * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return identity(), fmt.Errorf("unsupported transform %s with %d arguments", name, len(args))
+		}
+		result = result.multiply(m)
+	}
+
+	return result, nil
+}
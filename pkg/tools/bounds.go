@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+)
+
+// layerBoundsJS defines ExtendScript helpers that measure a layer's rendered bounds.
+// Composition-space bounds follow the 2D transform of the layer and its parents;
+// 3D rotation, depth and camera perspective are not taken into account.
+const layerBoundsJS = `
+		// Map a point from layer space to composition space through the parent chain
+		function layerToComp(layer, point, t) {
+			var p = [point[0], point[1]];
+			var current = layer;
+			while (current) {
+				var transform = current.property("ADBE Transform Group");
+				var anchor = transform.property("ADBE Anchor Point").valueAtTime(t, false);
+				var scale = transform.property("ADBE Scale").valueAtTime(t, false);
+				var rotation = transform.property("ADBE Rotate Z").valueAtTime(t, false) * Math.PI / 180;
+				var position = transform.property("ADBE Position").valueAtTime(t, false);
+				var x = (p[0] - anchor[0]) * scale[0] / 100;
+				var y = (p[1] - anchor[1]) * scale[1] / 100;
+				p = [
+					x * Math.cos(rotation) - y * Math.sin(rotation) + position[0],
+					x * Math.sin(rotation) + y * Math.cos(rotation) + position[1]
+				];
+				current = current.parent;
+			}
+			return p;
+		}
+
+		// Measure a layer's source rect and its bounding box in composition space
+		function layerBounds(layer, t, includeExtents) {
+			var rect = layer.sourceRectAtTime(t, includeExtents);
+			var corners = [
+				layerToComp(layer, [rect.left, rect.top], t),
+				layerToComp(layer, [rect.left + rect.width, rect.top], t),
+				layerToComp(layer, [rect.left + rect.width, rect.top + rect.height], t),
+				layerToComp(layer, [rect.left, rect.top + rect.height], t)
+			];
+			var left = corners[0][0], right = corners[0][0];
+			var top = corners[0][1], bottom = corners[0][1];
+			for (var c = 1; c < corners.length; c++) {
+				left = Math.min(left, corners[c][0]);
+				right = Math.max(right, corners[c][0]);
+				top = Math.min(top, corners[c][1]);
+				bottom = Math.max(bottom, corners[c][1]);
+			}
+
+			var approximate = false;
+			for (var current = layer; current; current = current.parent) {
+				if (current.threeDLayer) {
+					approximate = true;
+				}
+			}
+
+			return {
+				layerRect: { left: rect.left, top: rect.top, width: rect.width, height: rect.height },
+				compBounds: { left: left, top: top, right: right, bottom: bottom, width: right - left, height: bottom - top },
+				corners: corners,
+				approximate: approximate
+			};
+		}
+
+		// The title-safe area of a composition (the central 80%)
+		function titleSafeBox(comp) {
+			return {
+				left: comp.width * 0.1,
+				top: comp.height * 0.1,
+				right: comp.width * 0.9,
+				bottom: comp.height * 0.9,
+				width: comp.width * 0.8,
+				height: comp.height * 0.8
+			};
+		}
+
+		// Clamp a time into the layer's visible range so it has something to measure
+		function measureTimeForLayer(layer, t) {
+			var start = Math.min(layer.inPoint, layer.outPoint);
+			var end = Math.max(layer.inPoint, layer.outPoint);
+			return Math.max(start, Math.min(t, end - layer.containingComp.frameDuration));
+		}
+`
+
+// GetLayerBounds returns the sourceRectAtTime bounds of a layer at a time, in layer space and
+// as a bounding box in composition space. The current composition time is used when time is nil.
+func GetLayerBounds(compositionName string, layerIdentifier LayerIdentifier, time *float64, includeExtents bool) (LayerInfo, error) {
+	// Create layer identification JavaScript code
+	var layerIdentifierJS string
+	if layerIdentifier.Name != "" {
+		layerIdentifierJS = `
+		// Find layer by name
+		var targetLayer = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === "` + layerIdentifier.Name + `") {
+				targetLayer = comp.layer(i);
+				break;
+			}
+		}
+		`
+	} else if layerIdentifier.Index > 0 {
+		layerIdentifierJS = fmt.Sprintf(`
+		// Get layer by index
+		var targetLayer = comp.layer(%d);
+		`, layerIdentifier.Index)
+	} else {
+		return nil, fmt.Errorf("layer_identifier must have either name or index field: %w", ErrInvalidParams)
+	}
+
+	timeJS := "comp.time"
+	if time != nil {
+		timeJS = fmt.Sprintf("%f", *time)
+	}
+
+	script := `
+	try {
+		var compName = "` + compositionName + `";
+
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+
+		` + layerIdentifierJS + `
+
+		if (!targetLayer) {
+			return JSON.stringify({
+				error: "Layer not found"
+			});
+		}
+
+		if (typeof targetLayer.sourceRectAtTime !== "function") {
+			return JSON.stringify({
+				error: "Layer has no rendered bounds: " + targetLayer.name
+			});
+		}
+		` + layerBoundsJS + `
+		var t = ` + timeJS + `;
+		var bounds = layerBounds(targetLayer, t, ` + fmt.Sprintf("%t", includeExtents) + `);
+		var safe = titleSafeBox(comp);
+		var b = bounds.compBounds;
+
+		var result = {
+			name: targetLayer.name,
+			index: targetLayer.index,
+			time: t,
+			includeExtents: ` + fmt.Sprintf("%t", includeExtents) + `,
+			layerRect: bounds.layerRect,
+			compBounds: b,
+			corners: bounds.corners,
+			approximate: bounds.approximate,
+			compSize: [comp.width, comp.height],
+			titleSafe: safe,
+			insideComp: b.left >= 0 && b.top >= 0 && b.right <= comp.width && b.bottom <= comp.height,
+			insideTitleSafe: b.left >= safe.left && b.top >= safe.top && b.right <= safe.right && b.bottom <= safe.bottom
+		};
+
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var boundsInfo LayerInfo
+		if err := json.Unmarshal([]byte(resultStr), &boundsInfo); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := boundsInfo["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return boundsInfo, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
@@ -0,0 +1,601 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+)
+
+// CameraMove parameterizes a generated camera move. Zero values select each move's defaults.
+//
+// Intensity means, per move:
+//   - orbit: degrees around the vertical axis, default 90; negative reverses the direction
+//   - dolly: pixels along the view direction, default 400; negative pulls back
+//   - truck: pixels sideways, default 400; negative moves left
+//   - crane: pixels up, default 300; negative moves down
+//   - push_in: percent of the distance to the point of interest, default 30
+//   - shake: amplitude in pixels, default 10
+type CameraMove struct {
+	Duration    float64     `json:"duration"`              // Seconds
+	Intensity   float64     `json:"intensity,omitempty"`   // See above
+	StartTime   *float64    `json:"startTime,omitempty"`   // Default: the composition's current time
+	Rig         bool        `json:"rig,omitempty"`         // Animate a 3D null the camera is parented to instead of the camera
+	Linear      bool        `json:"linear,omitempty"`      // Dolly, truck, crane and orbit: no ease at the start and end
+	Center      *[3]float64 `json:"center,omitempty"`      // Orbit center, default the point of interest
+	TargetLayer string      `json:"targetLayer,omitempty"` // Orbit around this layer's anchor point in world space
+	Frequency   float64     `json:"frequency,omitempty"`   // Shake wobbles per second, default 1.5
+	Seed        int64       `json:"seed,omitempty"`        // Shake noise seed, for repeatable shakes
+}
+
+// cameraRigState is a camera's transform at the start of a move
+type cameraRigState struct {
+	Position        [3]float64  `json:"position"`
+	PointOfInterest *[3]float64 `json:"pointOfInterest"` // nil for one-node cameras
+	Target          *[3]float64 `json:"target"`          // World position of the orbit target layer's anchor point
+	StartTime       float64     `json:"startTime"`
+	FrameRate       float64     `json:"frameRate"`
+}
+
+// cameraPropertyKeys are the keyframes for one transform property. Relative keyframe values
+// are offsets added to the property's animated value at each keyframe time.
+type cameraPropertyKeys struct {
+	Keyframes []PropertyKeyframe `json:"keyframes"`
+	Relative  bool               `json:"relative,omitempty"`
+}
+
+// cameraMovePlan is what a camera move generator computes: keyframes for the camera, or for a
+// rig null centered at RigCenter
+type cameraMovePlan struct {
+	Camera    map[string]cameraPropertyKeys `json:"camera,omitempty"`
+	Rig       map[string]cameraPropertyKeys `json:"rig,omitempty"`
+	RigCenter [3]float64                    `json:"rigCenter"`
+}
+
+// cameraMoves maps the move names to their generator
+var cameraMoves = map[string]func(move CameraMove, state cameraRigState) (cameraMovePlan, error){
+	"orbit":   generateOrbit,
+	"dolly":   generateDolly,
+	"truck":   generateTruck,
+	"crane":   generateCrane,
+	"push_in": generatePushIn,
+	"shake":   generateShake,
+}
+
+// CameraMoveTypes returns the names of the camera moves, sorted
+func CameraMoveTypes() []string {
+	names := make([]string, 0, len(cameraMoves))
+	for name := range cameraMoves {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyCameraMove computes a camera move from the camera's transform at the start time and
+// writes it as keyframes on the camera, or, with move.Rig, as keyframes on a new 3D null that
+// the camera is parented to, leaving the camera's own animation untouched
+func ApplyCameraMove(compositionName string, cameraName string, moveType string, move CameraMove) (LayerInfo, error) {
+	moveType = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(moveType), "-", "_"))
+	generate, ok := cameraMoves[moveType]
+	if !ok {
+		return nil, fmt.Errorf("unknown camera move: %s. Must be one of: %s: %w", moveType, strings.Join(CameraMoveTypes(), ", "), ErrInvalidParams)
+	}
+	if move.Duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive: %w", ErrInvalidParams)
+	}
+
+	state, err := readCameraRigState(compositionName, cameraName, move)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := generate(move, state)
+	if err != nil {
+		return nil, err
+	}
+
+	rigName := ""
+	if move.Rig {
+		rigName = cameraName + " " + strings.ReplaceAll(moveType, "_", "-") + " rig"
+	}
+	return writeCameraMove(compositionName, cameraName, rigName, plan, moveType, state.StartTime, move.Duration)
+}
+
+// moveIntensity returns the move's intensity, or def when none was given
+func moveIntensity(move CameraMove, def float64) float64 {
+	if move.Intensity == 0 {
+		return def
+	}
+	return move.Intensity
+}
+
+// cameraForward is the unit view direction: towards the point of interest, or down +z for
+// one-node cameras
+func cameraForward(state cameraRigState) [3]float64 {
+	if state.PointOfInterest != nil {
+		if dir, ok := normalize3(sub3(*state.PointOfInterest, state.Position)); ok {
+			return dir
+		}
+	}
+	return [3]float64{0, 0, 1}
+}
+
+// cameraRight is the unit horizontal direction to the right of the view. y points down in
+// After Effects, so world up is -y.
+func cameraRight(state cameraRigState) [3]float64 {
+	forward := cameraForward(state)
+	if right, ok := normalize3(cross3(forward, [3]float64{0, -1, 0})); ok {
+		return right
+	}
+	return [3]float64{1, 0, 0}
+}
+
+// linearMove moves the camera, or the rig, by delta. withPOI also moves the point of
+// interest, keeping the view direction.
+func linearMove(move CameraMove, state cameraRigState, delta [3]float64, withPOI bool) cameraMovePlan {
+	end := state.StartTime + move.Duration
+	ease := !move.Linear
+	keys := func(from [3]float64) []PropertyKeyframe {
+		return []PropertyKeyframe{
+			{Time: state.StartTime, Value: from, Ease: ease},
+			{Time: end, Value: add3(from, delta), Ease: ease},
+		}
+	}
+
+	if move.Rig {
+		return cameraMovePlan{
+			Rig:       map[string]cameraPropertyKeys{"position": {Keyframes: keys(state.Position)}},
+			RigCenter: state.Position,
+		}
+	}
+	plan := cameraMovePlan{Camera: map[string]cameraPropertyKeys{"position": {Keyframes: keys(state.Position)}}}
+	if withPOI && state.PointOfInterest != nil {
+		plan.Camera["pointOfInterest"] = cameraPropertyKeys{Keyframes: keys(*state.PointOfInterest)}
+	}
+	return plan
+}
+
+// generateDolly moves the camera and its point of interest along the view direction
+func generateDolly(move CameraMove, state cameraRigState) (cameraMovePlan, error) {
+	delta := scale3(cameraForward(state), moveIntensity(move, 400))
+	return linearMove(move, state, delta, true), nil
+}
+
+// generateTruck moves the camera and its point of interest sideways
+func generateTruck(move CameraMove, state cameraRigState) (cameraMovePlan, error) {
+	delta := scale3(cameraRight(state), moveIntensity(move, 400))
+	return linearMove(move, state, delta, true), nil
+}
+
+// generateCrane moves the camera and its point of interest vertically
+func generateCrane(move CameraMove, state cameraRigState) (cameraMovePlan, error) {
+	delta := [3]float64{0, -moveIntensity(move, 300), 0}
+	return linearMove(move, state, delta, true), nil
+}
+
+// generatePushIn eases the camera towards its point of interest, which stays put. In a rig
+// the point of interest moves with the null, so the push-in becomes an eased dolly.
+func generatePushIn(move CameraMove, state cameraRigState) (cameraMovePlan, error) {
+	if state.PointOfInterest == nil {
+		return cameraMovePlan{}, fmt.Errorf("push-in needs a two-node camera with a point of interest: %w", ErrInvalidParams)
+	}
+	percent := moveIntensity(move, 30)
+	if percent >= 100 {
+		return cameraMovePlan{}, fmt.Errorf("push-in intensity must be below 100 percent of the distance: %w", ErrInvalidParams)
+	}
+	delta := scale3(sub3(*state.PointOfInterest, state.Position), percent/100)
+	move.Linear = false
+	return linearMove(move, state, delta, false), nil
+}
+
+// generateOrbit circles the camera around a center on the vertical axis. Keyframed orbits are
+// sampled every 15 degrees along an eased path; rigs rotate the null, which is exact.
+func generateOrbit(move CameraMove, state cameraRigState) (cameraMovePlan, error) {
+	var center [3]float64
+	switch {
+	case move.Center != nil:
+		center = *move.Center
+	case state.Target != nil:
+		center = *state.Target
+	case state.PointOfInterest != nil:
+		center = *state.PointOfInterest
+	default:
+		return cameraMovePlan{}, fmt.Errorf("orbiting a one-node camera needs a center or target layer: %w", ErrInvalidParams)
+	}
+	degrees := moveIntensity(move, 90)
+	end := state.StartTime + move.Duration
+
+	if move.Rig {
+		ease := !move.Linear
+		return cameraMovePlan{
+			Rig: map[string]cameraPropertyKeys{"yRotation": {Keyframes: []PropertyKeyframe{
+				{Time: state.StartTime, Value: 0, Ease: ease},
+				{Time: end, Value: degrees, Ease: ease},
+			}}},
+			RigCenter: center,
+		}, nil
+	}
+
+	samples := int(math.Ceil(math.Abs(degrees) / 15))
+	if samples < 4 {
+		samples = 4
+	}
+	var position, poi, rotation []PropertyKeyframe
+	orbitPOI := state.PointOfInterest != nil && *state.PointOfInterest != center
+	for i := 0; i <= samples; i++ {
+		progress := float64(i) / float64(samples)
+		if !move.Linear {
+			progress = smoothstep(progress)
+		}
+		angle := degrees * progress
+		t := state.StartTime + move.Duration*float64(i)/float64(samples)
+		position = append(position, PropertyKeyframe{Time: t, Value: add3(center, rotateY3(sub3(state.Position, center), angle))})
+		if orbitPOI {
+			poi = append(poi, PropertyKeyframe{Time: t, Value: add3(center, rotateY3(sub3(*state.PointOfInterest, center), angle))})
+		}
+		rotation = append(rotation, PropertyKeyframe{Time: t, Value: angle})
+	}
+
+	plan := cameraMovePlan{Camera: map[string]cameraPropertyKeys{"position": {Keyframes: position}}}
+	if orbitPOI {
+		plan.Camera["pointOfInterest"] = cameraPropertyKeys{Keyframes: poi}
+	}
+	if state.PointOfInterest == nil {
+		// One-node cameras don't aim, so turn them with the orbit
+		plan.Camera["yRotation"] = cameraPropertyKeys{Keyframes: rotation, Relative: true}
+	}
+	return plan, nil
+}
+
+// Shake baking limits. The noise changes direction up to twice per wobble, so 12 keys per
+// wobble follow it closely.
+const (
+	shakeKeysPerWobble = 12
+	maxShakeKeyframes  = 2000
+)
+
+// generateShake bakes handheld wobble into keyframes, at most one per frame: smooth noise on
+// position and a little roll, faded in and out over a quarter second. Keyframed shakes are
+// offsets added to the camera's existing animation.
+func generateShake(move CameraMove, state cameraRigState) (cameraMovePlan, error) {
+	amplitude := moveIntensity(move, 10)
+	frequency := move.Frequency
+	if frequency <= 0 {
+		frequency = 1.5
+	}
+	frameRate := state.FrameRate
+	if frameRate <= 0 {
+		frameRate = 30
+	}
+
+	noise := make([]func(float64) float64, 6)
+	for i := range noise {
+		noise[i] = bakedNoise(move.Seed*7 + int64(i))
+	}
+
+	// Bake only as densely as the noise needs, at most a key per frame
+	rate := math.Min(frameRate, shakeKeysPerWobble*frequency)
+	keys := int(math.Ceil(move.Duration * rate))
+	if keys > maxShakeKeyframes {
+		return cameraMovePlan{}, fmt.Errorf("shake needs %d keyframes, at most %d are allowed; shorten the duration or lower the frequency: %w", keys, maxShakeKeyframes, ErrInvalidParams)
+	}
+
+	var position, poi, roll []PropertyKeyframe
+	for k := 0; k <= keys; k++ {
+		elapsed := math.Min(math.Round(float64(k)/rate*frameRate)/frameRate, move.Duration)
+		fade := math.Min(1, math.Min(elapsed, move.Duration-elapsed)/0.25)
+		x := elapsed * frequency
+		a := amplitude * smoothstep(fade)
+		t := state.StartTime + elapsed
+
+		offset := [3]float64{noise[0](x) * a, noise[1](x) * a, noise[2](x) * a * 0.3}
+		if move.Rig {
+			offset = add3(state.Position, offset)
+		}
+		position = append(position, PropertyKeyframe{Time: t, Value: offset})
+		roll = append(roll, PropertyKeyframe{Time: t, Value: noise[3](x) * a * 0.08})
+		poi = append(poi, PropertyKeyframe{Time: t, Value: [3]float64{noise[4](x) * a * 0.5, noise[5](x) * a * 0.5, 0}})
+	}
+
+	if move.Rig {
+		return cameraMovePlan{
+			Rig: map[string]cameraPropertyKeys{
+				"position":  {Keyframes: position},
+				"zRotation": {Keyframes: roll},
+			},
+			RigCenter: state.Position,
+		}, nil
+	}
+	plan := cameraMovePlan{Camera: map[string]cameraPropertyKeys{
+		"position":  {Keyframes: position, Relative: true},
+		"zRotation": {Keyframes: roll, Relative: true},
+	}}
+	if state.PointOfInterest != nil {
+		plan.Camera["pointOfInterest"] = cameraPropertyKeys{Keyframes: poi, Relative: true}
+	}
+	return plan, nil
+}
+
+// bakedNoise returns smooth 1D value noise in [-1, 1] with a new random value at every
+// integer and a second octave at twice the rate
+func bakedNoise(seed int64) func(float64) float64 {
+	rng := rand.New(rand.NewSource(seed))
+	lattice := map[int]float64{}
+	value := func(i int) float64 {
+		if v, ok := lattice[i]; ok {
+			return v
+		}
+		// Fill in order so the same seed always gives the same values
+		for n := len(lattice); n <= i; n++ {
+			lattice[n] = rng.Float64()*2 - 1
+		}
+		return lattice[i]
+	}
+	octave := func(x float64) float64 {
+		i := int(math.Floor(x))
+		return value(i) + (value(i+1)-value(i))*smoothstep(x-float64(i))
+	}
+	return func(x float64) float64 {
+		return (octave(x) + 0.5*octave(x*2+17)) / 1.5
+	}
+}
+
+// smoothstep eases t in [0, 1] in and out
+func smoothstep(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+func add3(a, b [3]float64) [3]float64 {
+	return [3]float64{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func sub3(a, b [3]float64) [3]float64 {
+	return [3]float64{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func scale3(a [3]float64, s float64) [3]float64 {
+	return [3]float64{a[0] * s, a[1] * s, a[2] * s}
+}
+
+func cross3(a, b [3]float64) [3]float64 {
+	return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+// normalize3 returns a unit vector, or false for a zero vector
+func normalize3(a [3]float64) ([3]float64, bool) {
+	length := math.Sqrt(a[0]*a[0] + a[1]*a[1] + a[2]*a[2])
+	if length < 1e-9 {
+		return a, false
+	}
+	return scale3(a, 1/length), true
+}
+
+// rotateY3 rotates a vector about the vertical axis the way a positive Y Rotation turns a
+// layer in After Effects
+func rotateY3(a [3]float64, degrees float64) [3]float64 {
+	r := degrees * math.Pi / 180
+	sin, cos := math.Sin(r), math.Cos(r)
+	return [3]float64{a[0]*cos - a[2]*sin, a[1], a[0]*sin + a[2]*cos}
+}
+
+// cameraRigFindJS finds comp and camera by name, returning an error result if either is missing
+func cameraRigFindJS(compositionName string, cameraName string) string {
+	return `
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			if (app.project.item(i) instanceof CompItem && app.project.item(i).name === "` + escapeJSString(compositionName) + `") {
+				comp = app.project.item(i);
+				break;
+			}
+		}
+		if (!comp) {
+			return JSON.stringify({ error: "Composition not found: ` + escapeJSString(compositionName) + `" });
+		}
+
+		// Find the camera layer
+		var camera = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === "` + escapeJSString(cameraName) + `") {
+				camera = comp.layer(i);
+				break;
+			}
+		}
+		if (!camera) {
+			return JSON.stringify({ error: "Camera layer not found: ` + escapeJSString(cameraName) + `" });
+		}
+		if (!(camera instanceof CameraLayer)) {
+			return JSON.stringify({ error: "Layer is not a camera layer: ` + escapeJSString(cameraName) + `" });
+		}
+`
+}
+
+// readCameraRigState reads the camera's position and point of interest at the move's start
+func readCameraRigState(compositionName string, cameraName string, move CameraMove) (cameraRigState, error) {
+	var state cameraRigState
+	startJS := "comp.time"
+	if move.StartTime != nil {
+		startJS = fmt.Sprintf("%f", *move.StartTime)
+	}
+
+	script := `
+	try {
+		` + cameraRigFindJS(compositionName, cameraName) + `
+		var start = ` + startJS + `;
+		var transform = camera.transform;
+		var state = {
+			position: transform.position.valueAtTime(start, false),
+			pointOfInterest: null,
+			target: null,
+			startTime: start,
+			frameRate: comp.frameRate
+		};
+		if (camera.autoOrient === AutoOrientType.CAMERA_OR_POINT_OF_INTEREST) {
+			state.pointOfInterest = transform.pointOfInterest.valueAtTime(start, false);
+		}
+
+		var targetName = "` + escapeJSString(move.TargetLayer) + `";
+		if (targetName !== "") {
+			var target = null;
+			for (var i = 1; i <= comp.numLayers; i++) {
+				if (comp.layer(i).name === targetName) {
+					target = comp.layer(i);
+					break;
+				}
+			}
+			if (!target) {
+				return JSON.stringify({ error: "Target layer not found: " + targetName });
+			}
+			// toWorld is only available to expressions, so evaluate the target's world position
+			// with a temporary expression on the camera's position and restore it afterwards
+			var probe = camera.transform.position;
+			var savedExpression = probe.expression;
+			var savedEnabled = probe.expressionEnabled;
+			try {
+				probe.expression = "var L = thisComp.layer(" + target.index + "); var p = L.toWorld(L.anchorPoint); [p[0], p[1], p.length > 2 ? p[2] : 0]";
+				var targetPosition = probe.valueAtTime(start, false);
+				state.target = [targetPosition[0], targetPosition[1], targetPosition[2]];
+			} finally {
+				probe.expression = savedExpression;
+				if (savedExpression !== "") {
+					probe.expressionEnabled = savedEnabled;
+				}
+			}
+		}
+
+		return returnjson(state);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return state, err
+	}
+	resultStr, ok := result.(string)
+	if !ok {
+		return state, ErrInvalidResponse
+	}
+	if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+		return state, ErrAEScriptError(resultStr[7:])
+	}
+
+	var response struct {
+		cameraRigState
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(resultStr), &response); err != nil {
+		return state, err
+	}
+	if response.Error != "" {
+		return state, fmt.Errorf("%s", response.Error)
+	}
+	return response.cameraRigState, nil
+}
+
+// writeCameraMove writes a plan's keyframes to the camera, or creates the rig null named
+// rigName, parents the camera to it and keyframes the null
+func writeCameraMove(compositionName string, cameraName string, rigName string, plan cameraMovePlan, moveType string, startTime float64, duration float64) (LayerInfo, error) {
+	planJSON, err := json.Marshal(plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize camera move: %w", err)
+	}
+
+	script := `
+	try {
+		` + keyframeJS + `
+		` + cameraRigFindJS(compositionName, cameraName) + `
+		var plan = ` + string(planJSON) + `;
+		var rigName = "` + escapeJSString(rigName) + `";
+
+		function transformProperty(layer, key) {
+			switch (key) {
+				case "position": return layer.transform.position;
+				case "pointOfInterest": return layer.transform.pointOfInterest;
+				case "yRotation": return layer.transform.yRotation;
+				case "zRotation": return layer.transform.zRotation;
+			}
+			return null;
+		}
+
+		function addValues(base, offset) {
+			if (typeof base === "number") return base + offset;
+			var sum = [];
+			for (var d = 0; d < base.length; d++) sum.push(base[d] + (offset[d] || 0));
+			return sum;
+		}
+
+		var written = {};
+		var errors = [];
+		function applyKeys(layer, properties) {
+			for (var key in properties) {
+				var prop = transformProperty(layer, key);
+				var keyframes = properties[key].keyframes;
+				if (properties[key].relative) {
+					// Read every base value before adding keys, which change the animation
+					var bases = [];
+					for (var k = 0; k < keyframes.length; k++) bases.push(prop.valueAtTime(keyframes[k].time, true));
+					for (var k = 0; k < keyframes.length; k++) keyframes[k].value = addValues(bases[k], keyframes[k].value);
+				}
+				var outcome = setPropertyKeyframes(prop, keyframes);
+				written[key] = outcome.keyframes;
+				errors = errors.concat(outcome.errors);
+			}
+		}
+
+		var rig = null;
+		if (rigName !== "") {
+			rig = comp.layers.addNull();
+			rig.name = rigName;
+			rig.threeDLayer = true;
+			rig.moveBefore(camera);
+			if (camera.parent) {
+				rig.setParentWithJump(camera.parent);
+			}
+			rig.transform.position.setValue(plan.rigCenter);
+			camera.parent = rig;
+			applyKeys(rig, plan.rig);
+		} else {
+			applyKeys(camera, plan.camera);
+		}
+
+		return returnjson({
+			camera: camera.name,
+			move: "` + moveType + `",
+			startTime: ` + fmt.Sprintf("%f", startTime) + `,
+			endTime: ` + fmt.Sprintf("%f", startTime+duration) + `,
+			rig: rig ? rig.name : null,
+			keyframes: written,
+			errors: errors
+		});
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+	resultStr, ok := result.(string)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+		return nil, ErrAEScriptError(resultStr[7:])
+	}
+
+	var info LayerInfo
+	if err := json.Unmarshal([]byte(resultStr), &info); err != nil {
+		return nil, err
+	}
+	if errMsg, hasErr := info["error"].(string); hasErr {
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+	return info, nil
+}
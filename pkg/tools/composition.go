@@ -4,17 +4,26 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+	"strings"
 )
 
 // CompositionDetails represents the details of an After Effects composition
 type CompositionDetails map[string]interface{}
 
 
-// CreateComposition creates a new composition in After Effects
-func CreateComposition(name string, width int, height int, duration float64, frameRate float64) (CompositionDetails, error) {
+// CreateComposition creates a new composition in After Effects. renderer picks the 3D
+// renderer (Classic 3D, Cinema 4D, Advanced 3D, Ray-traced 3D or a match name), or keeps
+// the default when empty; the composition is not created if the renderer isn't available.
+func CreateComposition(name string, width int, height int, duration float64, frameRate float64, renderer string) (CompositionDetails, error) {
+	rendererMatchName := ""
+	if renderer != "" {
+		rendererMatchName = lookupRendererMatchName(renderer)
+	}
+
 	// Execute JavaScript to create composition
 	script := `
 	try {
+		var renderer = "` + escapeJSString(rendererMatchName) + `";
 		var name = "` + name + `";
 		var width = ` + fmt.Sprintf("%d", width) + `;
 		var height = ` + fmt.Sprintf("%d", height) + `;
@@ -25,6 +34,23 @@ func CreateComposition(name string, width int, height int, duration float64, fra
 		var project = app.project;
 		var comp = project.items.addComp(name, width, height, 1, duration, frameRate);
 		
+		// Pick the 3D renderer
+		if (renderer !== "") {
+			var available = false;
+			for (var i = 0; i < comp.renderers.length; i++) {
+				if (comp.renderers[i] === renderer) {
+					available = true;
+					break;
+				}
+			}
+			if (!available) {
+				var renderers = comp.renderers.join(", ");
+				comp.remove();
+				return "ERROR: Renderer not available: " + renderer + ". Available renderers: " + renderers;
+			}
+			comp.renderer = renderer;
+		}
+		
 		// Return the new composition details
 		var result = {
 			name: comp.name,
@@ -32,7 +58,8 @@ func CreateComposition(name string, width int, height int, duration float64, fra
 			duration: comp.duration,
 			width: comp.width,
 			height: comp.height,
-			frameRate: comp.frameRate
+			frameRate: comp.frameRate,
+			renderer: comp.renderer
 		};
 		
 		return returnjson(result);
@@ -65,3 +92,551 @@ func CreateComposition(name string, width int, height int, duration float64, fra
 
 	return nil, ErrInvalidResponse
 }
+
+// CompositionSettings represents settings that can be changed on an existing composition.
+// Nil fields are left unchanged.
+type CompositionSettings struct {
+	Name               *string   `json:"name,omitempty"`
+	Width              *int      `json:"width,omitempty"`
+	Height             *int      `json:"height,omitempty"`
+	Anchor             string    `json:"anchor,omitempty"` // Where existing layers stay pinned when resizing, e.g. "center" or "top-left"
+	PixelAspect        *float64  `json:"pixelAspect,omitempty"`
+	Duration           *float64  `json:"duration,omitempty"`
+	FrameRate          *float64  `json:"frameRate,omitempty"`
+	BgColor            *ColorRGB `json:"bgColor,omitempty"`
+	WorkAreaStart      *float64  `json:"workAreaStart,omitempty"`
+	WorkAreaDuration   *float64  `json:"workAreaDuration,omitempty"`
+	MotionBlur         *bool     `json:"motionBlur,omitempty"`
+	ShutterAngle       *float64  `json:"shutterAngle,omitempty"`
+	ShutterPhase       *float64  `json:"shutterPhase,omitempty"`
+	Renderer           string    `json:"renderer,omitempty"`
+	PreserveResolution *bool     `json:"preserveResolution,omitempty"`
+	PreserveFrameRate  *bool     `json:"preserveFrameRate,omitempty"`
+}
+
+// compositionAnchors maps resize anchor names to the fraction of the size change
+// that existing layers are shifted by on each axis
+var compositionAnchors = map[string][2]float64{
+	"top-left":     {0, 0},
+	"top":          {0.5, 0},
+	"top-right":    {1, 0},
+	"left":         {0, 0.5},
+	"center":       {0.5, 0.5},
+	"right":        {1, 0.5},
+	"bottom-left":  {0, 1},
+	"bottom":       {0.5, 1},
+	"bottom-right": {1, 1},
+}
+
+// CompositionRenderers maps friendly 3D renderer names to their match names
+var CompositionRenderers = map[string]string{
+	"Classic 3D":    "ADBE Advanced 3d",
+	"Cinema 4D":     "ADBE Ernst",
+	"Ray-traced 3D": "ADBE Picasso",
+	"Advanced 3D":   "ADBE Calder",
+}
+
+// lookupRendererMatchName converts a friendly renderer name to its match name
+func lookupRendererMatchName(renderer string) string {
+	for name, matchName := range CompositionRenderers {
+		if strings.EqualFold(name, renderer) {
+			return matchName
+		}
+	}
+	return renderer
+}
+
+// compositionSettingsJS is the ExtendScript helper that reports a composition's settings
+const compositionSettingsJS = `
+		function getCompSettings(comp) {
+			return {
+				name: comp.name,
+				id: comp.id,
+				width: comp.width,
+				height: comp.height,
+				pixelAspect: comp.pixelAspect,
+				duration: comp.duration,
+				frameRate: comp.frameRate,
+				bgColor: comp.bgColor,
+				workAreaStart: comp.workAreaStart,
+				workAreaDuration: comp.workAreaDuration,
+				motionBlur: comp.motionBlur,
+				shutterAngle: comp.shutterAngle,
+				shutterPhase: comp.shutterPhase,
+				renderer: comp.renderer,
+				renderers: comp.renderers,
+				preserveResolution: comp.preserveNestedResolution,
+				preserveFrameRate: comp.preserveNestedFrameRate,
+				numLayers: comp.numLayers
+			};
+		}
+`
+
+// validateCompositionSettings checks settings against the ranges After Effects accepts
+func validateCompositionSettings(settings CompositionSettings) error {
+	if settings.Name != nil && *settings.Name == "" {
+		return fmt.Errorf("composition name can't be empty: %w", ErrInvalidParams)
+	}
+	if settings.Width != nil && (*settings.Width < 4 || *settings.Width > 30000) {
+		return fmt.Errorf("width must be between 4 and 30000 pixels: %w", ErrInvalidParams)
+	}
+	if settings.Height != nil && (*settings.Height < 4 || *settings.Height > 30000) {
+		return fmt.Errorf("height must be between 4 and 30000 pixels: %w", ErrInvalidParams)
+	}
+	if settings.PixelAspect != nil && (*settings.PixelAspect < 0.01 || *settings.PixelAspect > 100) {
+		return fmt.Errorf("pixel aspect must be between 0.01 and 100: %w", ErrInvalidParams)
+	}
+	if settings.Duration != nil && (*settings.Duration <= 0 || *settings.Duration > 10800) {
+		return fmt.Errorf("duration must be more than 0 and at most 10800 seconds: %w", ErrInvalidParams)
+	}
+	if settings.FrameRate != nil && (*settings.FrameRate < 1 || *settings.FrameRate > 999) {
+		return fmt.Errorf("frame rate must be between 1 and 999: %w", ErrInvalidParams)
+	}
+	if settings.WorkAreaDuration != nil && *settings.WorkAreaDuration <= 0 {
+		return fmt.Errorf("work area duration must be positive: %w", ErrInvalidParams)
+	}
+	if settings.ShutterAngle != nil && (*settings.ShutterAngle < 0 || *settings.ShutterAngle > 720) {
+		return fmt.Errorf("shutter angle must be between 0 and 720: %w", ErrInvalidParams)
+	}
+	if settings.ShutterPhase != nil && (*settings.ShutterPhase < -360 || *settings.ShutterPhase > 360) {
+		return fmt.Errorf("shutter phase must be between -360 and 360: %w", ErrInvalidParams)
+	}
+	return nil
+}
+
+// ModifyComposition changes the settings of an existing composition. Settings are validated,
+// and the renderer's availability checked, before anything is changed.
+func ModifyComposition(compName string, settings CompositionSettings) (CompositionDetails, error) {
+	// Work out how far layers move when the size changes around an anchor
+	anchor := [2]float64{0, 0}
+	if settings.Anchor != "" {
+		var ok bool
+		anchor, ok = compositionAnchors[strings.ToLower(settings.Anchor)]
+		if !ok {
+			return nil, fmt.Errorf("invalid anchor: %s. Must be one of: top-left, top, top-right, left, center, right, bottom-left, bottom, bottom-right", settings.Anchor)
+		}
+	}
+	if settings.Renderer != "" {
+		settings.Renderer = lookupRendererMatchName(settings.Renderer)
+	}
+	if err := validateCompositionSettings(settings); err != nil {
+		return nil, err
+	}
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize composition settings: %w", err)
+	}
+
+	script := `
+	try {
+		var compName = "` + compName + `";
+		var settings = ` + string(settingsJSON) + `;
+		var anchor = [` + fmt.Sprintf("%f, %f", anchor[0], anchor[1]) + `];
+		` + compositionSettingsJS + `
+		
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+		
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+		
+		// Shift a property (and all of its keyframes) by an offset
+		function offsetProperty(prop, offset) {
+			if (prop.numKeys > 0) {
+				for (var k = 1; k <= prop.numKeys; k++) {
+					var value = prop.keyValue(k);
+					if (value instanceof Array) {
+						for (var d = 0; d < offset.length && d < value.length; d++) {
+							value[d] += offset[d];
+						}
+					} else {
+						value += offset[0];
+					}
+					prop.setValueAtKey(k, value);
+				}
+			} else {
+				var value = prop.value;
+				if (value instanceof Array) {
+					for (var d = 0; d < offset.length && d < value.length; d++) {
+						value[d] += offset[d];
+					}
+				} else {
+					value += offset[0];
+				}
+				prop.setValue(value);
+			}
+		}
+		
+		// Move unparented layers so they stay pinned to the anchor
+		function shiftLayers(dx, dy) {
+			for (var i = 1; i <= comp.numLayers; i++) {
+				var layer = comp.layer(i);
+				if (layer.parent) continue;
+				var transform = layer.transform;
+				if (transform.position.dimensionsSeparated) {
+					offsetProperty(transform.xPosition, [dx]);
+					offsetProperty(transform.yPosition, [dy]);
+				} else {
+					offsetProperty(transform.position, [dx, dy]);
+				}
+				if (transform.pointOfInterest) {
+					offsetProperty(transform.pointOfInterest, [dx, dy]);
+				}
+			}
+		}
+		
+		// Check everything that can fail before changing anything, so a failed call leaves
+		// the composition as it was
+		if (settings.renderer !== undefined) {
+			var available = false;
+			for (var i = 0; i < comp.renderers.length; i++) {
+				if (comp.renderers[i] === settings.renderer) {
+					available = true;
+					break;
+				}
+			}
+			if (!available) {
+				return JSON.stringify({
+					error: "Renderer not available: " + settings.renderer + ". Available renderers: " + comp.renderers.join(", ")
+				});
+			}
+		}
+		var finalDuration = settings.duration !== undefined ? settings.duration : comp.duration;
+		if (settings.workAreaStart !== undefined || settings.workAreaDuration !== undefined) {
+			var checkStart = settings.workAreaStart !== undefined ? settings.workAreaStart : comp.workAreaStart;
+			if (checkStart < 0 || checkStart >= finalDuration) {
+				return JSON.stringify({
+					error: "Work area start must be between 0 and the composition duration (" + finalDuration + "s)"
+				});
+			}
+			if (settings.workAreaDuration !== undefined && checkStart + settings.workAreaDuration > finalDuration + 0.0001) {
+				return JSON.stringify({
+					error: "Work area ends after the composition duration (" + finalDuration + "s)"
+				});
+			}
+		}
+		
+		var modified = {};
+		
+		if (settings.name !== undefined) {
+			comp.name = settings.name;
+			modified.name = settings.name;
+		}
+		
+		// Size
+		if (settings.width !== undefined || settings.height !== undefined) {
+			var oldWidth = comp.width;
+			var oldHeight = comp.height;
+			var newWidth = settings.width !== undefined ? settings.width : oldWidth;
+			var newHeight = settings.height !== undefined ? settings.height : oldHeight;
+			comp.width = newWidth;
+			comp.height = newHeight;
+			var dx = (newWidth - oldWidth) * anchor[0];
+			var dy = (newHeight - oldHeight) * anchor[1];
+			if (dx !== 0 || dy !== 0) {
+				shiftLayers(dx, dy);
+			}
+			modified.size = [newWidth, newHeight];
+		}
+		
+		if (settings.pixelAspect !== undefined) {
+			comp.pixelAspect = settings.pixelAspect;
+			modified.pixelAspect = settings.pixelAspect;
+		}
+		
+		if (settings.duration !== undefined) {
+			comp.duration = settings.duration;
+			modified.duration = settings.duration;
+		}
+		
+		if (settings.frameRate !== undefined) {
+			comp.frameRate = settings.frameRate;
+			modified.frameRate = settings.frameRate;
+		}
+		
+		if (settings.bgColor !== undefined) {
+			comp.bgColor = settings.bgColor;
+			modified.bgColor = settings.bgColor;
+		}
+		
+		// Work area
+		if (settings.workAreaStart !== undefined || settings.workAreaDuration !== undefined) {
+			var workAreaStart = settings.workAreaStart !== undefined ? settings.workAreaStart : comp.workAreaStart;
+			var workAreaDuration = settings.workAreaDuration !== undefined ? settings.workAreaDuration : Math.min(comp.workAreaDuration, comp.duration - workAreaStart);
+			
+			// Shrink the work area first so the new start is never out of range
+			comp.workAreaDuration = comp.frameDuration;
+			comp.workAreaStart = workAreaStart;
+			comp.workAreaDuration = workAreaDuration;
+			modified.workAreaStart = comp.workAreaStart;
+			modified.workAreaDuration = comp.workAreaDuration;
+		}
+		
+		// Motion blur
+		if (settings.motionBlur !== undefined) {
+			comp.motionBlur = settings.motionBlur;
+			modified.motionBlur = settings.motionBlur;
+		}
+		
+		if (settings.shutterAngle !== undefined) {
+			comp.shutterAngle = settings.shutterAngle;
+			modified.shutterAngle = settings.shutterAngle;
+		}
+		
+		if (settings.shutterPhase !== undefined) {
+			comp.shutterPhase = settings.shutterPhase;
+			modified.shutterPhase = settings.shutterPhase;
+		}
+		
+		// 3D renderer, checked above
+		if (settings.renderer !== undefined) {
+			comp.renderer = settings.renderer;
+			modified.renderer = settings.renderer;
+		}
+		
+		if (settings.preserveResolution !== undefined) {
+			comp.preserveNestedResolution = settings.preserveResolution;
+			modified.preserveResolution = settings.preserveResolution;
+		}
+		
+		if (settings.preserveFrameRate !== undefined) {
+			comp.preserveNestedFrameRate = settings.preserveFrameRate;
+			modified.preserveFrameRate = settings.preserveFrameRate;
+		}
+		
+		var result = getCompSettings(comp);
+		result.modified = modified;
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var compDetails CompositionDetails
+		if err := json.Unmarshal([]byte(resultStr), &compDetails); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := compDetails["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return compDetails, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// DuplicateComposition duplicates a composition. A shallow duplicate shares nested
+// compositions with the original; a deep duplicate also duplicates every nested
+// composition and points the copied layers at the copies.
+func DuplicateComposition(compName string, newName string, deep bool) (CompositionDetails, error) {
+	script := `
+	try {
+		var compName = "` + compName + `";
+		var newName = "` + escapeJSString(newName) + `";
+		var deep = ` + fmt.Sprintf("%t", deep) + `;
+		
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+		
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+		
+		// Duplicates keyed by original comp id, so a precomp used twice is copied once
+		var copies = {};
+		var duplicated = [];
+		
+		function duplicateComp(source) {
+			if (copies[source.id]) {
+				return copies[source.id];
+			}
+			var copy = source.duplicate();
+			copies[source.id] = copy;
+			duplicated.push({ original: source.name, copy: copy.name, id: copy.id });
+			if (deep) {
+				for (var i = 1; i <= copy.numLayers; i++) {
+					var layer = copy.layer(i);
+					if (layer.source && layer.source instanceof CompItem) {
+						layer.replaceSource(duplicateComp(layer.source), false);
+					}
+				}
+			}
+			return copy;
+		}
+		
+		var newComp = duplicateComp(comp);
+		if (newName !== "") {
+			newComp.name = newName;
+			duplicated[0].copy = newName;
+		}
+		
+		var result = {
+			name: newComp.name,
+			id: newComp.id,
+			duration: newComp.duration,
+			width: newComp.width,
+			height: newComp.height,
+			frameRate: newComp.frameRate,
+			deep: deep,
+			duplicated: duplicated
+		};
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var compDetails CompositionDetails
+		if err := json.Unmarshal([]byte(resultStr), &compDetails); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := compDetails["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return compDetails, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// TrimCompToWorkArea trims a composition to its work area, moving the work area
+// start to time zero like After Effects' "Trim Comp to Work Area" command
+func TrimCompToWorkArea(compName string) (CompositionDetails, error) {
+	script := `
+	try {
+		var compName = "` + compName + `";
+		
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+		
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+		
+		var workAreaStart = comp.workAreaStart;
+		var workAreaDuration = comp.workAreaDuration;
+		
+		// Slide every layer (with its keyframes) back so the work area starts at zero
+		if (workAreaStart !== 0) {
+			for (var i = 1; i <= comp.numLayers; i++) {
+				var layer = comp.layer(i);
+				var locked = layer.locked;
+				layer.locked = false;
+				layer.startTime -= workAreaStart;
+				layer.locked = locked;
+			}
+		}
+		
+		comp.duration = workAreaDuration;
+		comp.workAreaStart = 0;
+		comp.workAreaDuration = workAreaDuration;
+		
+		var result = {
+			name: comp.name,
+			id: comp.id,
+			duration: comp.duration,
+			removedFromStart: workAreaStart,
+			workAreaStart: comp.workAreaStart,
+			workAreaDuration: comp.workAreaDuration
+		};
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var compDetails CompositionDetails
+		if err := json.Unmarshal([]byte(resultStr), &compDetails); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := compDetails["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return compDetails, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
@@ -15,11 +15,12 @@ type EffectParameters map[string]interface{}
 
 // EffectInfo represents information about a known After Effects effect
 type EffectInfo struct {
-	MatchName   string
-	DisplayName string
-	Category    string
-	BPC         string // Bits per channel
-	GPU         string // Version when GPU acceleration was introduced (if applicable)
+	MatchName      string            `json:"matchName"`
+	DisplayName    string            `json:"displayName"`
+	Category       string            `json:"category"`
+	BPC            string            `json:"bpc,omitempty"`            // Bits per channel
+	GPU            string            `json:"gpu,omitempty"`            // Version when GPU acceleration was introduced (if applicable)
+	LocalizedNames map[string]string `json:"localizedNames,omitempty"` // Display names by AE language, e.g. "zh_CN"
 }
 
 // List of known effects organized by category
@@ -238,23 +239,39 @@ func lookupEffectMatchName(nameOrMatchName string) string {
 		}
 	}
 	
+	// Look up by display name in any language AE has been scanned in, or we have translations for
+	if matchName, ok := lookupLocalizedEffect(nameOrMatchName); ok {
+		return matchName
+	}
+	
 	// If not found in our known effects, return the input as is
 	// (it might be a third-party effect or one we don't have listed)
 	return nameOrMatchName
 }
 
-// ApplyEffect applies an effect to a layer in a composition
+// ApplyEffect applies an effect to a layer in a composition. Parameters are keyed by match name
+// or display name and validated against the effect's parameter description; the result has a
+// "report" entry per parameter with status "applied" or "failed" and the reason for failures.
 func ApplyEffect(compName string, layerName string, effectName string, parameters EffectParameters) (EffectDetails, error) {
 	// Convert effect name to match name if possible
 	effectMatchName := lookupEffectMatchName(effectName)
 	
+	if parameters == nil {
+		parameters = EffectParameters{}
+	}
+	parametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize effect parameters: %w", err)
+	}
+	
 	// Build the script to apply the effect
 	script := `
 	try {
-		var compName = "` + compName + `";
-		var layerName = "` + layerName + `";
-		var effectName = "` + effectName + `";
-		var effectMatchName = "` + effectMatchName + `";
+		var compName = "` + escapeJSStringEffect(compName) + `";
+		var layerName = "` + escapeJSStringEffect(layerName) + `";
+		var effectName = "` + escapeJSStringEffect(effectName) + `";
+		var effectMatchName = "` + escapeJSStringEffect(effectMatchName) + `";
+		var parameters = ` + string(parametersJSON) + `;
 		
 		// Find the composition
 		var comp = null;
@@ -311,88 +328,27 @@ func ApplyEffect(compName string, layerName string, effectName string, parameter
 		}
 	`
 
-	// If parameters were provided, adjust them
-	if parameters != nil {
-		for key, value := range parameters {
-			// Different handling based on parameter type
-			switch v := value.(type) {
-			case float64:
-				script += `
-				try {
-					if (effect.property("` + key + `")) {
-						effect.property("` + key + `").setValue(` + fmt.Sprintf("%f", v) + `);
-					}
-				} catch (paramErr) {
-					// Skip this parameter if it doesn't exist or can't be set
-				}
-				`
-			case bool:
-				boolVal := 0
-				if v {
-					boolVal = 1
-				}
-				script += `
-				try {
-					if (effect.property("` + key + `")) {
-						effect.property("` + key + `").setValue(` + fmt.Sprintf("%d", boolVal) + `);
-					}
-				} catch (paramErr) {
-					// Skip this parameter if it doesn't exist or can't be set
-				}
-				`
-			case string:
-				script += `
-				try {
-					if (effect.property("` + key + `")) {
-						effect.property("` + key + `").setValue("` + escapeJSStringEffect(v) + `");
-					}
-				} catch (paramErr) {
-					// Skip this parameter if it doesn't exist or can't be set
-				}
-				`
-			case []interface{}:
-				// Handle arrays (e.g., for color or point values)
-				if len(v) > 0 {
-					arrayStr := "["
-					for i, item := range v {
-						if i > 0 {
-							arrayStr += ", "
-						}
-						switch itemVal := item.(type) {
-						case float64:
-							arrayStr += fmt.Sprintf("%f", itemVal)
-						case int:
-							arrayStr += fmt.Sprintf("%d", itemVal)
-						case string:
-							arrayStr += `"` + escapeJSStringEffect(itemVal) + `"`
-						default:
-							arrayStr += "0"
-						}
-					}
-					arrayStr += "]"
-					
-					script += `
-					try {
-						if (effect.property("` + key + `")) {
-							effect.property("` + key + `").setValue(` + arrayStr + `);
-						}
-					} catch (paramErr) {
-						// Skip this parameter if it doesn't exist or can't be set
-					}
-					`
-				}
-			}
-		}
-	}
-
 	// Complete the script
-	script += `
+	script += effectParamsJS + `
+		// Set and validate the parameters
+		var report = [];
+		var failedCount = 0;
+		for (var key in parameters) {
+			if (!parameters.hasOwnProperty(key)) continue;
+			var entry = setEffectParam(effect, key, parameters[key], comp);
+			if (entry.status !== "applied") failedCount++;
+			report.push(entry);
+		}
+		
 		// Get information about the applied effect
 		var effectInfo = {
 			name: effect.name,
 			displayName: effect.displayName || effect.name,
 			matchName: effect.matchName,
-			parameters: []
+			parameters: [],
+			report: report,
+			failedCount: failedCount,
+			projectBitsPerChannel: app.project.bitsPerChannel
 		};
 		
 		// Gather parameter information
@@ -434,35 +390,43 @@ func ApplyEffect(compName string, layerName string, effectName string, parameter
 			return nil, err
 		}
 		
-		// Check for error in result
+		// Check for error in result, suggesting close matches for unknown effects
 		if errMsg, hasErr := effectDetails["error"].(string); hasErr {
+			if strings.HasPrefix(errMsg, "Failed to apply effect") && !isKnownEffect(effectName) {
+				if suggestions := effectSuggestions(effectName); suggestions != "" {
+					errMsg += ". " + suggestions
+				}
+			}
 			return nil, fmt.Errorf("%s", errMsg)
 		}
 		
+		// Warn when the effect clips a high bit depth project
+		warnings := []string{}
+		matchName, _ := effectDetails["matchName"].(string)
+		projectBPC, _ := effectDetails["projectBitsPerChannel"].(float64)
+		if info, ok := lookupEffectInfo(matchName); ok {
+			if warning := effectBitDepthWarning(info, int(projectBPC)); warning != "" {
+				warnings = append(warnings, warning)
+			}
+		}
+		effectDetails["warnings"] = warnings
+		
 		return effectDetails, nil
 	}
 
 	return nil, ErrInvalidResponse
 }
 
-// GetEffectCategories returns a list of effect categories
+// GetEffectCategories returns a list of effect categories from the cached effect catalog,
+// or from KnownEffects when no catalog has been discovered yet
 func GetEffectCategories() []string {
-	categories := make(map[string]bool)
-	for _, effect := range KnownEffects {
-		categories[effect.Category] = true
-	}
-	
-	result := make([]string, 0, len(categories))
-	for category := range categories {
-		result = append(result, category)
-	}
-	return result
+	return categoriesOf(catalogEffects())
 }
 
 // GetEffectsByCategory returns effects in a specified category
 func GetEffectsByCategory(category string) []EffectInfo {
 	var effects []EffectInfo
-	for _, effect := range KnownEffects {
+	for _, effect := range catalogEffects() {
 		if effect.Category == category {
 			effects = append(effects, effect)
 		}
@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// effectInfoIndex maps match names to effect details from KnownEffects and the cached
+// effect catalog
+func effectInfoIndex() map[string]EffectInfo {
+	index := make(map[string]EffectInfo, len(KnownEffects))
+	for matchName, effect := range KnownEffects {
+		index[matchName] = effect
+	}
+	for _, effect := range catalogEffects() {
+		if effect.BPC != "" {
+			index[effect.MatchName] = effect
+		}
+	}
+	return index
+}
+
+// lookupEffectInfo finds an effect's details by match name
+func lookupEffectInfo(matchName string) (EffectInfo, bool) {
+	effect, ok := effectInfoIndex()[matchName]
+	return effect, ok
+}
+
+// effectBitDepthWarning returns a warning when an effect processes fewer bits per channel
+// than the project, which clips high-bit-depth color, or "" when the effect is fine or its bit
+// depth is unknown
+func effectBitDepthWarning(effect EffectInfo, projectBPC int) string {
+	effectBPC, err := strconv.Atoi(effect.BPC)
+	if err != nil || effectBPC >= projectBPC {
+		return ""
+	}
+	consequence := "clipping overbright values"
+	if effectBPC == 8 {
+		consequence = "reducing precision to 8 bits per channel, which can add banding"
+		if projectBPC == 32 {
+			consequence += ", and clipping overbright values"
+		}
+	}
+	return fmt.Sprintf("%s is a %d-bpc effect in a %d-bpc project: %s", effect.DisplayName, effectBPC, projectBPC, consequence)
+}
+
+// EffectRenderReport lists, for every composition, the effects that will clip high-bit-depth
+// color in this project and the effects that have no GPU acceleration and so force the layer
+// to render on the CPU. Effects without bit depth or GPU details in the catalog are listed as
+// unknown.
+func EffectRenderReport() (EffectDetails, error) {
+	script := `
+	try {
+		var comps = [];
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (!(item instanceof CompItem)) continue;
+
+			var compEffects = [];
+			for (var l = 1; l <= item.numLayers; l++) {
+				var layer = item.layer(l);
+				var parade = layer.property("ADBE Effect Parade");
+				if (!parade) continue;
+				for (var e = 1; e <= parade.numProperties; e++) {
+					var fx = parade.property(e);
+					compEffects.push({
+						layer: layer.name,
+						layerIndex: layer.index,
+						index: e,
+						name: fx.name,
+						matchName: fx.matchName,
+						enabled: fx.enabled && layer.enabled
+					});
+				}
+			}
+			comps.push({ name: item.name, effects: compEffects });
+		}
+
+		var gpuAccel = "";
+		try { gpuAccel = String(app.project.gpuAccelType); } catch (e) {}
+
+		return returnjson({
+			bitsPerChannel: app.project.bitsPerChannel,
+			gpuAccelType: gpuAccel,
+			comps: comps
+		});
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	details, err := runEffectScript(script)
+	if err != nil {
+		return nil, err
+	}
+	projectBPC := 8
+	if bpc, ok := details["bitsPerChannel"].(float64); ok {
+		projectBPC = int(bpc)
+	}
+
+	infos := effectInfoIndex()
+	comps, _ := details["comps"].([]interface{})
+	report := make([]map[string]interface{}, 0, len(comps))
+	totals := map[string]int{"clipsBitDepth": 0, "forcesCPU": 0, "unknown": 0}
+	for _, rawComp := range comps {
+		comp, _ := rawComp.(map[string]interface{})
+		clips := []map[string]interface{}{}
+		cpu := []map[string]interface{}{}
+		unknown := []map[string]interface{}{}
+
+		effects, _ := comp["effects"].([]interface{})
+		for _, rawEffect := range effects {
+			entry, _ := rawEffect.(map[string]interface{})
+			matchName, _ := entry["matchName"].(string)
+			info, known := infos[matchName]
+			if !known {
+				unknown = append(unknown, entry)
+				continue
+			}
+			entry["bpc"] = info.BPC
+			if warning := effectBitDepthWarning(info, projectBPC); warning != "" {
+				clipped := copyEffectEntry(entry)
+				clipped["warning"] = warning
+				clips = append(clips, clipped)
+			}
+			if info.GPU == "" {
+				cpu = append(cpu, entry)
+			}
+		}
+		if len(effects) == 0 {
+			continue
+		}
+
+		totals["clipsBitDepth"] += len(clips)
+		totals["forcesCPU"] += len(cpu)
+		totals["unknown"] += len(unknown)
+		report = append(report, map[string]interface{}{
+			"composition":   comp["name"],
+			"effects":       len(effects),
+			"clipsBitDepth": clips,
+			"forcesCPU":     cpu,
+			"unknown":       unknown,
+		})
+	}
+
+	return EffectDetails{
+		"bitsPerChannel": projectBPC,
+		"gpuAccelType":   details["gpuAccelType"],
+		"comps":          report,
+		"totals":         totals,
+	}, nil
+}
+
+// copyEffectEntry makes a shallow copy of a report entry so it can be annotated separately
+func copyEffectEntry(entry map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(entry)+1)
+	for k, v := range entry {
+		copied[k] = v
+	}
+	return copied
+}
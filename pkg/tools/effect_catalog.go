@@ -0,0 +1,285 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+)
+
+// EffectCatalog is the effect list discovered from one After Effects version
+type EffectCatalog struct {
+	Version   string       `json:"version"`
+	Build     string       `json:"build,omitempty"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+	Effects   []EffectInfo `json:"effects"`
+}
+
+// effectCatalogCache is the on-disk catalog file, keyed by After Effects version
+type effectCatalogCache struct {
+	LastVersion string                    `json:"lastVersion"`
+	Versions    map[string]*EffectCatalog `json:"versions"`
+}
+
+// effectCatalogMemo is the catalog cache as last read from disk, so effect lookups don't
+// reread the file; RefreshEffectCatalog clears it when it saves a new scan
+var effectCatalogMemo struct {
+	sync.Mutex
+	cache *effectCatalogCache
+}
+
+// EffectCatalogPath returns the location of the effect catalog cache in the AE-MCP folder
+func EffectCatalogPath() (string, error) {
+	folders, err := ae.GetMCPFolders()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(folders.BaseFolder, "effect-catalog.json"), nil
+}
+
+// loadEffectCatalogCache reads the catalog cache, returning an empty cache if there is none
+func loadEffectCatalogCache() (*effectCatalogCache, error) {
+	cache := &effectCatalogCache{Versions: map[string]*EffectCatalog{}}
+	path, err := EffectCatalogPath()
+	if err != nil {
+		return cache, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return cache, fmt.Errorf("failed to read effect catalog: %w", err)
+	}
+	if err := json.Unmarshal(data, cache); err != nil {
+		return cache, fmt.Errorf("invalid effect catalog %s: %w", path, err)
+	}
+	if cache.Versions == nil {
+		cache.Versions = map[string]*EffectCatalog{}
+	}
+	return cache, nil
+}
+
+// cachedEffectCatalog returns the catalog cache, reading it from disk on first use. The
+// result is shared and must not be modified.
+func cachedEffectCatalog() (*effectCatalogCache, error) {
+	effectCatalogMemo.Lock()
+	defer effectCatalogMemo.Unlock()
+	if effectCatalogMemo.cache != nil {
+		return effectCatalogMemo.cache, nil
+	}
+	cache, err := loadEffectCatalogCache()
+	if err != nil {
+		return cache, err
+	}
+	effectCatalogMemo.cache = cache
+	return cache, nil
+}
+
+// saveEffectCatalogCache writes the catalog cache, replacing the file atomically
+func saveEffectCatalogCache(cache *effectCatalogCache) error {
+	path, err := EffectCatalogPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create catalog folder: %w", err)
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize effect catalog: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write effect catalog: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// mergeEffectCatalog merges discovered effects into a version's catalog by match name.
+// Effects missing from the scan are kept, since a plugin may only be unavailable for now, and
+// bit depth and GPU details are carried over from KnownEffects. Names scanned in language are
+// recorded as localized names; a scan in another language than English keeps the English
+// display name and category when they are already known. It returns how many effects were
+// new.
+func mergeEffectCatalog(catalog *EffectCatalog, discovered []EffectInfo, language string) int {
+	index := make(map[string]int, len(catalog.Effects))
+	for i, effect := range catalog.Effects {
+		index[effect.MatchName] = i
+	}
+
+	added := 0
+	for _, effect := range discovered {
+		known, isKnown := KnownEffects[effect.MatchName]
+		if isKnown {
+			effect.BPC, effect.GPU = known.BPC, known.GPU
+		}
+
+		i, exists := index[effect.MatchName]
+		names := map[string]string{}
+		if exists {
+			for lang, name := range catalog.Effects[i].LocalizedNames {
+				names[lang] = name
+			}
+		}
+		if language != "" {
+			names[language] = effect.DisplayName
+		}
+		if len(names) > 0 {
+			effect.LocalizedNames = names
+		}
+
+		if !isEnglishLanguage(language) {
+			if exists {
+				effect.DisplayName, effect.Category = catalog.Effects[i].DisplayName, catalog.Effects[i].Category
+			} else if isKnown {
+				effect.DisplayName, effect.Category = known.DisplayName, known.Category
+			}
+		}
+
+		if exists {
+			catalog.Effects[i] = effect
+			continue
+		}
+		index[effect.MatchName] = len(catalog.Effects)
+		catalog.Effects = append(catalog.Effects, effect)
+		added++
+	}
+
+	sort.Slice(catalog.Effects, func(i, j int) bool {
+		a, b := catalog.Effects[i], catalog.Effects[j]
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		return a.DisplayName < b.DisplayName
+	})
+	return added
+}
+
+// RefreshEffectCatalog enumerates app.effects in the running After Effects and merges the
+// result into the on-disk catalog under the running version. Display names are recorded under
+// the running language (app.isoLanguage), so scanning in each language AE is used in lets
+// effects be found by any of their names.
+func RefreshEffectCatalog() (map[string]interface{}, error) {
+	script := `
+	try {
+		var effects = [];
+		for (var i = 0; i < app.effects.length; i++) {
+			var effect = app.effects[i];
+			effects.push({
+				matchName: effect.matchName,
+				displayName: effect.displayName,
+				category: effect.category
+			});
+		}
+
+		return returnjson({
+			version: app.version,
+			build: app.buildName,
+			language: app.isoLanguage,
+			effects: effects
+		});
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	resultStr, ok := result.(string)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+		return nil, ErrAEScriptError(resultStr[7:])
+	}
+
+	var scanned struct {
+		EffectCatalog
+		Language string `json:"language"`
+	}
+	if err := json.Unmarshal([]byte(resultStr), &scanned); err != nil {
+		return nil, err
+	}
+	if scanned.Version == "" {
+		return nil, ErrInvalidResponse
+	}
+
+	cache, err := loadEffectCatalogCache()
+	if err != nil {
+		return nil, err
+	}
+	catalog, ok := cache.Versions[scanned.Version]
+	if !ok {
+		catalog = &EffectCatalog{Version: scanned.Version}
+		cache.Versions[scanned.Version] = catalog
+	}
+	catalog.Build = scanned.Build
+	catalog.UpdatedAt = time.Now().UTC()
+	added := mergeEffectCatalog(catalog, scanned.Effects, scanned.Language)
+	cache.LastVersion = scanned.Version
+
+	err = saveEffectCatalogCache(cache)
+	effectCatalogMemo.Lock()
+	effectCatalogMemo.cache = nil
+	effectCatalogMemo.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	path, _ := EffectCatalogPath()
+	return map[string]interface{}{
+		"version":    catalog.Version,
+		"build":      catalog.Build,
+		"language":   scanned.Language,
+		"discovered": len(scanned.Effects),
+		"added":      added,
+		"effects":    len(catalog.Effects),
+		"categories": len(categoriesOf(catalog.Effects)),
+		"path":       path,
+	}, nil
+}
+
+// catalogEffects returns the effects of the most recently scanned After Effects version,
+// falling back to KnownEffects when no catalog has been cached
+func catalogEffects() []EffectInfo {
+	cache, err := cachedEffectCatalog()
+	if err == nil {
+		if catalog, ok := cache.Versions[cache.LastVersion]; ok && len(catalog.Effects) > 0 {
+			return catalog.Effects
+		}
+	}
+
+	effects := make([]EffectInfo, 0, len(KnownEffects))
+	for _, effect := range KnownEffects {
+		effects = append(effects, effect)
+	}
+	sort.Slice(effects, func(i, j int) bool {
+		return effects[i].DisplayName < effects[j].DisplayName
+	})
+	return effects
+}
+
+// categoriesOf returns the sorted, non-empty categories of a list of effects. Effects
+// without a category are hidden from the Effects menu.
+func categoriesOf(effects []EffectInfo) []string {
+	seen := make(map[string]bool)
+	var categories []string
+	for _, effect := range effects {
+		if effect.Category != "" && !seen[effect.Category] {
+			seen[effect.Category] = true
+			categories = append(categories, effect.Category)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
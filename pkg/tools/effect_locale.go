@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"strings"
+)
+
+// knownLocalizedNames holds translated display names for common effects, by AE language and
+// match name, so they resolve before the effect catalog has been scanned in that language
+var knownLocalizedNames = map[string]map[string]string{
+	"zh_CN": {
+		"ADBE Camera Lens Blur":        "摄像机镜头模糊",
+		"ADBE Motion Blur":             "定向模糊",
+		"ADBE Box Blur2":               "快速方框模糊",
+		"ADBE Gaussian Blur 2":         "高斯模糊",
+		"ADBE Radial Blur":             "径向模糊",
+		"ADBE Sharpen":                 "锐化",
+		"ADBE Smart Blur":              "智能模糊",
+		"ADBE Unsharp Mask2":           "钝化蒙版",
+		"ADBE Invert":                  "反转",
+		"ADBE Brightness & Contrast 2": "亮度和对比度",
+		"ADBE Lumetri":                 "Lumetri 颜色",
+		"ADBE Curves":                  "曲线",
+		"ADBE HUE SATURATION":          "色相/饱和度",
+		"ADBE Tint":                    "色调",
+		"ADBE Ripple":                  "波纹",
+		"ADBE Spherize":                "球面化",
+		"ADBE TRANSFORM":               "变换",
+		"ADBE Wave Warp":               "波形变形",
+		"ADBE Angle Control":           "角度控制",
+		"ADBE Checkbox Control":        "复选框控制",
+		"ADBE Color Control":           "颜色控制",
+		"ADBE Layer Control":           "图层控制",
+		"ADBE Point Control":           "点控制",
+		"ADBE Slider Control":          "滑块控制",
+		"ADBE 4ColorGradient":          "四色渐变",
+		"ADBE Fill":                    "填充",
+		"ADBE Ramp":                    "梯度渐变",
+		"ADBE Lens Flare":              "镜头光晕",
+		"ADBE Scribble Fill":           "涂写",
+		"ADBE Stroke":                  "描边",
+		"ADBE Write-on":                "书写",
+		"VISINF Grain Implant":         "添加颗粒",
+		"ADBE Fractal Noise":           "分形杂色",
+		"ADBE Noise":                   "杂色",
+		"ADBE AIF Perlin Noise 3D":     "湍流杂色",
+		"ADBE Glo2":                    "发光",
+		"ADBE Tile":                    "动态拼贴",
+		"ADBE Posterize":               "色调分离",
+		"ADBE Find Edges":              "查找边缘",
+		"ADBE Mosaic":                  "马赛克",
+		"ADBE Roughen Edges":           "毛边",
+		"ADBE Numbers2":                "编号",
+		"ADBE Echo":                    "残影",
+		"ADBE Timewarp":                "时间扭曲",
+		"ADBE Block Dissolve":          "块溶解",
+		"ADBE Gradient Wipe":           "渐变擦除",
+		"ADBE IRIS_WIPE":               "光圈擦除",
+		"ADBE Linear Wipe":             "线性擦除",
+		"ADBE Radial Wipe":             "径向擦除",
+		"ADBE Venetian Blinds":         "百叶窗",
+	},
+}
+
+// isEnglishLanguage reports whether an AE language code, such as "en_US", is English
+func isEnglishLanguage(language string) bool {
+	return language == "" || strings.HasPrefix(strings.ToLower(language), "en")
+}
+
+// localizedNamesOf returns an effect's display names by language, combining the built-in
+// translations with the names recorded by catalog scans
+func localizedNamesOf(effect EffectInfo) map[string]string {
+	names := make(map[string]string, len(effect.LocalizedNames)+len(knownLocalizedNames))
+	for language, translations := range knownLocalizedNames {
+		if name, ok := translations[effect.MatchName]; ok {
+			names[language] = name
+		}
+	}
+	for language, name := range effect.LocalizedNames {
+		names[language] = name
+	}
+	return names
+}
+
+// lookupLocalizedEffect resolves a display name in any language to a match name, using the
+// effect catalogs of every scanned After Effects version and the built-in translations.
+// Names are compared ignoring case.
+func lookupLocalizedEffect(name string) (string, bool) {
+	if cache, err := cachedEffectCatalog(); err == nil {
+		for _, catalog := range cache.Versions {
+			for _, effect := range catalog.Effects {
+				if effect.MatchName == name || strings.EqualFold(effect.DisplayName, name) {
+					return effect.MatchName, true
+				}
+				for _, localized := range effect.LocalizedNames {
+					if strings.EqualFold(localized, name) {
+						return effect.MatchName, true
+					}
+				}
+			}
+		}
+	}
+
+	for _, translations := range knownLocalizedNames {
+		for matchName, localized := range translations {
+			if strings.EqualFold(localized, name) {
+				return matchName, true
+			}
+		}
+	}
+	return "", false
+}
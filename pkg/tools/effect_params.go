@@ -0,0 +1,289 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+)
+
+// effectParamsJS describes effect parameters and sets them with validation. It defines:
+//
+//	describeEffectProperties(group) - parameter descriptions, recursing into parameter groups
+//	findEffectParam(effect, key)    - a parameter by match name, or by display name ignoring case
+//	setEffectParam(effect, key, value, comp) - validates and sets one value, returning a report entry
+//
+// Popup menus accept a 1-based item number or an item name, checkboxes accept booleans, layer
+// parameters accept a layer index or name, and colors accept [r, g, b] or [r, g, b, a] in 0-1.
+const effectParamsJS = `
+		var effectValueTypes = {};
+		effectValueTypes[PropertyValueType.OneD] = "oneD";
+		effectValueTypes[PropertyValueType.TwoD] = "twoD";
+		effectValueTypes[PropertyValueType.TwoD_SPATIAL] = "twoD";
+		effectValueTypes[PropertyValueType.ThreeD] = "threeD";
+		effectValueTypes[PropertyValueType.ThreeD_SPATIAL] = "threeD";
+		effectValueTypes[PropertyValueType.COLOR] = "color";
+		effectValueTypes[PropertyValueType.LAYER_INDEX] = "layer";
+		effectValueTypes[PropertyValueType.MASK_INDEX] = "mask";
+		effectValueTypes[PropertyValueType.CUSTOM_VALUE] = "custom";
+		effectValueTypes[PropertyValueType.NO_VALUE] = "noValue";
+
+		function describeEffectProperty(prop) {
+			var info = {
+				name: prop.name,
+				matchName: prop.matchName,
+				index: prop.propertyIndex
+			};
+			if (prop.propertyType !== PropertyType.PROPERTY) {
+				info.valueType = "group";
+				info.parameters = describeEffectProperties(prop);
+				return info;
+			}
+
+			info.valueType = effectValueTypes[prop.propertyValueType] || "unknown";
+			var dropdown = false;
+			try { dropdown = prop.isDropdownEffect; } catch (e) {}
+			if (dropdown) {
+				info.valueType = "popup";
+				try {
+					var options = prop.propertyParameters;
+					if (options && options.length > 0) {
+						info.options = [];
+						for (var o = 0; o < options.length; o++) info.options.push(String(options[o]));
+					}
+				} catch (e) {}
+			}
+			if (prop.hasMin) info.min = prop.minValue;
+			if (prop.hasMax) info.max = prop.maxValue;
+			info.canSetValue = prop.canSetValue;
+			info.canVaryOverTime = prop.canVaryOverTime;
+			if (info.valueType !== "noValue" && info.valueType !== "custom") {
+				try { info.defaultValue = prop.value; } catch (e) {}
+			}
+			return info;
+		}
+
+		function describeEffectProperties(group) {
+			var params = [];
+			for (var p = 1; p <= group.numProperties; p++) {
+				var prop = group.property(p);
+				// Unnamed no-value properties only mark the end of a parameter topic
+				if (prop.propertyType === PropertyType.PROPERTY && prop.propertyValueType === PropertyValueType.NO_VALUE && !prop.name) continue;
+				params.push(describeEffectProperty(prop));
+			}
+			return params;
+		}
+
+		function searchEffectParams(group, matches) {
+			for (var p = 1; p <= group.numProperties; p++) {
+				var prop = group.property(p);
+				if (matches(prop)) return prop;
+				if (prop.propertyType !== PropertyType.PROPERTY) {
+					var nested = searchEffectParams(prop, matches);
+					if (nested) return nested;
+				}
+			}
+			return null;
+		}
+
+		function findEffectParam(effect, key) {
+			var lowerKey = String(key).toLowerCase();
+			return searchEffectParams(effect, function (prop) { return prop.matchName === key; }) ||
+				searchEffectParams(effect, function (prop) { return prop.name.toLowerCase() === lowerKey; });
+		}
+
+		function isNumberArray(value, lengths) {
+			if (!(value instanceof Array)) return false;
+			var lengthOk = false;
+			for (var l = 0; l < lengths.length; l++) if (value.length === lengths[l]) lengthOk = true;
+			if (!lengthOk) return false;
+			for (var v = 0; v < value.length; v++) if (typeof value[v] !== "number") return false;
+			return true;
+		}
+
+		function effectParamValue(info, value, comp) {
+			switch (info.valueType) {
+			case "oneD":
+				if (typeof value === "boolean") value = value ? 1 : 0;
+				if (typeof value !== "number") return { error: "expects a number" };
+				if (info.min !== undefined && value < info.min) return { error: "must be at least " + info.min };
+				if (info.max !== undefined && value > info.max) return { error: "must be at most " + info.max };
+				return { value: value };
+			case "popup":
+				var count = info.options ? info.options.length : info.max;
+				if (typeof value === "string") {
+					if (!info.options) return { error: "menu item names are not available in this version of After Effects; use the item number" };
+					for (var o = 0; o < info.options.length; o++) {
+						if (info.options[o].toLowerCase() === value.toLowerCase()) return { value: o + 1 };
+					}
+					return { error: "unknown menu item, expected one of: " + info.options.join(", ") };
+				}
+				if (typeof value !== "number" || value !== Math.floor(value)) return { error: "expects a menu item number or name" };
+				if (value < 1 || (count && value > count)) return { error: "menu item must be between 1 and " + count };
+				return { value: value };
+			case "twoD":
+				if (!isNumberArray(value, [2])) return { error: "expects [x, y]" };
+				return { value: value };
+			case "threeD":
+				if (!isNumberArray(value, [3])) return { error: "expects [x, y, z]" };
+				return { value: value };
+			case "color":
+				if (!isNumberArray(value, [3, 4])) return { error: "expects [r, g, b] or [r, g, b, a] in 0-1" };
+				return { value: value.length === 3 ? [value[0], value[1], value[2], 1] : value };
+			case "layer":
+				if (typeof value === "string") {
+					for (var li = 1; li <= comp.numLayers; li++) {
+						if (comp.layer(li).name === value) return { value: li };
+					}
+					return { error: "layer not found: " + value };
+				}
+				if (typeof value !== "number" || value < 0 || value > comp.numLayers) return { error: "expects a layer name or index (0 for none)" };
+				return { value: value };
+			case "mask":
+				if (typeof value !== "number") return { error: "expects a mask index" };
+				return { value: value };
+			}
+			return { error: "parameters of type " + info.valueType + " cannot be set by value" };
+		}
+
+		function setEffectParam(effect, key, value, comp) {
+			var entry = { parameter: key, status: "failed" };
+			var prop = findEffectParam(effect, key);
+			if (!prop) {
+				entry.error = "no such parameter";
+				return entry;
+			}
+			entry.name = prop.name;
+			entry.matchName = prop.matchName;
+			if (prop.propertyType !== PropertyType.PROPERTY) {
+				entry.error = "is a parameter group";
+				return entry;
+			}
+			if (!prop.canSetValue) {
+				entry.error = "cannot be set";
+				return entry;
+			}
+
+			var checked = effectParamValue(describeEffectProperty(prop), value, comp);
+			if (checked.error) {
+				entry.error = checked.error;
+				return entry;
+			}
+			try {
+				if (prop.numKeys > 0) {
+					prop.setValueAtTime(comp.time, checked.value);
+				} else {
+					prop.setValue(checked.value);
+				}
+				entry.status = "applied";
+				entry.value = prop.value;
+			} catch (setErr) {
+				entry.error = setErr.toString();
+			}
+			return entry;
+		}
+`
+
+// DescribeEffect reports the parameters of an effect: display name, match name, value type,
+// min/max, popup menu items and default value. Effects only expose their parameters once
+// applied, so the effect is added to a temporary composition that is removed afterwards.
+func DescribeEffect(effectName string) (EffectDetails, error) {
+	effectMatchName := lookupEffectMatchName(effectName)
+
+	script := `
+	try {
+		var effectName = "` + escapeJSStringEffect(effectName) + `";
+		var effectMatchName = "` + escapeJSStringEffect(effectMatchName) + `";
+		` + effectParamsJS + `
+		// Remember existing folders so a Solids folder created for the probe can be removed
+		var folderIds = {};
+		for (var i = 1; i <= app.project.numItems; i++) {
+			if (app.project.item(i) instanceof FolderItem) folderIds[app.project.item(i).id] = true;
+		}
+
+		var probeComp = app.project.items.addComp("Effect Probe", 100, 100, 1, 1, 30);
+		var probeLayer = probeComp.layers.addSolid([0, 0, 0], "Effect Probe", 100, 100, 1);
+		var probeSource = probeLayer.source;
+
+		var result = null;
+		var failure = null;
+		try {
+			var effect = null;
+			if (probeLayer.Effects.canAddProperty(effectMatchName)) {
+				effect = probeLayer.Effects.addProperty(effectMatchName);
+			} else if (probeLayer.Effects.canAddProperty(effectName)) {
+				effect = probeLayer.Effects.addProperty(effectName);
+			}
+			if (!effect) {
+				failure = "Effect not found: " + effectName;
+			} else {
+				result = {
+					displayName: effect.name,
+					matchName: effect.matchName,
+					parameters: describeEffectProperties(effect)
+				};
+			}
+		} catch (probeErr) {
+			failure = "Failed to describe effect: " + effectName + ". Error: " + probeErr.toString();
+		}
+
+		// Clean up the probe
+		probeComp.remove();
+		probeSource.remove();
+		for (var i = app.project.numItems; i >= 1; i--) {
+			var folder = app.project.item(i);
+			if (folder instanceof FolderItem && !folderIds[folder.id] && folder.numItems === 0) folder.remove();
+		}
+
+		if (failure) {
+			return JSON.stringify({
+				error: failure
+			});
+		}
+
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	details, err := runEffectScript(script)
+	if err != nil && !isKnownEffect(effectName) {
+		if suggestions := effectSuggestions(effectName); suggestions != "" {
+			return nil, fmt.Errorf("%w. %s", err, suggestions)
+		}
+	}
+	return details, err
+}
+
+// runEffectScript executes an effect script and decodes its result
+func runEffectScript(script string) (EffectDetails, error) {
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var effectDetails EffectDetails
+		if err := json.Unmarshal([]byte(resultStr), &effectDetails); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := effectDetails["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return effectDetails, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
@@ -0,0 +1,363 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+	"gopkg.in/yaml.v3"
+)
+
+// EffectRecipe is a named effect stack that can be applied to a layer in one step. Recipes
+// are stored as JSON or YAML files in the recipes folder.
+type EffectRecipe struct {
+	Name        string         `json:"name" yaml:"name"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Effects     []RecipeEffect `json:"effects" yaml:"effects"`
+	Source      string         `json:"source,omitempty" yaml:"-"` // File the recipe was loaded from, or "builtin"
+}
+
+// RecipeEffect is one effect of a recipe, applied in order
+type RecipeEffect struct {
+	Effect     string           `json:"effect" yaml:"effect"`                 // Display name or match name
+	Name       string           `json:"name,omitempty" yaml:"name,omitempty"` // Instance name on the layer
+	Enabled    *bool            `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Parameters EffectParameters `json:"parameters,omitempty" yaml:"parameters,omitempty"` // Keyed by parameter display name or match name
+}
+
+// builtinEffectRecipes are available until a recipe file with the same name replaces them
+var builtinEffectRecipes = []EffectRecipe{
+	{
+		Name:        "soft glow",
+		Description: "Diffused highlights with a gentle bloom",
+		Effects: []RecipeEffect{
+			{Effect: "Gaussian Blur", Parameters: EffectParameters{"Blurriness": 4.0}},
+			{Effect: "Glow", Parameters: EffectParameters{"Glow Threshold": 60.0, "Glow Radius": 30.0, "Glow Intensity": 0.8}},
+			{Effect: "Curves"},
+		},
+	},
+	{
+		Name:        "film look",
+		Description: "Muted color, fine grain and a soft vignette",
+		Effects: []RecipeEffect{
+			{Effect: "Curves"},
+			{Effect: "Hue/Saturation", Parameters: EffectParameters{"Master Saturation": -15.0}},
+			{Effect: "Noise", Parameters: EffectParameters{"Amount of Noise": 3.0}},
+			{Effect: "CC Vignette", Parameters: EffectParameters{"Amount": 40.0}},
+		},
+	},
+}
+
+// EffectRecipeDir returns the folder effect recipes are read from and saved to
+func EffectRecipeDir() (string, error) {
+	folders, err := ae.GetMCPFolders()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(folders.BaseFolder, "recipes"), nil
+}
+
+// isKnownEffect reports whether an effect is in KnownEffects or the cached effect catalog
+func isKnownEffect(name string) bool {
+	matchName := lookupEffectMatchName(name)
+	if _, ok := KnownEffects[matchName]; ok {
+		return true
+	}
+	for _, effect := range catalogEffects() {
+		if effect.MatchName == name || strings.EqualFold(effect.DisplayName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEffectRecipe checks that a recipe has a name and names every effect. Effects that
+// aren't known are still accepted, since they may be match names of third-party or newer
+// effects, such as those recorded by SaveLayerEffectsAsRecipe; applying the recipe reports
+// any effect AE can't add.
+func validateEffectRecipe(recipe EffectRecipe) error {
+	if strings.TrimSpace(recipe.Name) == "" {
+		return fmt.Errorf("recipe name is required: %w", ErrInvalidParams)
+	}
+	if len(recipe.Effects) == 0 {
+		return fmt.Errorf("recipe %s has no effects: %w", recipe.Name, ErrInvalidParams)
+	}
+	for i, effect := range recipe.Effects {
+		if strings.TrimSpace(effect.Effect) == "" {
+			return fmt.Errorf("recipe %s effect %d has no effect name: %w", recipe.Name, i+1, ErrInvalidParams)
+		}
+	}
+	return nil
+}
+
+// loadEffectRecipeFile reads a JSON or YAML recipe; the name defaults to the file name
+func loadEffectRecipeFile(path string) (EffectRecipe, error) {
+	var recipe EffectRecipe
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return recipe, fmt.Errorf("failed to read recipe: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &recipe)
+	} else {
+		err = yaml.Unmarshal(data, &recipe)
+	}
+	if err != nil {
+		return recipe, fmt.Errorf("invalid recipe %s: %w", path, err)
+	}
+	if recipe.Name == "" {
+		recipe.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	recipe.Source = path
+	return recipe, validateEffectRecipe(recipe)
+}
+
+// ListEffectRecipes returns the built-in recipes and those in the recipes folder, sorted by
+// name. Files that fail to load are reported in the second return value instead of failing
+// the whole list.
+func ListEffectRecipes() ([]EffectRecipe, []string, error) {
+	recipes := map[string]EffectRecipe{}
+	for _, recipe := range builtinEffectRecipes {
+		recipe.Source = "builtin"
+		recipes[strings.ToLower(recipe.Name)] = recipe
+	}
+
+	dir, err := EffectRecipeDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to read recipes folder: %w", err)
+	}
+
+	var problems []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		recipe, err := loadEffectRecipeFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		recipes[strings.ToLower(recipe.Name)] = recipe
+	}
+
+	list := make([]EffectRecipe, 0, len(recipes))
+	for _, recipe := range recipes {
+		list = append(list, recipe)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name)
+	})
+	return list, problems, nil
+}
+
+// FindEffectRecipe looks up a recipe by name, ignoring case
+func FindEffectRecipe(name string) (EffectRecipe, error) {
+	recipes, _, err := ListEffectRecipes()
+	if err != nil {
+		return EffectRecipe{}, err
+	}
+	for _, recipe := range recipes {
+		if strings.EqualFold(recipe.Name, name) {
+			return recipe, nil
+		}
+	}
+	names := make([]string, len(recipes))
+	for i, recipe := range recipes {
+		names[i] = recipe.Name
+	}
+	return EffectRecipe{}, fmt.Errorf("recipe not found: %s. Available recipes: %s: %w", name, strings.Join(names, ", "), ErrInvalidParams)
+}
+
+// ApplyEffectRecipe adds a recipe's effects to a layer in order, in a single script run.
+// Each effect's parameters are validated as in ApplyEffect; the result reports every effect
+// and parameter as applied or failed.
+func ApplyEffectRecipe(compName string, layerName string, recipeName string) (EffectDetails, error) {
+	recipe, err := FindEffectRecipe(recipeName)
+	if err != nil {
+		return nil, err
+	}
+
+	type scriptEffect struct {
+		RecipeEffect
+		MatchName string `json:"matchName"`
+	}
+	effects := make([]scriptEffect, len(recipe.Effects))
+	for i, effect := range recipe.Effects {
+		effects[i] = scriptEffect{RecipeEffect: effect, MatchName: lookupEffectMatchName(effect.Effect)}
+	}
+	effectsJSON, err := json.Marshal(effects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize recipe: %w", err)
+	}
+
+	script, err := effectStackScript(compName, layerName, nil, effectParamsJS+`
+		var recipeEffects = `+string(effectsJSON)+`;
+		var applied = [];
+		var failedCount = 0;
+
+		for (var r = 0; r < recipeEffects.length; r++) {
+			var spec = recipeEffects[r];
+			var entry = { effect: spec.effect, status: "failed" };
+			var parade = layer.property("ADBE Effect Parade");
+			var fx = null;
+			if (parade.canAddProperty(spec.matchName)) {
+				fx = parade.addProperty(spec.matchName);
+			} else if (parade.canAddProperty(spec.effect)) {
+				fx = parade.addProperty(spec.effect);
+			}
+			if (!fx) {
+				entry.error = "effect is not available";
+				failedCount++;
+				applied.push(entry);
+				continue;
+			}
+
+			// Adding effects invalidates earlier references, so keep only the index
+			var fxIndex = fx.propertyIndex;
+			if (spec.name) fx.name = spec.name;
+			if (spec.enabled !== undefined) fx.enabled = spec.enabled;
+
+			entry.status = "applied";
+			entry.index = fxIndex;
+			entry.name = fx.name;
+			entry.matchName = fx.matchName;
+			entry.report = [];
+			var params = spec.parameters || {};
+			for (var key in params) {
+				if (!params.hasOwnProperty(key)) continue;
+				var paramEntry = setEffectParam(layer.property("ADBE Effect Parade").property(fxIndex), key, params[key], comp);
+				if (paramEntry.status !== "applied") failedCount++;
+				entry.report.push(paramEntry);
+			}
+			applied.push(entry);
+		}
+
+		return returnjson({
+			recipe: "`+escapeJSStringEffect(recipe.Name)+`",
+			layer: layer.name,
+			effects: applied,
+			failedCount: failedCount
+		});`)
+	if err != nil {
+		return nil, err
+	}
+	return runEffectScript(script)
+}
+
+// SaveLayerEffectsAsRecipe saves a layer's current effect stack as a recipe file. Effects are
+// recorded by match name with the values of their settable parameters; layer and mask
+// references are left out since they only make sense in the original composition. format is
+// "json" (default) or "yaml".
+func SaveLayerEffectsAsRecipe(compName string, layerName string, recipeName string, description string, format string, overwrite bool) (EffectDetails, error) {
+	if strings.TrimSpace(recipeName) == "" {
+		return nil, fmt.Errorf("recipe name is required: %w", ErrInvalidParams)
+	}
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "yaml" {
+		return nil, fmt.Errorf("invalid recipe format: %s. Must be one of: json, yaml: %w", format, ErrInvalidParams)
+	}
+
+	dir, err := EffectRecipeDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, recipeFileName(recipeName)+"."+format)
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return nil, fmt.Errorf("recipe file already exists: %s: %w", path, ErrInvalidParams)
+	}
+
+	script, err := effectStackScript(compName, layerName, nil, `
+		function collectValues(group, values) {
+			for (var p = 1; p <= group.numProperties; p++) {
+				var prop = group.property(p);
+				if (prop.propertyType !== PropertyType.PROPERTY) {
+					collectValues(prop, values);
+					continue;
+				}
+				var type = prop.propertyValueType;
+				if (!prop.canSetValue || type === PropertyValueType.NO_VALUE || type === PropertyValueType.CUSTOM_VALUE ||
+					type === PropertyValueType.LAYER_INDEX || type === PropertyValueType.MASK_INDEX) continue;
+				values[prop.matchName] = prop.value;
+			}
+			return values;
+		}
+
+		var stack = [];
+		for (var e = 1; e <= effects.numProperties; e++) {
+			var fx = effects.property(e);
+			var spec = { effect: fx.matchName, name: fx.name, parameters: collectValues(fx, {}) };
+			if (!fx.enabled) spec.enabled = false;
+			stack.push(spec);
+		}
+
+		return returnjson({ effects: stack });`)
+	if err != nil {
+		return nil, err
+	}
+	details, err := runEffectScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	recipe := EffectRecipe{Name: recipeName, Description: description}
+	data, err := json.Marshal(details["effects"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read effect stack: %w", err)
+	}
+	if err := json.Unmarshal(data, &recipe.Effects); err != nil {
+		return nil, fmt.Errorf("failed to read effect stack: %w", err)
+	}
+	if len(recipe.Effects) == 0 {
+		return nil, fmt.Errorf("layer has no effects to save: %s: %w", layerName, ErrInvalidParams)
+	}
+
+	if format == "yaml" {
+		data, err = yaml.Marshal(recipe)
+	} else {
+		data, err = json.MarshalIndent(recipe, "", "  ")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize recipe: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create recipes folder: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write recipe: %w", err)
+	}
+
+	return EffectDetails{
+		"recipe":  recipe.Name,
+		"path":    path,
+		"effects": len(recipe.Effects),
+	}, nil
+}
+
+// recipeFileName turns a recipe name into a file name without extension
+func recipeFileName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ' || r == '.':
+			b.WriteRune('-')
+		}
+	}
+	if b.Len() == 0 {
+		return "recipe"
+	}
+	return b.String()
+}
@@ -0,0 +1,237 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EffectSearchResult is an effect matched by SearchEffects
+type EffectSearchResult struct {
+	EffectInfo
+	Score  int    `json:"score"`  // 0-100, higher is a closer match
+	Reason string `json:"reason"` // What matched: name, match name, localized name, synonym, category or similar name
+}
+
+// effectSynonyms maps everyday terms to the display names of the effects they usually mean
+var effectSynonyms = map[string][]string{
+	"blur":            {"Gaussian Blur", "Fast Box Blur", "Camera Lens Blur", "Directional Blur", "Radial Blur"},
+	"defocus":         {"Camera Lens Blur", "Gaussian Blur"},
+	"bokeh":           {"Camera Lens Blur"},
+	"depth of field":  {"Camera Lens Blur", "Depth of Field"},
+	"motion blur":     {"Directional Blur", "CC Force Motion Blur", "Pixel Motion Blur"},
+	"soften":          {"Gaussian Blur", "Fast Box Blur", "Smart Blur"},
+	"sharpen":         {"Sharpen", "Unsharp Mask"},
+	"glow":            {"Glow", "CC Light Rays"},
+	"bloom":           {"Glow"},
+	"light rays":      {"CC Light Rays", "CC Light Burst 2.5"},
+	"god rays":        {"CC Light Rays"},
+	"flare":           {"Lens Flare"},
+	"lightning":       {"Advanced Lightning"},
+	"color grade":     {"Lumetri Color", "Curves", "Color Balance (HLS)"},
+	"grade":           {"Lumetri Color", "Curves"},
+	"lut":             {"Apply Color LUT", "Lumetri Color"},
+	"contrast":        {"Brightness & Contrast", "Curves"},
+	"brightness":      {"Brightness & Contrast", "Curves"},
+	"saturation":      {"Hue/Saturation", "Lumetri Color"},
+	"desaturate":      {"Hue/Saturation", "Tint"},
+	"black and white": {"Tint", "Hue/Saturation"},
+	"grayscale":       {"Tint", "Hue/Saturation"},
+	"colorize":        {"Tint", "Hue/Saturation"},
+	"grain":           {"Add Grain", "Noise", "Match Grain"},
+	"film grain":      {"Add Grain", "Match Grain"},
+	"vignette":        {"CC Vignette"},
+	"clouds":          {"Fractal Noise", "Turbulent Noise"},
+	"smoke":           {"Fractal Noise", "Turbulent Noise"},
+	"fog":             {"Fractal Noise", "Fog 3D"},
+	"distort":         {"Wave Warp", "Ripple", "Spherize", "Warp", "Liquify"},
+	"wave":            {"Wave Warp", "Ripple"},
+	"ripple":          {"Ripple", "Wave Warp", "Radio Waves"},
+	"bulge":           {"Spherize", "Liquify"},
+	"pixelate":        {"Mosaic"},
+	"gradient":        {"Gradient Ramp", "4-Color Gradient"},
+	"ramp":            {"Gradient Ramp"},
+	"outline":         {"Stroke", "Find Edges"},
+	"edges":           {"Find Edges", "Roughen Edges"},
+	"cartoon":         {"Cartoon", "Posterize"},
+	"wipe":            {"Linear Wipe", "Radial Wipe", "Gradient Wipe", "Iris Wipe", "Card Wipe"},
+	"transition":      {"Linear Wipe", "Radial Wipe", "Gradient Wipe", "Block Dissolve", "Venetian Blinds"},
+	"dissolve":        {"Block Dissolve", "Gradient Wipe"},
+	"trail":           {"Echo", "CC Force Motion Blur"},
+	"slow motion":     {"Timewarp", "Pixel Motion Blur"},
+	"retime":          {"Timewarp"},
+	"write on":        {"Write-on", "Scribble", "Stroke"},
+	"handwriting":     {"Write-on", "Scribble"},
+	"counter":         {"Numbers", "Timecode"},
+	"negative":        {"Invert"},
+	"repeat":          {"Motion Tile", "CC RepeTile"},
+	"tile":            {"Motion Tile", "CC RepeTile"},
+	"kaleidoscope":    {"CC Kaleida"},
+	"shake":           {"Transform", "Wave Warp"},
+	"audio":           {"Audio Spectrum", "Audio Waveform"},
+	"visualizer":      {"Audio Spectrum", "Audio Waveform"},
+	"slider":          {"Slider Control"},
+}
+
+// SearchEffects ranks effects in the effect catalog (or KnownEffects) against a query, by
+// display name, match name, localized names, category, the synonym table and, for typos,
+// edit distance.
+// category, when given, limits results to that category, ignoring case.
+func SearchEffects(query string, category string, limit int) []EffectSearchResult {
+	if limit <= 0 {
+		limit = 10
+	}
+	return rankEffects(catalogEffects(), query, category, limit)
+}
+
+// rankEffects scores effects against a query and returns the best limit matches
+func rankEffects(effects []EffectInfo, query string, category string, limit int) []EffectSearchResult {
+	q := normalizeEffectQuery(query)
+	if q == "" && category == "" {
+		return nil
+	}
+
+	// Display names suggested by synonyms of the query, scored higher than synonyms of just
+	// some of its words
+	synonyms := map[string]int{}
+	for term, names := range effectSynonyms {
+		score := 0
+		if q == term {
+			score = 85
+		} else if strings.Contains(" "+q+" ", " "+term+" ") {
+			score = 65
+		}
+		for _, name := range names {
+			if key := strings.ToLower(name); score > synonyms[key] {
+				synonyms[key] = score
+			}
+		}
+	}
+
+	var results []EffectSearchResult
+	for _, effect := range effects {
+		if category != "" && !strings.EqualFold(effect.Category, category) {
+			continue
+		}
+		if effect.Category == "" {
+			continue
+		}
+		score, reason := scoreEffect(effect, q, synonyms)
+		if q == "" {
+			score, reason = 50, "category"
+		}
+		if score > 0 {
+			results = append(results, EffectSearchResult{EffectInfo: effect, Score: score, Reason: reason})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].DisplayName < results[j].DisplayName
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// scoreEffect scores one effect against a normalized query, keeping the best of the ways it
+// can match
+func scoreEffect(effect EffectInfo, q string, synonyms map[string]int) (int, string) {
+	name := normalizeEffectQuery(effect.DisplayName)
+	matchName := strings.ToLower(effect.MatchName)
+	effectCategory := normalizeEffectQuery(effect.Category)
+
+	best, reason := 0, ""
+	consider := func(score int, why string) {
+		if score > best {
+			best, reason = score, why
+		}
+	}
+
+	switch {
+	case name == q || matchName == q:
+		consider(100, "name")
+	case strings.HasPrefix(name, q):
+		consider(90, "name")
+	case containsAllWords(name, q):
+		consider(80, "name")
+	case strings.Contains(name, q):
+		consider(70, "name")
+	case strings.Contains(matchName, q):
+		consider(60, "match name")
+	}
+	for _, localized := range localizedNamesOf(effect) {
+		if localized = normalizeEffectQuery(localized); localized == q {
+			consider(100, "localized name")
+		} else if strings.Contains(localized, q) {
+			consider(70, "localized name")
+		}
+	}
+	consider(synonyms[strings.ToLower(effect.DisplayName)], "synonym")
+	if effectCategory == q {
+		consider(50, "category")
+	} else if containsAllWords(effectCategory, q) {
+		consider(40, "category")
+	}
+
+	// Typos: a close whole name ranks with real matches, a close single word below them
+	if similarity := effectNameSimilarity(q, name); similarity >= 0.75 {
+		consider(int(similarity*95), "similar name")
+	}
+	for _, word := range strings.Fields(name) {
+		if similarity := effectNameSimilarity(q, word); similarity >= 0.6 {
+			consider(int(similarity*60), "similar name")
+		}
+	}
+	return best, reason
+}
+
+// effectNameSimilarity is 1 minus the edit distance relative to the longer string
+func effectNameSimilarity(a string, b string) float64 {
+	longest := len([]rune(a))
+	if n := len([]rune(b)); n > longest {
+		longest = n
+	}
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(fontNameDistance(a, b))/float64(longest)
+}
+
+// containsAllWords reports whether every word of query appears in text
+func containsAllWords(text string, query string) bool {
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return false
+	}
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeEffectQuery lowercases a name and turns punctuation into single spaces
+func normalizeEffectQuery(s string) string {
+	s = strings.ToLower(s)
+	s = strings.NewReplacer("-", " ", "_", " ", "&", " ", "/", " ", "(", " ", ")", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// effectSuggestions formats the closest matches for an unknown effect name, or "" if there
+// are none
+func effectSuggestions(effectName string) string {
+	results := SearchEffects(effectName, "", 5)
+	if len(results) == 0 {
+		return ""
+	}
+	names := make([]string, len(results))
+	for i, result := range results {
+		names[i] = fmt.Sprintf("%s (%s)", result.DisplayName, result.MatchName)
+	}
+	return "Did you mean: " + strings.Join(names, ", ") + "?"
+}
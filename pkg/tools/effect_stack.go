@@ -0,0 +1,317 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EffectIdentifier selects an effect in a layer's effect stack, by 1-based index or by
+// name. Names match the effect's instance name first, then its match name, then its
+// display name ignoring case.
+type EffectIdentifier struct {
+	Name  string `json:"name,omitempty"`
+	Index int    `json:"index,omitempty"`
+}
+
+// EffectChanges are changes to an existing effect. Parameters and keyframes are keyed by
+// parameter match name or display name and validated like ApplyEffect parameters.
+type EffectChanges struct {
+	Parameters EffectParameters              `json:"parameters,omitempty"`
+	Keyframes  map[string][]PropertyKeyframe `json:"keyframes,omitempty"`
+	Enabled    *bool                         `json:"enabled,omitempty"`
+	Name       string                        `json:"name,omitempty"` // Rename the effect instance
+}
+
+// ParseEffectIdentifier decodes an effect identifier from a generic MCP argument map
+func ParseEffectIdentifier(raw map[string]interface{}) (EffectIdentifier, error) {
+	var effect EffectIdentifier
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return effect, fmt.Errorf("failed to serialize effect identifier: %w", err)
+	}
+	if err := json.Unmarshal(data, &effect); err != nil {
+		return effect, fmt.Errorf("invalid effect identifier: %w", err)
+	}
+	if effect.Name == "" && effect.Index <= 0 {
+		return effect, fmt.Errorf("effect must have either name or index field: %w", ErrInvalidParams)
+	}
+	return effect, nil
+}
+
+// ParsePropertyKeyframes decodes keyframes keyed by property from a generic MCP argument map
+func ParsePropertyKeyframes(raw map[string]interface{}) (map[string][]PropertyKeyframe, error) {
+	var keyframes map[string][]PropertyKeyframe
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize keyframes: %w", err)
+	}
+	if err := json.Unmarshal(data, &keyframes); err != nil {
+		return nil, fmt.Errorf("invalid keyframes: %w", err)
+	}
+	return keyframes, nil
+}
+
+// effectStackScript wraps body in a script that finds the composition, the layer and, when
+// effect is not nil, the effect; body can use comp, layer, effects and effect
+func effectStackScript(compName string, layerName string, effect *EffectIdentifier, body string) (string, error) {
+	effectJSON := []byte("null")
+	if effect != nil {
+		if effect.Name == "" && effect.Index <= 0 {
+			return "", fmt.Errorf("effect must have either name or index field: %w", ErrInvalidParams)
+		}
+		var err error
+		if effectJSON, err = json.Marshal(effect); err != nil {
+			return "", fmt.Errorf("failed to serialize effect identifier: %w", err)
+		}
+	}
+
+	return `
+	try {
+		var compName = "` + escapeJSStringEffect(compName) + `";
+		var layerName = "` + escapeJSStringEffect(layerName) + `";
+		var effectId = ` + string(effectJSON) + `;
+
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+
+		// Find the layer
+		var layer = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === layerName) {
+				layer = comp.layer(i);
+				break;
+			}
+		}
+
+		if (!layer) {
+			return JSON.stringify({
+				error: "Layer not found: " + layerName
+			});
+		}
+
+		var effects = layer.property("ADBE Effect Parade");
+		if (!effects) {
+			return JSON.stringify({
+				error: "Layer has no effects: " + layerName
+			});
+		}
+
+		// Find the effect
+		var effect = null;
+		if (effectId) {
+			if (effectId.index) {
+				if (effectId.index <= effects.numProperties) effect = effects.property(effectId.index);
+			} else {
+				var lowerName = effectId.name.toLowerCase();
+				var byMatchName = null, byDisplayName = null;
+				for (var e = 1; e <= effects.numProperties && !effect; e++) {
+					var candidate = effects.property(e);
+					if (candidate.name === effectId.name) effect = candidate;
+					if (!byMatchName && candidate.matchName === effectId.name) byMatchName = candidate;
+					if (!byDisplayName && candidate.name.toLowerCase() === lowerName) byDisplayName = candidate;
+				}
+				effect = effect || byMatchName || byDisplayName;
+			}
+			if (!effect) {
+				return JSON.stringify({
+					error: "Effect not found on " + layerName + ": " + (effectId.name || "index " + effectId.index) + " (the layer has " + effects.numProperties + " effect(s))"
+				});
+			}
+		}
+
+		function effectSummary(fx) {
+			return {
+				index: fx.propertyIndex,
+				name: fx.name,
+				matchName: fx.matchName,
+				enabled: fx.enabled
+			};
+		}
+		` + body + `
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`, nil
+}
+
+// ListLayerEffects lists a layer's effect stack in order, with each effect's parameters,
+// current values and keyframe counts
+func ListLayerEffects(compName string, layerName string) (EffectDetails, error) {
+	script, err := effectStackScript(compName, layerName, nil, `
+		function listParams(group) {
+			var params = [];
+			for (var p = 1; p <= group.numProperties; p++) {
+				var prop = group.property(p);
+				if (prop.propertyType !== PropertyType.PROPERTY) {
+					params.push({ name: prop.name, matchName: prop.matchName, parameters: listParams(prop) });
+				} else if (prop.propertyValueType !== PropertyValueType.NO_VALUE && prop.propertyValueType !== PropertyValueType.CUSTOM_VALUE) {
+					var param = { name: prop.name, matchName: prop.matchName, value: prop.value };
+					if (prop.numKeys > 0) param.keyframes = prop.numKeys;
+					if (prop.expressionEnabled) param.expression = prop.expression;
+					params.push(param);
+				}
+			}
+			return params;
+		}
+
+		var list = [];
+		for (var e = 1; e <= effects.numProperties; e++) {
+			var fx = effects.property(e);
+			var summary = effectSummary(fx);
+			summary.parameters = listParams(fx);
+			list.push(summary);
+		}
+
+		return returnjson({
+			layer: layer.name,
+			layerIndex: layer.index,
+			effects: list
+		});`)
+	if err != nil {
+		return nil, err
+	}
+	return runEffectScript(script)
+}
+
+// ModifyEffect changes parameters, keyframes, the enabled state or the name of an effect
+// already on a layer. The result has a "report" entry per parameter, as from ApplyEffect.
+func ModifyEffect(compName string, layerName string, effect EffectIdentifier, changes EffectChanges) (EffectDetails, error) {
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize effect changes: %w", err)
+	}
+
+	script, err := effectStackScript(compName, layerName, &effect, effectParamsJS+keyframeJS+`
+		var changes = `+string(changesJSON)+`;
+		var report = [];
+		var failedCount = 0;
+
+		if (changes.name) effect.name = changes.name;
+		if (changes.enabled !== undefined) effect.enabled = changes.enabled;
+
+		var parameters = changes.parameters || {};
+		for (var key in parameters) {
+			if (!parameters.hasOwnProperty(key)) continue;
+			var entry = setEffectParam(effect, key, parameters[key], comp);
+			if (entry.status !== "applied") failedCount++;
+			report.push(entry);
+		}
+
+		// Keyframes are validated against the parameter like single values
+		var keyframes = changes.keyframes || {};
+		for (var keyParam in keyframes) {
+			if (!keyframes.hasOwnProperty(keyParam)) continue;
+			var keyEntry = { parameter: keyParam, status: "failed" };
+			var prop = findEffectParam(effect, keyParam);
+			if (!prop) {
+				keyEntry.error = "no such parameter";
+			} else if (prop.propertyType !== PropertyType.PROPERTY || !prop.canVaryOverTime) {
+				keyEntry.name = prop.name;
+				keyEntry.matchName = prop.matchName;
+				keyEntry.error = "cannot be keyframed";
+			} else {
+				keyEntry.name = prop.name;
+				keyEntry.matchName = prop.matchName;
+				var info = describeEffectProperty(prop);
+				var outcome = setPropertyKeyframes(prop, keyframes[keyParam], function (value) {
+					return effectParamValue(info, value, comp);
+				});
+				keyEntry.keyframes = outcome.keyframes;
+				if (outcome.errors.length === 0) {
+					keyEntry.status = "applied";
+				} else {
+					keyEntry.error = outcome.errors.join("; ");
+				}
+			}
+			if (keyEntry.status !== "applied") failedCount++;
+			report.push(keyEntry);
+		}
+
+		var result = effectSummary(effect);
+		result.layer = layer.name;
+		result.report = report;
+		result.failedCount = failedCount;
+		return returnjson(result);`)
+	if err != nil {
+		return nil, err
+	}
+	return runEffectScript(script)
+}
+
+// MoveEffect moves an effect in the layer's effect stack. direction is "up", "down", "top" or
+// "bottom"; when it is empty the effect moves to the 1-based toIndex.
+func MoveEffect(compName string, layerName string, effect EffectIdentifier, direction string, toIndex int) (EffectDetails, error) {
+	switch direction {
+	case "", "up", "down", "top", "bottom":
+	default:
+		return nil, fmt.Errorf("invalid direction: %s. Must be one of: up, down, top, bottom: %w", direction, ErrInvalidParams)
+	}
+	if direction == "" && toIndex <= 0 {
+		return nil, fmt.Errorf("either direction or to_index is required: %w", ErrInvalidParams)
+	}
+
+	script, err := effectStackScript(compName, layerName, &effect, `
+		var direction = "`+direction+`";
+		var fromIndex = effect.propertyIndex;
+		var target = `+fmt.Sprintf("%d", toIndex)+`;
+		if (direction === "up") target = fromIndex - 1;
+		if (direction === "down") target = fromIndex + 1;
+		if (direction === "top") target = 1;
+		if (direction === "bottom") target = effects.numProperties;
+		target = Math.max(1, Math.min(effects.numProperties, target));
+
+		if (target !== fromIndex) effect.moveTo(target);
+		// Moving invalidates references into the stack, so look the effect up again
+		var result = effectSummary(layer.property("ADBE Effect Parade").property(target));
+		result.layer = layer.name;
+		result.previousIndex = fromIndex;
+		return returnjson(result);`)
+	if err != nil {
+		return nil, err
+	}
+	return runEffectScript(script)
+}
+
+// DuplicateEffect duplicates an effect, with its values and keyframes, directly below itself
+func DuplicateEffect(compName string, layerName string, effect EffectIdentifier) (EffectDetails, error) {
+	script, err := effectStackScript(compName, layerName, &effect, `
+		var sourceIndex = effect.propertyIndex;
+		var copy = effect.duplicate();
+		var result = effectSummary(copy);
+		result.layer = layer.name;
+		result.sourceIndex = sourceIndex;
+		return returnjson(result);`)
+	if err != nil {
+		return nil, err
+	}
+	return runEffectScript(script)
+}
+
+// RemoveEffect removes an effect from a layer's effect stack
+func RemoveEffect(compName string, layerName string, effect EffectIdentifier) (EffectDetails, error) {
+	script, err := effectStackScript(compName, layerName, &effect, `
+		var removed = effectSummary(effect);
+		effect.remove();
+		return returnjson({
+			layer: layer.name,
+			removed: removed,
+			remaining: layer.property("ADBE Effect Parade").numProperties
+		});`)
+	if err != nil {
+		return nil, err
+	}
+	return runEffectScript(script)
+}
@@ -10,6 +10,7 @@ var (
 	ErrInvalidResponse = errors.New("invalid response from After Effects")
 	ErrNotFound        = errors.New("item not found in After Effects")
 	ErrInvalidParams   = errors.New("invalid parameters for After Effects operation")
+	ErrFontListUnavailable = errors.New("font enumeration requires After Effects 24.0 or later")
 )
 
 // ErrAEScriptError represents an error that occurred during execution of After Effects script
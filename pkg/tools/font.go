@@ -0,0 +1,358 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+)
+
+// FontInfo describes an installed font
+type FontInfo struct {
+	Family         string `json:"family"`
+	Style          string `json:"style"`
+	PostScriptName string `json:"postScriptName"`
+	FullName       string `json:"fullName,omitempty"`
+}
+
+// FontResolution is the installed font chosen for a requested font name
+type FontResolution struct {
+	PostScriptName string `json:"postScriptName"`
+	Family         string `json:"family,omitempty"`
+	Style          string `json:"style,omitempty"`
+	StyleMatched   bool   `json:"styleMatched"`      // The requested style is provided by the font itself
+	Warning        string `json:"warning,omitempty"` // Set when the font was substituted or fuzzy-matched
+}
+
+// fontCache holds the installed fonts so text tools don't enumerate them on every call
+var fontCache struct {
+	sync.Mutex
+	loaded bool
+	fonts  []FontInfo
+}
+
+// regularStyles are preferred, in order, when only a family is requested
+var regularStyles = []string{"regular", "roman", "book", "normal", "medium"}
+
+// ListFonts returns the installed fonts, optionally filtered by a case-insensitive
+// substring of the family, style, full or PostScript name. Requires After Effects 24.0+.
+func ListFonts(filter string, refresh bool) ([]FontInfo, error) {
+	fonts, err := installedFonts(refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter == "" {
+		return fonts, nil
+	}
+
+	filter = strings.ToLower(filter)
+	var matches []FontInfo
+	for _, font := range fonts {
+		if strings.Contains(strings.ToLower(font.Family+" "+font.Style), filter) ||
+			strings.Contains(strings.ToLower(font.FullName), filter) ||
+			strings.Contains(strings.ToLower(font.PostScriptName), filter) {
+			matches = append(matches, font)
+		}
+	}
+	return matches, nil
+}
+
+// installedFonts enumerates app.fonts, using the cached list unless refresh is set
+func installedFonts(refresh bool) ([]FontInfo, error) {
+	fontCache.Lock()
+	defer fontCache.Unlock()
+
+	if fontCache.loaded && !refresh {
+		return fontCache.fonts, nil
+	}
+
+	script := `
+	try {
+		if (typeof app.fonts === "undefined" || !app.fonts.allFonts) {
+			return JSON.stringify({ available: false });
+		}
+
+		var fonts = [];
+		var addFont = function(font) {
+			fonts.push({
+				family: font.familyName,
+				style: font.styleName,
+				postScriptName: font.postScriptName,
+				fullName: font.fullName
+			});
+		};
+
+		// allFonts groups fonts by family
+		var groups = app.fonts.allFonts;
+		for (var i = 0; i < groups.length; i++) {
+			if (groups[i] instanceof Array) {
+				for (var j = 0; j < groups[i].length; j++) {
+					addFont(groups[i][j]);
+				}
+			} else {
+				addFont(groups[i]);
+			}
+		}
+
+		return returnjson({ available: true, fonts: fonts });
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	resultStr, ok := result.(string)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+		return nil, ErrAEScriptError(resultStr[7:])
+	}
+
+	var response struct {
+		Available bool       `json:"available"`
+		Fonts     []FontInfo `json:"fonts"`
+		Error     string     `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(resultStr), &response); err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%s", response.Error)
+	}
+	if !response.Available {
+		return nil, ErrFontListUnavailable
+	}
+
+	sort.SliceStable(response.Fonts, func(i, j int) bool {
+		if response.Fonts[i].Family != response.Fonts[j].Family {
+			return response.Fonts[i].Family < response.Fonts[j].Family
+		}
+		return response.Fonts[i].Style < response.Fonts[j].Style
+	})
+
+	fontCache.fonts = response.Fonts
+	fontCache.loaded = true
+	return fontCache.fonts, nil
+}
+
+// ResolveFont maps a PostScript name, full name or family (plus optional style) to an
+// installed font's PostScript name. Typos are fuzzy-matched with a warning; a font
+// with no close match is an error. When the After Effects version can't enumerate
+// fonts, the name is passed through unchanged.
+func ResolveFont(name string, style string) (FontResolution, error) {
+	fonts, err := installedFonts(false)
+	if err == ErrFontListUnavailable {
+		return FontResolution{PostScriptName: name, StyleMatched: style == ""}, nil
+	}
+	if err != nil {
+		return FontResolution{}, err
+	}
+	return resolveFontIn(fonts, name, style)
+}
+
+// resolveFontIn resolves a font name against a list of installed fonts
+func resolveFontIn(fonts []FontInfo, name string, style string) (FontResolution, error) {
+	query := normalizeFontName(name)
+	wantStyle := normalizeFontName(style)
+
+	resolved := func(font FontInfo, warning string) FontResolution {
+		return FontResolution{
+			PostScriptName: font.PostScriptName,
+			Family:         font.Family,
+			Style:          font.Style,
+			StyleMatched:   wantStyle == "" || normalizeFontName(font.Style) == wantStyle,
+			Warning:        warning,
+		}
+	}
+
+	// Exact PostScript or full name
+	for _, font := range fonts {
+		if normalizeFontName(font.PostScriptName) == query || normalizeFontName(font.FullName) == query {
+			if wantStyle == "" || normalizeFontName(font.Style) == wantStyle {
+				return resolved(font, ""), nil
+			}
+			// A style was requested too, so look within the font's family
+			return pickFamilyStyle(fonts, font.Family, style, resolved), nil
+		}
+	}
+
+	// Family name, optionally with style
+	for _, font := range fonts {
+		if normalizeFontName(font.Family) == query {
+			return pickFamilyStyle(fonts, font.Family, style, resolved), nil
+		}
+	}
+
+	// Fuzzy match against all names
+	type candidate struct {
+		font     FontInfo
+		distance int
+		family   bool
+	}
+	var candidates []candidate
+	for _, font := range fonts {
+		best := candidate{font: font, distance: -1}
+		for _, key := range []string{font.PostScriptName, font.FullName, font.Family} {
+			if key == "" {
+				continue
+			}
+			d := fontNameDistance(query, normalizeFontName(key))
+			if best.distance < 0 || d < best.distance {
+				best.distance = d
+				best.family = key == font.Family
+			}
+		}
+		if best.distance >= 0 {
+			candidates = append(candidates, best)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	threshold := len(query) / 4
+	if threshold < 2 {
+		threshold = 2
+	}
+	if len(candidates) > 0 && candidates[0].distance <= threshold {
+		best := candidates[0]
+		var match FontResolution
+		if best.family {
+			match = pickFamilyStyle(fonts, best.font.Family, style, resolved)
+		} else {
+			match = resolved(best.font, "")
+		}
+		warning := fmt.Sprintf("Font %q is not installed; using closest match %s (%s %s)", name, match.PostScriptName, match.Family, match.Style)
+		if match.Warning != "" {
+			warning += ". " + match.Warning
+		}
+		match.Warning = warning
+		return match, nil
+	}
+
+	// Suggest the closest distinct names
+	var suggestions []string
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		if len(suggestions) == 3 {
+			break
+		}
+		if !seen[c.font.PostScriptName] {
+			seen[c.font.PostScriptName] = true
+			suggestions = append(suggestions, c.font.PostScriptName)
+		}
+	}
+	if len(suggestions) > 0 {
+		return FontResolution{}, fmt.Errorf("font not installed: %s (did you mean %s?)", name, strings.Join(suggestions, ", "))
+	}
+	return FontResolution{}, fmt.Errorf("font not installed: %s", name)
+}
+
+// pickFamilyStyle chooses a font of the family with the requested style, falling back to its
+// regular style and then to its first font
+func pickFamilyStyle(fonts []FontInfo, family string, style string, resolved func(FontInfo, string) FontResolution) FontResolution {
+	var members []FontInfo
+	for _, font := range fonts {
+		if font.Family == family {
+			members = append(members, font)
+		}
+	}
+
+	wantStyle := normalizeFontName(style)
+	if wantStyle != "" {
+		for _, font := range members {
+			if normalizeFontName(font.Style) == wantStyle {
+				return resolved(font, "")
+			}
+		}
+	}
+
+	fallback := members[0]
+	found := false
+	for _, regular := range regularStyles {
+		for _, font := range members {
+			if normalizeFontName(font.Style) == regular {
+				fallback = font
+				found = true
+				break
+			}
+		}
+		if found {
+			break
+		}
+	}
+
+	if wantStyle != "" {
+		return resolved(fallback, fmt.Sprintf("Style %q is not available for %s; using %s", style, family, fallback.Style))
+	}
+	return resolved(fallback, "")
+}
+
+// normalizeFontName lowercases a font name and drops spaces and punctuation
+func normalizeFontName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r > 0x7f {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// fontNameDistance is the edit distance between two names, counting adjacent
+// transpositions as a single edit
+func fontNameDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+// minInt returns the smaller of two ints
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// fontSubstitutionCheckJS warns when After Effects substituted a different font for
+// the one assigned to the text document. A family name resolving to one of its own
+// fonts (Arial to ArialMT) is not a substitution.
+func fontSubstitutionCheckJS(requested string) string {
+	return `
+		var appliedFont = textLayer.property("Source Text").value.font;
+		var requestedKey = "` + normalizeFontName(requested) + `";
+		if (appliedFont.toLowerCase().replace(/[^a-z0-9\u0080-\uffff]/g, "").indexOf(requestedKey) !== 0) {
+			warnings.push("Font ` + escapeJSString(requested) + ` is not available; After Effects substituted " + appliedFont);
+		}`
+}
@@ -0,0 +1,51 @@
+package tools
+
+// PropertyKeyframe is a keyframe on a layer property, used for transform and effect
+// parameter keyframes alike
+type PropertyKeyframe struct {
+	Time  float64     `json:"time"`           // Composition time in seconds
+	Value interface{} `json:"value"`          // Number or array, in the property's own units
+	Ease  bool        `json:"ease,omitempty"` // Ease in and out of this keyframe
+	Hold  bool        `json:"hold,omitempty"` // Hold the value until the next keyframe
+}
+
+// keyframeJS defines setPropertyKeyframes(prop, keyframes, convert), which adds
+// PropertyKeyframes to a property. convert, when given, maps each value before it is set and
+// returns { value } or { error }. It returns the number of keyframes set and any errors.
+const keyframeJS = `
+		function setPropertyKeyframes(prop, keyframes, convert) {
+			var outcome = { keyframes: 0, errors: [] };
+			var dimensions = 1;
+			if (prop.propertyValueType === PropertyValueType.TwoD) dimensions = 2;
+			if (prop.propertyValueType === PropertyValueType.ThreeD) dimensions = 3;
+
+			for (var k = 0; k < keyframes.length; k++) {
+				var key = keyframes[k];
+				var value = key.value;
+				if (convert) {
+					var converted = convert(value);
+					if (converted.error) {
+						outcome.errors.push("keyframe at " + key.time + "s " + converted.error);
+						continue;
+					}
+					value = converted.value;
+				}
+				try {
+					var keyIndex = prop.addKey(key.time);
+					prop.setValueAtKey(keyIndex, value);
+					if (key.ease) {
+						var eases = [];
+						for (var d = 0; d < dimensions; d++) eases.push(new KeyframeEase(0, 33.333));
+						prop.setTemporalEaseAtKey(keyIndex, eases, eases);
+					}
+					if (key.hold) {
+						prop.setInterpolationTypeAtKey(keyIndex, KeyframeInterpolationType.HOLD);
+					}
+					outcome.keyframes++;
+				} catch (keyErr) {
+					outcome.errors.push("keyframe at " + key.time + "s: " + keyErr.toString());
+				}
+			}
+			return outcome;
+		}
+`
@@ -50,12 +50,17 @@ const (
 	
 	// Extrusion
 	ExtrusionOptions = "ADBE Extrsn Options Group"
+	BevelStyle       = "ADBE Bevel Styles"
 	BevelDepth       = "ADBE Bevel Depth"
 	HoleBevelDepth   = "ADBE Hole Bevel Depth"
 	ExtrusionDepth   = "ADBE Extrsn Depth"
 	
 	// Material
 	MaterialOptions    = "ADBE Material Options Group"
+	CastsShadows       = "ADBE Casts Shadows" // Also the Casts Shadows option of lights
+	AcceptsShadows     = "ADBE Accepts Shadows"
+	AcceptsLights      = "ADBE Accepts Lights"
+	AppearsInReflections = "ADBE Appears in Reflections"
 	LightTransmission  = "ADBE Light Transmission"
 	AmbientCoefficient = "ADBE Ambient Coefficient"
 	DiffuseCoefficient = "ADBE Diffuse Coefficient"
@@ -103,7 +108,7 @@ const (
 	FalloffStart     = "ADBE Light Falloff Start"
 	FalloffDistance  = "ADBE Light Falloff Distance"
 	
-	// Shadow
+	// Shadow (Casts Shadows is CastsShadows, shared with the material options)
 	ShadowDarkness   = "ADBE Light Shadow Darkness"
 	ShadowDiffusion  = "ADBE Light Shadow Diffusion"
 )
@@ -119,11 +124,22 @@ const (
 
 // Shape Layer specific match names
 const (
-	ShapeContents = "ADBE Root Vectors Group"
-	ShapeGroup    = "ADBE Vector Group"
-	ShapePath     = "ADBE Vector Shape"
-	ShapeFill     = "ADBE Vector Graphic - Fill"
-	ShapeStroke   = "ADBE Vector Graphic - Stroke"
+	ShapeContents       = "ADBE Root Vectors Group"
+	ShapeGroup          = "ADBE Vector Group"
+	ShapePath           = "ADBE Vector Shape"
+	ShapeFill           = "ADBE Vector Graphic - Fill"
+	ShapeStroke         = "ADBE Vector Graphic - Stroke"
+	ShapeGradientFill   = "ADBE Vector Graphic - G-Fill"
+	ShapeGradientStroke = "ADBE Vector Graphic - G-Stroke"
+
+	// Path operators
+	ShapeTrimPaths    = "ADBE Vector Filter - Trim"
+	ShapeRepeater     = "ADBE Vector Filter - Repeater"
+	ShapeOffsetPaths  = "ADBE Vector Filter - Offset"
+	ShapeRoundCorners = "ADBE Vector Filter - RC"
+	ShapeZigZag       = "ADBE Vector Filter - Zigzag"
+	ShapeTwist        = "ADBE Vector Filter - Twist"
+	ShapeWigglePaths  = "ADBE Vector Filter - Roughen"
 )
 
 // LayerInfo represents information about a layer in After Effects
@@ -292,8 +308,7 @@ func ModifyLayer(compositionName string, layerIdentifier LayerIdentifier, proper
 			});
 		}
 		
-		` + layerIdentifierJS + `
-		
+		` + layerIdentifierJS + keyframeJS + `
 		if (!targetLayer) {
 			return JSON.stringify({
 				error: "Layer not found"
@@ -360,6 +375,26 @@ func ModifyLayer(compositionName string, layerIdentifier LayerIdentifier, proper
 			result.modified.threeDLayer = props.threeDLayer;
 		}
 		
+		// Keyframes, as {property: [{time, value, ease, hold}]}
+		if (props.keyframes) {
+			var keyframeProps = {
+				anchorPoint: targetLayer.transform.anchorPoint,
+				position: targetLayer.transform.position,
+				scale: targetLayer.transform.scale,
+				rotation: targetLayer.transform.rotation,
+				opacity: targetLayer.transform.opacity
+			};
+			result.modified.keyframes = {};
+			for (var keyProp in props.keyframes) {
+				if (!keyframeProps[keyProp]) {
+					return JSON.stringify({
+						error: "Cannot keyframe property: " + keyProp + ". Must be one of: anchorPoint, position, scale, rotation, opacity"
+					});
+				}
+				result.modified.keyframes[keyProp] = setPropertyKeyframes(keyframeProps[keyProp], props.keyframes[keyProp]);
+			}
+		}
+		
 		return returnjson(result);
 	} catch (err) {
 		return "ERROR: " + err.toString();
@@ -3,6 +3,7 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"github.com/sunqirui1987/ae-mcp/pkg/ae"
 )
 
@@ -134,3 +135,352 @@ func AddLightLayer(compositionName, layerName, lightType string, color [3]float6
 	return nil, fmt.Errorf("unexpected script response format")
 }
 
+// Light falloff types, the values of the Falloff property
+var lightFalloffTypes = map[string]int{
+	"none":                   1,
+	"smooth":                 2,
+	"inverse square clamped": 3,
+}
+
+// lightInfoJS defines lightInfo(lightLayer), which describes a light layer and its light
+// options, and lightOption(lightLayer, matchName), which returns a light option property.
+// Options that don't apply to the light type, such as the cone of a point light, are null.
+const lightInfoJS = `
+		// Light types each option applies to; After Effects hides the others
+		var lightOptionTypes = {
+			"` + ConeAngle + `": [LightType.SPOT],
+			"` + ConeFeather + `": [LightType.SPOT],
+			"` + FalloffType + `": [LightType.SPOT, LightType.POINT],
+			"` + FalloffStart + `": [LightType.SPOT, LightType.POINT],
+			"` + FalloffDistance + `": [LightType.SPOT, LightType.POINT],
+			"` + CastsShadows + `": [LightType.PARALLEL, LightType.SPOT, LightType.POINT],
+			"` + ShadowDarkness + `": [LightType.PARALLEL, LightType.SPOT, LightType.POINT],
+			"` + ShadowDiffusion + `": [LightType.PARALLEL, LightType.SPOT, LightType.POINT]
+		};
+
+		function lightOption(lightLayer, matchName) {
+			var types = lightOptionTypes[matchName];
+			if (types) {
+				var applies = false;
+				for (var t = 0; t < types.length; t++) {
+					if (types[t] === lightLayer.lightType) applies = true;
+				}
+				if (!applies) return null;
+			}
+			return lightLayer.property("` + LightOptions + `").property(matchName);
+		}
+
+		function lightOptionValue(lightLayer, matchName) {
+			var prop = lightOption(lightLayer, matchName);
+			return prop ? prop.value : null;
+		}
+
+		function lightTypeName(lightLayer) {
+			switch (lightLayer.lightType) {
+				case LightType.PARALLEL: return "Parallel";
+				case LightType.SPOT: return "Spot";
+				case LightType.POINT: return "Point";
+				case LightType.AMBIENT: return "Ambient";
+			}
+			return "Unknown";
+		}
+
+		// Only parallel and spot lights have a direction, and so a point of interest
+		function lightAims(lightLayer) {
+			return lightLayer.lightType === LightType.PARALLEL || lightLayer.lightType === LightType.SPOT;
+		}
+
+		function lightInfo(lightLayer) {
+			var falloffNames = { 1: "None", 2: "Smooth", 3: "Inverse Square Clamped" };
+			var falloff = lightOptionValue(lightLayer, "` + FalloffType + `");
+			var poi = null;
+			var poiLayer = null;
+			if (lightAims(lightLayer)) {
+				try {
+					poi = lightLayer.transform.pointOfInterest.value;
+					if (lightLayer.transform.pointOfInterest.expressionEnabled) {
+						var poiMatch = /thisComp\.layer\("((?:[^"\\]|\\.)*)"\)/.exec(lightLayer.transform.pointOfInterest.expression);
+						if (poiMatch) poiLayer = poiMatch[1];
+					}
+				} catch (e) {}
+			}
+			var hasPosition = lightLayer.lightType !== LightType.AMBIENT;
+
+			return {
+				index: lightLayer.index,
+				name: lightLayer.name,
+				type: "Light",
+				lightType: lightTypeName(lightLayer),
+				enabled: lightLayer.enabled,
+				threeDLayer: true,
+				autoOrient: lightLayer.autoOrient === AutoOrientType.CAMERA_OR_POINT_OF_INTEREST,
+				transform: {
+					position: hasPosition ? lightLayer.transform.position.value : null,
+					pointOfInterest: poi,
+					pointOfInterestLayer: poiLayer,
+					orientation: hasPosition ? lightLayer.transform.orientation.value : null
+				},
+				lightOptions: {
+					intensity: lightOptionValue(lightLayer, "` + LightIntensity + `"),
+					color: lightOptionValue(lightLayer, "` + LightColor + `"),
+					coneAngle: lightOptionValue(lightLayer, "` + ConeAngle + `"),
+					coneFeather: lightOptionValue(lightLayer, "` + ConeFeather + `"),
+					falloff: falloff === null ? null : (falloffNames[falloff] || falloff),
+					radius: lightOptionValue(lightLayer, "` + FalloffStart + `"),
+					falloffDistance: lightOptionValue(lightLayer, "` + FalloffDistance + `"),
+					castsShadows: lightOptionValue(lightLayer, "` + CastsShadows + `") === 1,
+					shadowDarkness: lightOptionValue(lightLayer, "` + ShadowDarkness + `"),
+					shadowDiffusion: lightOptionValue(lightLayer, "` + ShadowDiffusion + `")
+				}
+			};
+		}
+`
+
+// lightLayerScript wraps body in a script that finds a light layer by name as lightLayer in
+// comp, with lightInfo defined
+func lightLayerScript(compositionName, layerName, body string) string {
+	return `
+	try {
+		` + lightInfoJS + `
+
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			if (app.project.item(i) instanceof CompItem && app.project.item(i).name === "` + escapeJSString(compositionName) + `") {
+				comp = app.project.item(i);
+				break;
+			}
+		}
+
+		if (!comp) {
+			return returnjson({
+				error: "Composition not found: " + "` + escapeJSString(compositionName) + `"
+			});
+		}
+
+		// Find the light layer
+		var lightLayer = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === "` + escapeJSString(layerName) + `") {
+				lightLayer = comp.layer(i);
+				break;
+			}
+		}
+
+		if (!lightLayer) {
+			return returnjson({
+				error: "Light layer not found: " + "` + escapeJSString(layerName) + `"
+			});
+		}
+
+		// Check if it's actually a light layer
+		if (!(lightLayer instanceof LightLayer)) {
+			return returnjson({
+				error: "Layer is not a light layer: " + "` + escapeJSString(layerName) + `"
+			});
+		}
+		` + body + `
+	} catch (e) {
+		return returnjson({ error: "Error accessing light layer: " + e.toString() });
+	}
+	`
+}
+
+// runLightScript executes a light layer script and returns its "light" result, along with
+// any "skipped" options
+func runLightScript(script string) (map[string]interface{}, error) {
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, fmt.Errorf("error executing script: %v", err)
+	}
+
+	var response map[string]interface{}
+	if resultStr, ok := result.(string); ok {
+		if err := json.Unmarshal([]byte(resultStr), &response); err != nil {
+			return nil, fmt.Errorf("error parsing script response: %v", err)
+		}
+	} else {
+		return nil, fmt.Errorf("unexpected script response type")
+	}
+
+	if errMsg, ok := response["error"].(string); ok {
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	light, ok := response["light"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected script response format")
+	}
+	if skipped, ok := response["skipped"].([]interface{}); ok && len(skipped) > 0 {
+		light["skipped"] = skipped
+	}
+	return light, nil
+}
+
+// ModifyLightProperties modifies properties of an existing light layer. Supported options:
+//   - lightType: "Parallel", "Spot", "Point" or "Ambient", applied before the other options
+//   - intensity (percent), color ([r, g, b] 0-1)
+//   - coneAngle, coneFeather: spot lights only
+//   - falloff ("None", "Smooth" or "Inverse Square Clamped"), radius, falloffDistance
+//   - castsShadows (bool), shadowDarkness, shadowDiffusion
+//   - position, pointOfInterest ([x, y, z]), orientation
+//   - pointOfInterestLayer: name of a layer the light aims at through an expression, or "" to
+//     remove the link
+//   - autoOrient: false makes the light ignore its point of interest
+//
+// Options that don't apply to the light's type are listed under "skipped" in the result.
+func ModifyLightProperties(compositionName, layerName string, options map[string]interface{}) (map[string]interface{}, error) {
+	if compositionName == "" {
+		return nil, fmt.Errorf("composition name is required")
+	}
+	if layerName == "" {
+		return nil, fmt.Errorf("layer name is required")
+	}
+	if len(options) == 0 {
+		return nil, fmt.Errorf("light options are required")
+	}
+
+	// Normalize the light type and falloff names to the values the script expects
+	normalized := make(map[string]interface{}, len(options))
+	for key, value := range options {
+		normalized[key] = value
+	}
+	if lightType, ok := normalized["lightType"]; ok {
+		name, _ := lightType.(string)
+		name = strings.ToUpper(name)
+		if name != "PARALLEL" && name != "SPOT" && name != "POINT" && name != "AMBIENT" {
+			return nil, fmt.Errorf("invalid light type: %v. Valid types are 'Parallel', 'Spot', 'Point', or 'Ambient'", lightType)
+		}
+		normalized["lightType"] = name
+	}
+	if falloff, ok := normalized["falloff"]; ok {
+		switch v := falloff.(type) {
+		case string:
+			value, ok := lightFalloffTypes[strings.ToLower(v)]
+			if !ok {
+				return nil, fmt.Errorf("invalid falloff: %s. Valid falloffs are 'None', 'Smooth', or 'Inverse Square Clamped'", v)
+			}
+			normalized["falloff"] = value
+		case float64:
+			if v < 1 || v > 3 {
+				return nil, fmt.Errorf("invalid falloff: %v. Valid values are 1 (None), 2 (Smooth) and 3 (Inverse Square Clamped)", v)
+			}
+		default:
+			return nil, fmt.Errorf("invalid falloff: %v", falloff)
+		}
+	}
+	if castsShadows, ok := normalized["castsShadows"].(bool); ok {
+		if castsShadows {
+			normalized["castsShadows"] = 1
+		} else {
+			normalized["castsShadows"] = 0
+		}
+	}
+
+	optionsJSON, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding light options: %v", err)
+	}
+
+	script := lightLayerScript(compositionName, layerName, `
+		var options = `+string(optionsJSON)+`;
+		var skipped = [];
+
+		function setLightOption(key, matchName) {
+			if (options[key] === undefined) return;
+			var prop = lightOption(lightLayer, matchName);
+			if (!prop) {
+				skipped.push(key + ": not available on " + lightTypeName(lightLayer) + " lights");
+				return;
+			}
+			try {
+				prop.setValue(options[key]);
+			} catch (e) {
+				skipped.push(key + ": " + e.toString());
+			}
+		}
+
+		function setTransform(key, prop) {
+			if (options[key] === undefined) return;
+			if (!prop) {
+				skipped.push(key + ": not available on " + lightTypeName(lightLayer) + " lights");
+				return;
+			}
+			try {
+				prop.setValue(options[key]);
+			} catch (e) {
+				skipped.push(key + ": " + e.toString());
+			}
+		}
+
+		// Change the type first, since it decides which options exist
+		if (options.lightType) {
+			lightLayer.lightType = LightType[options.lightType];
+		}
+
+		setLightOption("intensity", "`+LightIntensity+`");
+		if (options.color) {
+			options.color = [options.color[0], options.color[1], options.color[2], 1];
+		}
+		setLightOption("color", "`+LightColor+`");
+		setLightOption("coneAngle", "`+ConeAngle+`");
+		setLightOption("coneFeather", "`+ConeFeather+`");
+		setLightOption("falloff", "`+FalloffType+`");
+		setLightOption("radius", "`+FalloffStart+`");
+		setLightOption("falloffDistance", "`+FalloffDistance+`");
+		setLightOption("castsShadows", "`+CastsShadows+`");
+		setLightOption("shadowDarkness", "`+ShadowDarkness+`");
+		setLightOption("shadowDiffusion", "`+ShadowDiffusion+`");
+
+		var hasPosition = lightLayer.lightType !== LightType.AMBIENT;
+		setTransform("position", hasPosition ? lightLayer.transform.position : null);
+		setTransform("orientation", hasPosition ? lightLayer.transform.orientation : null);
+
+		if (options.autoOrient !== undefined) {
+			lightLayer.autoOrient = options.autoOrient ? AutoOrientType.CAMERA_OR_POINT_OF_INTEREST : AutoOrientType.NO_AUTO_ORIENT;
+		}
+
+		var poiProp = lightAims(lightLayer) ? lightLayer.transform.pointOfInterest : null;
+		if (options.pointOfInterestLayer !== undefined) {
+			if (!poiProp) {
+				skipped.push("pointOfInterestLayer: not available on " + lightTypeName(lightLayer) + " lights");
+			} else if (options.pointOfInterestLayer === "") {
+				poiProp.expression = "";
+			} else {
+				var target = null;
+				for (var t = 1; t <= comp.numLayers; t++) {
+					if (comp.layer(t).name === options.pointOfInterestLayer) {
+						target = comp.layer(t);
+						break;
+					}
+				}
+				if (!target) {
+					skipped.push("pointOfInterestLayer: layer not found: " + options.pointOfInterestLayer);
+				} else {
+					var ref = 'thisComp.layer("' + target.name.replace(/\\/g, "\\\\").replace(/"/g, '\\"') + '")';
+					poiProp.expression = ref + ".toWorld(" + ref + ".anchorPoint)";
+					lightLayer.autoOrient = AutoOrientType.CAMERA_OR_POINT_OF_INTEREST;
+				}
+			}
+		}
+		setTransform("pointOfInterest", poiProp);
+
+		return returnjson({ success: true, light: lightInfo(lightLayer), skipped: skipped });`)
+
+	return runLightScript(script)
+}
+
+// GetLightLayerInfo retrieves information about a light layer in a composition
+func GetLightLayerInfo(compositionName, layerName string) (map[string]interface{}, error) {
+	if compositionName == "" {
+		return nil, fmt.Errorf("composition name is required")
+	}
+	if layerName == "" {
+		return nil, fmt.Errorf("layer name is required")
+	}
+
+	script := lightLayerScript(compositionName, layerName, `
+		return returnjson({ success: true, light: lightInfo(lightLayer) });`)
+
+	return runLightScript(script)
+}
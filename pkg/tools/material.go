@@ -0,0 +1,350 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+)
+
+// LayerMaterial holds the Material Options of a 3D layer. Empty and nil fields are left
+// unchanged. Percentages are 0-100.
+type LayerMaterial struct {
+	CastsShadows         string   `json:"castsShadows,omitempty"`         // "on", "off" or "only"
+	AcceptsShadows       string   `json:"acceptsShadows,omitempty"`       // "on", "off" or "only"
+	AcceptsLights        *bool    `json:"acceptsLights,omitempty"`        // Whether lights illuminate the layer
+	AppearsInReflections string   `json:"appearsInReflections,omitempty"` // "on", "off" or "only"
+	LightTransmission    *float64 `json:"lightTransmission,omitempty"`
+	Ambient              *float64 `json:"ambient,omitempty"`
+	Diffuse              *float64 `json:"diffuse,omitempty"`
+	SpecularIntensity    *float64 `json:"specularIntensity,omitempty"`
+	SpecularShininess    *float64 `json:"specularShininess,omitempty"`
+	Metal                *float64 `json:"metal,omitempty"`
+	ReflectionIntensity  *float64 `json:"reflectionIntensity,omitempty"`
+	ReflectionSharpness  *float64 `json:"reflectionSharpness,omitempty"`
+	ReflectionRolloff    *float64 `json:"reflectionRolloff,omitempty"`
+	Transparency         *float64 `json:"transparency,omitempty"`
+	TransparencyRolloff  *float64 `json:"transparencyRolloff,omitempty"`
+	IndexOfRefraction    *float64 `json:"indexOfRefraction,omitempty"` // 1.0-3.0, e.g. 1.5 for glass
+}
+
+// LayerExtrusion holds the Geometry Options of an extruded text or shape layer. Empty and nil
+// fields are left unchanged. Depths are in pixels.
+type LayerExtrusion struct {
+	BevelStyle     string   `json:"bevelStyle,omitempty"` // "none", "angular", "concave" or "convex"
+	BevelDepth     *float64 `json:"bevelDepth,omitempty"`
+	HoleBevelDepth *float64 `json:"holeBevelDepth,omitempty"` // Percent of the bevel depth
+	ExtrusionDepth *float64 `json:"extrusionDepth,omitempty"`
+}
+
+// materialSwitchValues are the menu values of the casts shadows, accepts shadows and appears
+// in reflections options
+var materialSwitchValues = map[string]int{"off": 0, "on": 1, "only": 2}
+
+// bevelStyles are the menu values of the bevel style option
+var bevelStyles = map[string]int{"none": 1, "angular": 2, "concave": 3, "convex": 4}
+
+// layerOptionSetting is one property of a layer's material or geometry options to set
+type layerOptionSetting struct {
+	Key       string      `json:"key"`
+	Group     string      `json:"group"`
+	MatchName string      `json:"matchName"`
+	Value     interface{} `json:"value"`
+}
+
+// ParseLayerMaterial converts MCP material arguments, keyed like the LayerMaterial JSON fields
+func ParseLayerMaterial(args map[string]interface{}) (LayerMaterial, error) {
+	var material LayerMaterial
+	data, err := json.Marshal(args)
+	if err != nil {
+		return material, fmt.Errorf("invalid material options: %w", ErrInvalidParams)
+	}
+	if err := json.Unmarshal(data, &material); err != nil {
+		return material, fmt.Errorf("invalid material options: %v: %w", err, ErrInvalidParams)
+	}
+	return material, nil
+}
+
+// ParseLayerExtrusion converts MCP extrusion arguments, keyed like the LayerExtrusion JSON
+// fields
+func ParseLayerExtrusion(args map[string]interface{}) (LayerExtrusion, error) {
+	var extrusion LayerExtrusion
+	data, err := json.Marshal(args)
+	if err != nil {
+		return extrusion, fmt.Errorf("invalid extrusion options: %w", ErrInvalidParams)
+	}
+	if err := json.Unmarshal(data, &extrusion); err != nil {
+		return extrusion, fmt.Errorf("invalid extrusion options: %v: %w", err, ErrInvalidParams)
+	}
+	return extrusion, nil
+}
+
+// settings lists the material properties to set
+func (m LayerMaterial) settings() ([]layerOptionSetting, error) {
+	var settings []layerOptionSetting
+	addSwitch := func(key string, matchName string, value string) error {
+		if value == "" {
+			return nil
+		}
+		v, ok := materialSwitchValues[strings.ToLower(value)]
+		if !ok {
+			return fmt.Errorf("invalid %s: %s. Must be on, off or only: %w", key, value, ErrInvalidParams)
+		}
+		settings = append(settings, layerOptionSetting{key, MaterialOptions, matchName, v})
+		return nil
+	}
+	addNumber := func(key string, matchName string, value *float64) {
+		if value != nil {
+			settings = append(settings, layerOptionSetting{key, MaterialOptions, matchName, *value})
+		}
+	}
+
+	if err := addSwitch("castsShadows", CastsShadows, m.CastsShadows); err != nil {
+		return nil, err
+	}
+	if err := addSwitch("acceptsShadows", AcceptsShadows, m.AcceptsShadows); err != nil {
+		return nil, err
+	}
+	if m.AcceptsLights != nil {
+		value := 0
+		if *m.AcceptsLights {
+			value = 1
+		}
+		settings = append(settings, layerOptionSetting{"acceptsLights", MaterialOptions, AcceptsLights, value})
+	}
+	if err := addSwitch("appearsInReflections", AppearsInReflections, m.AppearsInReflections); err != nil {
+		return nil, err
+	}
+	addNumber("lightTransmission", LightTransmission, m.LightTransmission)
+	addNumber("ambient", AmbientCoefficient, m.Ambient)
+	addNumber("diffuse", DiffuseCoefficient, m.Diffuse)
+	addNumber("specularIntensity", SpecularIntensity, m.SpecularIntensity)
+	addNumber("specularShininess", SpecularShininess, m.SpecularShininess)
+	addNumber("metal", Metal, m.Metal)
+	addNumber("reflectionIntensity", ReflectionIntensity, m.ReflectionIntensity)
+	addNumber("reflectionSharpness", ReflectionSharpness, m.ReflectionSharpness)
+	addNumber("reflectionRolloff", ReflectionRolloff, m.ReflectionRolloff)
+	addNumber("transparency", Transparency, m.Transparency)
+	addNumber("transparencyRolloff", TransparencyRolloff, m.TransparencyRolloff)
+	if m.IndexOfRefraction != nil && (*m.IndexOfRefraction < 1 || *m.IndexOfRefraction > 3) {
+		return nil, fmt.Errorf("index of refraction must be between 1 and 3: %w", ErrInvalidParams)
+	}
+	addNumber("indexOfRefraction", IndexOfRefraction, m.IndexOfRefraction)
+	return settings, nil
+}
+
+// settings lists the geometry properties to set
+func (e LayerExtrusion) settings() ([]layerOptionSetting, error) {
+	var settings []layerOptionSetting
+	if e.BevelStyle != "" {
+		v, ok := bevelStyles[strings.ToLower(e.BevelStyle)]
+		if !ok {
+			return nil, fmt.Errorf("invalid bevel style: %s. Must be none, angular, concave or convex: %w", e.BevelStyle, ErrInvalidParams)
+		}
+		settings = append(settings, layerOptionSetting{"bevelStyle", ExtrusionOptions, BevelStyle, v})
+	}
+	for _, depth := range []struct {
+		key       string
+		matchName string
+		value     *float64
+	}{
+		{"bevelDepth", BevelDepth, e.BevelDepth},
+		{"holeBevelDepth", HoleBevelDepth, e.HoleBevelDepth},
+		{"extrusionDepth", ExtrusionDepth, e.ExtrusionDepth},
+	} {
+		if depth.value == nil {
+			continue
+		}
+		if *depth.value < 0 {
+			return nil, fmt.Errorf("%s can't be negative: %w", depth.key, ErrInvalidParams)
+		}
+		settings = append(settings, layerOptionSetting{depth.key, ExtrusionOptions, depth.matchName, *depth.value})
+	}
+	return settings, nil
+}
+
+// SetLayerMaterial sets the Material Options of a layer, making it 3D first if needed. The
+// result has a "report" entry per option with status "applied" or "failed", and warns when
+// an option has no effect with the composition's renderer.
+func SetLayerMaterial(compositionName string, layerIdentifier LayerIdentifier, material LayerMaterial) (LayerInfo, error) {
+	settings, err := material.settings()
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, fmt.Errorf("no material options given: %w", ErrInvalidParams)
+	}
+	return setLayer3DOptions(compositionName, layerIdentifier, settings, false)
+}
+
+// SetLayerExtrusion sets the extrusion depth and bevel of a text or shape layer, making it 3D
+// first if needed. Extrusion only renders with the Cinema 4D and Advanced 3D renderers, which
+// can be chosen with ModifyComposition or when creating the composition.
+func SetLayerExtrusion(compositionName string, layerIdentifier LayerIdentifier, extrusion LayerExtrusion) (LayerInfo, error) {
+	settings, err := extrusion.settings()
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, fmt.Errorf("no extrusion options given: %w", ErrInvalidParams)
+	}
+	return setLayer3DOptions(compositionName, layerIdentifier, settings, true)
+}
+
+// setLayer3DOptions sets material or geometry option properties on a layer and reports each
+// one. extrudable limits the layer to text and shape layers.
+func setLayer3DOptions(compositionName string, layerIdentifier LayerIdentifier, settings []layerOptionSetting, extrudable bool) (LayerInfo, error) {
+	var layerIdentifierJS string
+	if layerIdentifier.Name != "" {
+		layerIdentifierJS = `
+		// Find layer by name
+		var targetLayer = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === "` + escapeJSString(layerIdentifier.Name) + `") {
+				targetLayer = comp.layer(i);
+				break;
+			}
+		}
+		`
+	} else if layerIdentifier.Index > 0 {
+		layerIdentifierJS = fmt.Sprintf(`
+		// Get layer by index
+		var targetLayer = comp.layer(%d);
+		`, layerIdentifier.Index)
+	} else {
+		return nil, fmt.Errorf("layer_identifier must have either name or index field: %w", ErrInvalidParams)
+	}
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize layer options: %w", err)
+	}
+
+	script := `
+	try {
+		var compName = "` + escapeJSString(compositionName) + `";
+		var settings = ` + string(settingsJSON) + `;
+		var extrudable = ` + fmt.Sprintf("%t", extrudable) + `;
+
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+
+		` + layerIdentifierJS + `
+
+		if (!targetLayer) {
+			return JSON.stringify({
+				error: "Layer not found"
+			});
+		}
+
+		if (extrudable && !(targetLayer instanceof TextLayer || targetLayer instanceof ShapeLayer)) {
+			return JSON.stringify({
+				error: "Only text and shape layers can be extruded: " + targetLayer.name
+			});
+		}
+		if (!(targetLayer instanceof AVLayer)) {
+			return JSON.stringify({
+				error: "Layer has no material options: " + targetLayer.name
+			});
+		}
+
+		var madeThreeD = false;
+		if (!targetLayer.threeDLayer) {
+			targetLayer.threeDLayer = true;
+			madeThreeD = true;
+		}
+
+		var report = [];
+		var failedCount = 0;
+		for (var s = 0; s < settings.length; s++) {
+			var setting = settings[s];
+			var entry = { option: setting.key, value: setting.value, status: "applied" };
+			try {
+				var group = targetLayer.property(setting.group);
+				var prop = group ? group.property(setting.matchName) : null;
+				if (!prop) {
+					throw new Error("not available on this layer");
+				}
+				prop.setValue(setting.value);
+				entry.value = prop.value;
+			} catch (setErr) {
+				entry.status = "failed";
+				entry.error = setErr.toString();
+				failedCount++;
+			}
+			report.push(entry);
+		}
+
+		return returnjson({
+			layer: targetLayer.name,
+			index: targetLayer.index,
+			madeThreeD: madeThreeD,
+			renderer: comp.renderer,
+			report: report,
+			failedCount: failedCount
+		});
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	resultStr, ok := result.(string)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+		return nil, ErrAEScriptError(resultStr[7:])
+	}
+
+	var info LayerInfo
+	if err := json.Unmarshal([]byte(resultStr), &info); err != nil {
+		return nil, err
+	}
+	if errMsg, hasErr := info["error"].(string); hasErr {
+		return nil, fmt.Errorf("%s", errMsg)
+	}
+
+	if warning := renderer3DWarning(info["renderer"], settings); warning != "" {
+		info["warnings"] = []string{warning}
+	}
+	return info, nil
+}
+
+// renderer3DWarning explains options that the Classic 3D renderer ignores, or returns ""
+func renderer3DWarning(renderer interface{}, settings []layerOptionSetting) string {
+	if renderer != CompositionRenderers["Classic 3D"] {
+		return ""
+	}
+	var ignored []string
+	for _, setting := range settings {
+		switch setting.MatchName {
+		case BevelStyle, BevelDepth, HoleBevelDepth, ExtrusionDepth,
+			ReflectionIntensity, ReflectionSharpness, ReflectionRolloff,
+			Transparency, TransparencyRolloff, IndexOfRefraction, AppearsInReflections:
+			ignored = append(ignored, setting.Key)
+		}
+	}
+	if len(ignored) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("The composition uses the Classic 3D renderer, which ignores %s; switch to Cinema 4D or Advanced 3D to see them", strings.Join(ignored, ", "))
+}
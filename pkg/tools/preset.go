@@ -0,0 +1,263 @@
+package tools
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PresetInfo is an animation preset (.ffx) found by the preset indexer
+type PresetInfo struct {
+	Name     string `json:"name"`
+	Category string `json:"category"` // Folder path below the preset directory, e.g. "Text/Animate In"
+	Path     string `json:"path"`
+}
+
+// presetIndex is the in-memory preset catalog, built on first use
+var presetIndex struct {
+	sync.Mutex
+	built   bool
+	presets []PresetInfo
+}
+
+// PresetDirs returns the directories scanned for .ffx presets: the directories in the
+// AE_MCP_PRESET_DIRS environment variable (separated like PATH), followed by the Presets and
+// User Presets folders of every installed After Effects version
+func PresetDirs() []string {
+	var dirs []string
+	if env := os.Getenv("AE_MCP_PRESET_DIRS"); env != "" {
+		dirs = append(dirs, filepath.SplitList(env)...)
+	}
+
+	var patterns []string
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "darwin":
+		patterns = []string{
+			"/Applications/Adobe After Effects */Presets",
+			filepath.Join(home, "Documents", "Adobe", "After Effects *", "User Presets"),
+		}
+	case "windows":
+		programFiles := os.Getenv("ProgramFiles")
+		if programFiles == "" {
+			programFiles = `C:\Program Files`
+		}
+		patterns = []string{
+			filepath.Join(programFiles, "Adobe", "Adobe After Effects *", "Support Files", "Presets"),
+			filepath.Join(home, "Documents", "Adobe", "After Effects *", "User Presets"),
+		}
+	}
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(pattern)
+		sort.Strings(matches)
+		dirs = append(dirs, matches...)
+	}
+	return dirs
+}
+
+// IndexPresets scans directories for .ffx files and replaces the preset catalog. When dirs is
+// empty, PresetDirs is used. Directories that don't exist are skipped.
+func IndexPresets(dirs []string) ([]PresetInfo, error) {
+	if len(dirs) == 0 {
+		dirs = PresetDirs()
+	}
+
+	var presets []PresetInfo
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				// Skip unreadable folders rather than failing the whole index
+				return nil
+			}
+			if entry.IsDir() || !strings.EqualFold(filepath.Ext(path), ".ffx") {
+				return nil
+			}
+			category := ""
+			if rel, err := filepath.Rel(dir, filepath.Dir(path)); err == nil && rel != "." {
+				category = filepath.ToSlash(rel)
+			}
+			presets = append(presets, PresetInfo{
+				Name:     strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+				Category: category,
+				Path:     path,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan preset directory %s: %w", dir, err)
+		}
+	}
+
+	sort.Slice(presets, func(i, j int) bool {
+		if presets[i].Category != presets[j].Category {
+			return presets[i].Category < presets[j].Category
+		}
+		return presets[i].Name < presets[j].Name
+	})
+
+	presetIndex.Lock()
+	presetIndex.built = true
+	presetIndex.presets = presets
+	presetIndex.Unlock()
+	return presets, nil
+}
+
+// indexedPresets returns the preset catalog, building it on first use
+func indexedPresets() ([]PresetInfo, error) {
+	presetIndex.Lock()
+	built, presets := presetIndex.built, presetIndex.presets
+	presetIndex.Unlock()
+	if built {
+		return presets, nil
+	}
+	return IndexPresets(nil)
+}
+
+// SearchPresets finds presets whose name or category contains every word of the query,
+// ignoring case. Name matches rank above category matches, and exact and prefix name matches
+// rank highest. category, when given, limits results to that category and its subfolders.
+func SearchPresets(query string, category string, limit int) ([]PresetInfo, error) {
+	presets, err := indexedPresets()
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	words := strings.Fields(strings.ToLower(query))
+	category = strings.ToLower(strings.Trim(filepath.ToSlash(category), "/"))
+
+	type scored struct {
+		preset PresetInfo
+		score  int
+	}
+	var matches []scored
+	for _, preset := range presets {
+		name := strings.ToLower(preset.Name)
+		presetCategory := strings.ToLower(preset.Category)
+		if category != "" && presetCategory != category && !strings.HasPrefix(presetCategory, category+"/") {
+			continue
+		}
+
+		score := 0
+		matched := true
+		for _, word := range words {
+			switch {
+			case strings.HasPrefix(name, word):
+				score += 3
+			case strings.Contains(name, word):
+				score += 2
+			case strings.Contains(presetCategory, word):
+				score++
+			default:
+				matched = false
+			}
+		}
+		if !matched {
+			continue
+		}
+		if name == strings.ToLower(query) {
+			score += 10
+		}
+		matches = append(matches, scored{preset, score})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	results := make([]PresetInfo, len(matches))
+	for i, match := range matches {
+		results[i] = match.preset
+	}
+	return results, nil
+}
+
+// resolvePreset returns the path of a preset given a path to an .ffx file, a preset name or
+// "category/name" from the catalog
+func resolvePreset(preset string) (string, error) {
+	if strings.EqualFold(filepath.Ext(preset), ".ffx") {
+		if _, err := os.Stat(preset); err == nil {
+			return preset, nil
+		}
+	}
+
+	presets, err := indexedPresets()
+	if err != nil {
+		return "", err
+	}
+	wanted := strings.ToLower(strings.TrimSuffix(filepath.ToSlash(preset), ".ffx"))
+	for _, info := range presets {
+		name := strings.ToLower(info.Name)
+		if name == wanted || strings.ToLower(info.Category)+"/"+name == wanted {
+			return info.Path, nil
+		}
+	}
+	return "", fmt.Errorf("preset not found: %s. Use the preset search tool to find presets: %w", preset, ErrInvalidParams)
+}
+
+// ApplyPreset applies an animation preset to a layer with applyPreset, opening the
+// composition in the viewer with only that layer selected. preset is a path to an .ffx file or
+// the name of a preset in the catalog. The result lists the effects the preset added.
+func ApplyPreset(compName string, layerName string, preset string) (EffectDetails, error) {
+	path, err := resolvePreset(preset)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := effectStackScript(compName, layerName, nil, `
+		var presetFile = new File("`+escapeJSStringEffect(filepath.ToSlash(path))+`");
+		if (!presetFile.exists) {
+			return JSON.stringify({
+				error: "Preset file not found: " + presetFile.fsName
+			});
+		}
+
+		// applyPreset acts on the selected layers of the active composition, so select only
+		// this layer in the viewer and restore the selection afterwards
+		var selectedBefore = comp.selectedLayers;
+		for (var s = 0; s < selectedBefore.length; s++) {
+			selectedBefore[s].selected = false;
+		}
+		layer.selected = true;
+		comp.openInViewer();
+
+		var effectsBefore = effects.numProperties;
+		try {
+			layer.applyPreset(presetFile);
+		} finally {
+			layer.selected = false;
+			for (var s = 0; s < selectedBefore.length; s++) {
+				selectedBefore[s].selected = true;
+			}
+		}
+
+		var parade = layer.property("ADBE Effect Parade");
+		var added = [];
+		for (var e = effectsBefore + 1; e <= parade.numProperties; e++) {
+			added.push(effectSummary(parade.property(e)));
+		}
+
+		return returnjson({
+			layer: layer.name,
+			preset: presetFile.displayName,
+			path: presetFile.fsName,
+			addedEffects: added
+		});`)
+	if err != nil {
+		return nil, err
+	}
+	return runEffectScript(script)
+}
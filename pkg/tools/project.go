@@ -3,6 +3,7 @@ package tools
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/sunqirui1987/ae-mcp/pkg/ae"
 )
 
@@ -36,7 +37,8 @@ func GetProjectInfo() (ProjectInfo, error) {
 					duration: item.duration,
 					width: item.width,
 					height: item.height,
-					frameRate: item.frameRate
+					frameRate: item.frameRate,
+					parentFolder: item.parentFolder === project.rootFolder ? null : item.parentFolder.name
 				});
 			}
 		}
@@ -73,4 +75,631 @@ func GetProjectInfo() (ProjectInfo, error) {
 	}
 
 	return nil, ErrInvalidResponse
-} 
\ No newline at end of file
+} 
+// ProjectItemQuery describes which project items to list and how to page them
+type ProjectItemQuery struct {
+	Type           string `json:"type,omitempty"`           // Folder, Composition, Footage, Solid or Placeholder
+	Name           string `json:"name,omitempty"`           // Case-insensitive substring of the item name
+	Label          *int   `json:"label,omitempty"`          // Label color index (0-16)
+	ParentFolderID int    `json:"parentFolderId,omitempty"` // Only items inside this folder (0 for all items)
+	Recursive      bool   `json:"recursive,omitempty"`      // Include items in subfolders of ParentFolderID
+	Offset         int    `json:"offset"`
+	Limit          int    `json:"limit"`
+}
+
+// projectItemInfoJS is the ExtendScript helper used to describe a project item
+const projectItemInfoJS = `
+		function getItemType(item) {
+			if (item instanceof FolderItem) return "Folder";
+			if (item instanceof CompItem) return "Composition";
+			if (item instanceof FootageItem) {
+				if (item.mainSource instanceof SolidSource) return "Solid";
+				if (item.mainSource instanceof PlaceholderSource) return "Placeholder";
+				return "Footage";
+			}
+			return "Unknown";
+		}
+		
+		function getItemPath(item) {
+			var parts = [];
+			var folder = item.parentFolder;
+			while (folder && folder !== app.project.rootFolder) {
+				parts.unshift(folder.name);
+				folder = folder.parentFolder;
+			}
+			return parts.join("/");
+		}
+		
+		function getItemInfo(item) {
+			var info = {
+				id: item.id,
+				name: item.name,
+				type: getItemType(item),
+				label: item.label,
+				comment: item.comment,
+				path: getItemPath(item),
+				parentFolder: null
+			};
+			if (item.parentFolder && item.parentFolder !== app.project.rootFolder) {
+				info.parentFolder = {
+					id: item.parentFolder.id,
+					name: item.parentFolder.name
+				};
+			}
+			if (item instanceof FolderItem) {
+				info.numItems = item.numItems;
+			} else {
+				info.width = item.width;
+				info.height = item.height;
+				info.duration = item.duration;
+				info.frameRate = item.frameRate;
+				info.usedIn = item.usedIn.length;
+				if (item instanceof FootageItem && item.file) {
+					info.file = item.file.fsName;
+				}
+				if (item instanceof CompItem) {
+					info.numLayers = item.numLayers;
+				}
+			}
+			return info;
+		}
+`
+
+// ListProjectItems lists project items (folders, footage, solids and compositions)
+// matching the query, one page at a time
+func ListProjectItems(query ProjectItemQuery) (ProjectInfo, error) {
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+	if query.Limit <= 0 {
+		query.Limit = 100
+	}
+
+	queryJSON, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize query: %w", err)
+	}
+
+	script := `
+	try {
+		var query = ` + string(queryJSON) + `;
+		var project = app.project;
+		` + projectItemInfoJS + `
+		
+		// Resolve the folder to list from
+		var parentFolder = null;
+		if (query.parentFolderId) {
+			parentFolder = project.itemByID(query.parentFolderId);
+			if (!parentFolder || !(parentFolder instanceof FolderItem)) {
+				return JSON.stringify({
+					error: "Folder not found: " + query.parentFolderId
+				});
+			}
+		}
+		
+		function isInFolder(item, folder, recursive) {
+			var current = item.parentFolder;
+			while (current) {
+				if (current === folder) return true;
+				if (!recursive) return false;
+				current = current.parentFolder;
+			}
+			return false;
+		}
+		
+		var nameFilter = query.name ? query.name.toLowerCase() : "";
+		var matches = [];
+		for (var i = 1; i <= project.numItems; i++) {
+			var item = project.item(i);
+			if (parentFolder && !isInFolder(item, parentFolder, query.recursive)) continue;
+			if (query.type && getItemType(item) !== query.type) continue;
+			if (query.label !== undefined && item.label !== query.label) continue;
+			if (nameFilter && item.name.toLowerCase().indexOf(nameFilter) < 0) continue;
+			matches.push(item);
+		}
+		
+		var result = {
+			total: matches.length,
+			offset: query.offset,
+			limit: query.limit,
+			items: []
+		};
+		for (var i = query.offset; i < matches.length && i < query.offset + query.limit; i++) {
+			result.items.push(getItemInfo(matches[i]));
+		}
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var itemList ProjectInfo
+		if err := json.Unmarshal([]byte(resultStr), &itemList); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := itemList["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return itemList, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// GetProjectItemTree returns the folder hierarchy of the project as a nested tree,
+// starting at the given folder (0 for the root folder) and descending at most maxDepth levels
+func GetProjectItemTree(folderID int, maxDepth int) (ProjectInfo, error) {
+	if maxDepth <= 0 {
+		maxDepth = 32
+	}
+
+	script := `
+	try {
+		var folderId = ` + fmt.Sprintf("%d", folderID) + `;
+		var maxDepth = ` + fmt.Sprintf("%d", maxDepth) + `;
+		var project = app.project;
+		` + projectItemInfoJS + `
+		
+		var folder = project.rootFolder;
+		if (folderId) {
+			folder = project.itemByID(folderId);
+			if (!folder || !(folder instanceof FolderItem)) {
+				return JSON.stringify({
+					error: "Folder not found: " + folderId
+				});
+			}
+		}
+		
+		function buildTree(folder, depth) {
+			var node = {
+				id: folder.id,
+				name: folder === project.rootFolder ? "Root" : folder.name,
+				type: "Folder",
+				numItems: folder.numItems,
+				children: []
+			};
+			if (depth >= maxDepth) {
+				node.truncated = folder.numItems > 0;
+				return node;
+			}
+			for (var i = 1; i <= folder.numItems; i++) {
+				var child = folder.item(i);
+				if (child instanceof FolderItem) {
+					node.children.push(buildTree(child, depth + 1));
+				} else {
+					node.children.push(getItemInfo(child));
+				}
+			}
+			return node;
+		}
+		
+		return returnjson(buildTree(folder, 0));
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var tree ProjectInfo
+		if err := json.Unmarshal([]byte(resultStr), &tree); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := tree["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return tree, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// CreateFolder creates a folder in the project panel, inside the given parent folder (0 for the root)
+func CreateFolder(name string, parentFolderID int) (ProjectInfo, error) {
+	if name == "" {
+		return nil, fmt.Errorf("folder name is required: %w", ErrInvalidParams)
+	}
+
+	script := `
+	try {
+		var name = "` + escapeJSString(name) + `";
+		var parentId = ` + fmt.Sprintf("%d", parentFolderID) + `;
+		var project = app.project;
+		
+		var parentFolder = project.rootFolder;
+		if (parentId) {
+			parentFolder = project.itemByID(parentId);
+			if (!parentFolder || !(parentFolder instanceof FolderItem)) {
+				return JSON.stringify({
+					error: "Folder not found: " + parentId
+				});
+			}
+		}
+		
+		var folder = project.items.addFolder(name);
+		folder.parentFolder = parentFolder;
+		
+		var result = {
+			id: folder.id,
+			name: folder.name,
+			type: "Folder",
+			parentFolder: parentFolder === project.rootFolder ? null : {
+				id: parentFolder.id,
+				name: parentFolder.name
+			}
+		};
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var folderInfo ProjectInfo
+		if err := json.Unmarshal([]byte(resultStr), &folderInfo); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := folderInfo["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return folderInfo, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// MoveProjectItems moves project items into a folder (0 for the root folder)
+func MoveProjectItems(itemIDs []int, folderID int) (ProjectInfo, error) {
+	if len(itemIDs) == 0 {
+		return nil, fmt.Errorf("at least one item id is required: %w", ErrInvalidParams)
+	}
+
+	idsJSON, err := json.Marshal(itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize item ids: %w", err)
+	}
+
+	script := `
+	try {
+		var itemIds = ` + string(idsJSON) + `;
+		var folderId = ` + fmt.Sprintf("%d", folderID) + `;
+		var project = app.project;
+		
+		var folder = project.rootFolder;
+		if (folderId) {
+			folder = project.itemByID(folderId);
+			if (!folder || !(folder instanceof FolderItem)) {
+				return JSON.stringify({
+					error: "Folder not found: " + folderId
+				});
+			}
+		}
+		
+		var result = {
+			folder: folder === project.rootFolder ? "Root" : folder.name,
+			moved: [],
+			failed: []
+		};
+		
+		for (var i = 0; i < itemIds.length; i++) {
+			var item = project.itemByID(itemIds[i]);
+			if (!item) {
+				result.failed.push({ id: itemIds[i], reason: "Item not found" });
+				continue;
+			}
+			if (item === folder) {
+				result.failed.push({ id: itemIds[i], reason: "Cannot move a folder into itself" });
+				continue;
+			}
+			try {
+				item.parentFolder = folder;
+				result.moved.push({ id: item.id, name: item.name });
+			} catch (moveErr) {
+				result.failed.push({ id: itemIds[i], reason: moveErr.toString() });
+			}
+		}
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var moveInfo ProjectInfo
+		if err := json.Unmarshal([]byte(resultStr), &moveInfo); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := moveInfo["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return moveInfo, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// RenameProjectItem renames a project item identified by its id
+func RenameProjectItem(itemID int, newName string) (ProjectInfo, error) {
+	if itemID <= 0 || newName == "" {
+		return nil, fmt.Errorf("item id and new name are required: %w", ErrInvalidParams)
+	}
+
+	script := `
+	try {
+		var itemId = ` + fmt.Sprintf("%d", itemID) + `;
+		var newName = "` + escapeJSString(newName) + `";
+		
+		var item = app.project.itemByID(itemId);
+		if (!item) {
+			return JSON.stringify({
+				error: "Item not found: " + itemId
+			});
+		}
+		
+		var oldName = item.name;
+		item.name = newName;
+		
+		var result = {
+			id: item.id,
+			oldName: oldName,
+			name: item.name
+		};
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var renameInfo ProjectInfo
+		if err := json.Unmarshal([]byte(resultStr), &renameInfo); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := renameInfo["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return renameInfo, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// RemoveUnusedItems deletes footage and solids that are not used in any composition,
+// optionally the listed compositions, and optionally folders left empty. A listed
+// composition is skipped while it is nested in a composition that stays, queued for
+// rendering or the active composition. Items used only by removed compositions count as
+// unused. With dryRun set, it only reports what would be removed.
+func RemoveUnusedItems(compositionIDs []int, removeEmptyFolders bool, dryRun bool) (ProjectInfo, error) {
+	if compositionIDs == nil {
+		compositionIDs = []int{}
+	}
+	idsJSON, err := json.Marshal(compositionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize composition ids: %w", err)
+	}
+
+	script := `
+	try {
+		var compositionIds = ` + string(idsJSON) + `;
+		var removeEmptyFolders = ` + fmt.Sprintf("%t", removeEmptyFolders) + `;
+		var dryRun = ` + fmt.Sprintf("%t", dryRun) + `;
+		var project = app.project;
+		
+		var result = {
+			dryRun: dryRun,
+			removed: [],
+			skipped: []
+		};
+		
+		// Compositions queued for rendering or active in the viewer are never removed
+		var protectedIds = {};
+		for (var i = 1; i <= project.renderQueue.numItems; i++) {
+			protectedIds[project.renderQueue.item(i).comp.id] = "Queued for rendering";
+		}
+		if (project.activeItem instanceof CompItem) {
+			protectedIds[project.activeItem.id] = "Active composition";
+		}
+		
+		var candidateIds = {};
+		for (var i = 0; i < compositionIds.length; i++) {
+			var comp = project.itemByID(compositionIds[i]);
+			if (!comp || !(comp instanceof CompItem)) {
+				result.skipped.push({ id: compositionIds[i], reason: "Composition not found" });
+			} else if (protectedIds[comp.id]) {
+				result.skipped.push({ id: comp.id, name: comp.name, reason: protectedIds[comp.id] });
+			} else {
+				candidateIds[comp.id] = true;
+			}
+		}
+		
+		// Collect first, since removing items renumbers the project.
+		// "removedIds" also lets a dry run treat folders holding only unused footage as empty.
+		var unused = [];
+		var removedIds = {};
+		
+		// An item is unused when every composition using it is being removed. Repeat until
+		// nothing changes, since removing a composition can leave what it used unused.
+		var found = true;
+		while (found) {
+			found = false;
+			for (var i = 1; i <= project.numItems; i++) {
+				var item = project.item(i);
+				if (removedIds[item.id]) continue;
+				if (!(item instanceof FootageItem) && !candidateIds[item.id]) continue;
+				var used = false;
+				for (var u = 0; u < item.usedIn.length; u++) {
+					if (!removedIds[item.usedIn[u].id]) {
+						used = true;
+						break;
+					}
+				}
+				if (!used) {
+					unused.push(item);
+					removedIds[item.id] = true;
+					found = true;
+				}
+			}
+		}
+		
+		for (var id in candidateIds) {
+			if (!removedIds[id]) {
+				var comp = project.itemByID(Number(id));
+				result.skipped.push({ id: comp.id, name: comp.name, reason: "Used in another composition" });
+			}
+		}
+		
+		for (var i = 0; i < unused.length; i++) {
+			result.removed.push({
+				id: unused[i].id,
+				name: unused[i].name,
+				type: unused[i] instanceof CompItem ? "Composition" : "Footage"
+			});
+		}
+		if (!dryRun) {
+			for (var i = 0; i < unused.length; i++) {
+				unused[i].remove();
+			}
+		}
+		
+		if (removeEmptyFolders) {
+			// Repeat until no folder is removed, so nested empty folders go too
+			var removedFolder = true;
+			while (removedFolder) {
+				removedFolder = false;
+				for (var i = project.numItems; i >= 1; i--) {
+					var item = project.item(i);
+					if (!(item instanceof FolderItem) || removedIds[item.id]) continue;
+					var empty = true;
+					for (var j = 1; j <= item.numItems; j++) {
+						if (!removedIds[item.item(j).id]) {
+							empty = false;
+							break;
+						}
+					}
+					if (empty) {
+						removedIds[item.id] = true;
+						result.removed.push({ id: item.id, name: item.name, type: "Folder" });
+						if (!dryRun) {
+							item.remove();
+						}
+						removedFolder = true;
+						break;
+					}
+				}
+			}
+		}
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var removeInfo ProjectInfo
+		if err := json.Unmarshal([]byte(resultStr), &removeInfo); err != nil {
+			return nil, err
+		}
+
+		return removeInfo, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
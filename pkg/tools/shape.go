@@ -3,7 +3,11 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+
 	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+	"github.com/sunqirui1987/ae-mcp/pkg/curvefit"
+	"github.com/sunqirui1987/ae-mcp/pkg/svg"
 )
 
 // ShapeVertex represents a point in a shape
@@ -25,10 +29,51 @@ type ShapeData struct {
 	FeatherInterps []int        `json:"featherInterps,omitempty"` // 0 for non-Hold, 1 for Hold
 	FeatherTensions []float64   `json:"featherTensions,omitempty"`
 	FeatherRelCornerAngles []float64 `json:"featherRelCornerAngles,omitempty"`
+	Smooth       bool           `json:"smooth,omitempty"`          // Fit smooth Bezier curves through the vertices before drawing
+	SmoothTolerance float64     `json:"smoothTolerance,omitempty"` // Maximum distance in pixels between a vertex and the fitted curve, default 1
+}
+
+// SmoothShapeData replaces a polyline with cubic Beziers fitted through its vertices. Dense
+// input is first simplified with Ramer-Douglas-Peucker at half the tolerance; sharp turns
+// are kept as corners. Existing tangents are ignored.
+func SmoothShapeData(shapeData ShapeData, tolerance float64) (ShapeData, error) {
+	if len(shapeData.FeatherRadii) > 0 || len(shapeData.FeatherSegLocs) > 0 {
+		return shapeData, fmt.Errorf("feather points cannot be combined with smoothing: %w", ErrInvalidParams)
+	}
+	if tolerance <= 0 {
+		tolerance = 1
+	}
+
+	points := make([]curvefit.Point, len(shapeData.Vertices))
+	for i, v := range shapeData.Vertices {
+		points[i] = curvefit.Point(v)
+	}
+	fitted := curvefit.Fit(points, curvefit.Options{
+		Tolerance: tolerance,
+		Simplify:  tolerance / 2,
+		Closed:    shapeData.Closed,
+	})
+
+	smoothed := ShapeData{Closed: shapeData.Closed}
+	for i, v := range fitted.Vertices {
+		smoothed.Vertices = append(smoothed.Vertices, ShapeVertex(v))
+		smoothed.InTangents = append(smoothed.InTangents, ShapeTangent(fitted.InTangents[i]))
+		smoothed.OutTangents = append(smoothed.OutTangents, ShapeTangent(fitted.OutTangents[i]))
+	}
+	return smoothed, nil
 }
 
 // AddShapeLayer adds a shape layer to a composition
 func AddShapeLayer(compositionName string, layerName string, shapeData ShapeData) (LayerInfo, error) {
+	// Fit curves through the vertices when smoothing is requested
+	if shapeData.Smooth {
+		smoothed, err := SmoothShapeData(shapeData, shapeData.SmoothTolerance)
+		if err != nil {
+			return nil, err
+		}
+		shapeData = smoothed
+	}
+	
 	// Convert shape data to JSON for passing to JavaScript
 	shapeJSON, err := json.Marshal(shapeData)
 	if err != nil {
@@ -383,6 +428,265 @@ func AddPresetShapeLayer(compositionName string, layerName string, shapeType str
 	return nil, ErrInvalidResponse
 }
 
+// ShapePathGroup is a named group of paths drawn with a shared fill and stroke
+type ShapePathGroup struct {
+	Name          string      `json:"name,omitempty"`
+	Paths         []ShapeData `json:"paths"`
+	FillColor     *ColorRGB   `json:"fillColor,omitempty"`     // No fill when nil
+	FillOpacity   *float64    `json:"fillOpacity,omitempty"`   // 0-100, defaults to 100
+	FillRule      string      `json:"fillRule,omitempty"`      // "nonzero" (default) or "evenodd"
+	StrokeColor   *ColorRGB   `json:"strokeColor,omitempty"`   // No stroke when nil
+	StrokeWidth   float64     `json:"strokeWidth,omitempty"`
+	StrokeOpacity *float64    `json:"strokeOpacity,omitempty"` // 0-100, defaults to 100
+	LineCap       string      `json:"lineCap,omitempty"`       // "butt", "round" or "square"
+	LineJoin      string      `json:"lineJoin,omitempty"`      // "miter", "round" or "bevel"
+}
+
+// SVGImportOptions controls how an SVG drawing is placed on a shape layer
+type SVGImportOptions struct {
+	SVG      string     `json:"svg,omitempty"`      // SVG markup
+	FilePath string     `json:"filePath,omitempty"` // Path to an SVG file, used when SVG is empty
+	Scale    float64    `json:"scale,omitempty"`    // Defaults to 1
+	Offset   [2]float64 `json:"offset,omitempty"`   // Added to every point after scaling
+	Center   bool       `json:"center,omitempty"`   // Center the drawing in the composition
+}
+
+// AddShapeGroupsLayer adds a shape layer with one vector group per ShapePathGroup. Path coordinates
+// are composition coordinates unless center is set, which centers the drawing in the composition.
+func AddShapeGroupsLayer(compositionName string, layerName string, groups []ShapePathGroup, center bool) (LayerInfo, error) {
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("at least one shape group is required: %w", ErrInvalidParams)
+	}
+
+	groupsJSON, err := json.Marshal(groups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize shape groups: %w", err)
+	}
+
+	script := `
+	try {
+		var compName = "` + compositionName + `";
+		var layerName = "` + layerName + `";
+		var groups = ` + string(groupsJSON) + `;
+		var center = ` + fmt.Sprintf("%t", center) + `;
+		
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+		
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+		
+		var lineCaps = { butt: 1, round: 2, square: 3 };
+		var lineJoins = { miter: 1, round: 2, bevel: 3 };
+		
+		// Create shape layer
+		var shapeLayer = comp.layers.addShape();
+		shapeLayer.name = layerName;
+		var contents = shapeLayer.property("` + ShapeContents + `");
+		var pathCount = 0;
+		
+		for (var g = 0; g < groups.length; g++) {
+			var groupData = groups[g];
+			var vectorGroup = contents.addProperty("` + ShapeGroup + `");
+			vectorGroup.name = groupData.name || ("Group " + (g + 1));
+			var groupContents = vectorGroup.property("ADBE Vectors Group");
+			
+			// Paths
+			for (var p = 0; p < groupData.paths.length; p++) {
+				var pathData = groupData.paths[p];
+				var shape = new Shape();
+				shape.vertices = pathData.vertices;
+				if (pathData.inTangents && pathData.inTangents.length > 0) {
+					shape.inTangents = pathData.inTangents;
+				}
+				if (pathData.outTangents && pathData.outTangents.length > 0) {
+					shape.outTangents = pathData.outTangents;
+				}
+				shape.closed = pathData.closed;
+				
+				var pathGroup = groupContents.addProperty("ADBE Vector Shape - Group");
+				pathGroup.property("` + ShapePath + `").setValue(shape);
+				pathCount++;
+			}
+			
+			// Stroke is added first so it draws above the fill, as in SVG
+			if (groupData.strokeColor) {
+				var stroke = groupContents.addProperty("` + ShapeStroke + `");
+				stroke.property("ADBE Vector Stroke Color").setValue(groupData.strokeColor);
+				stroke.property("ADBE Vector Stroke Width").setValue(groupData.strokeWidth || 1);
+				if (groupData.strokeOpacity !== undefined) {
+					stroke.property("ADBE Vector Stroke Opacity").setValue(groupData.strokeOpacity);
+				}
+				if (groupData.lineCap && lineCaps[groupData.lineCap]) {
+					stroke.property("ADBE Vector Stroke Line Cap").setValue(lineCaps[groupData.lineCap]);
+				}
+				if (groupData.lineJoin && lineJoins[groupData.lineJoin]) {
+					stroke.property("ADBE Vector Stroke Line Join").setValue(lineJoins[groupData.lineJoin]);
+				}
+			}
+			
+			if (groupData.fillColor) {
+				var fill = groupContents.addProperty("` + ShapeFill + `");
+				fill.property("ADBE Vector Fill Color").setValue(groupData.fillColor);
+				if (groupData.fillOpacity !== undefined) {
+					fill.property("ADBE Vector Fill Opacity").setValue(groupData.fillOpacity);
+				}
+				fill.property("ADBE Vector Fill Rule").setValue(groupData.fillRule === "evenodd" ? 2 : 1);
+			}
+		}
+		
+		// Path coordinates map directly onto the composition, or the drawing is centered
+		var transform = shapeLayer.property("ADBE Transform Group");
+		if (center) {
+			var bounds = shapeLayer.sourceRectAtTime(0, false);
+			transform.property("ADBE Anchor Point").setValue([bounds.left + bounds.width / 2, bounds.top + bounds.height / 2, 0]);
+			transform.property("ADBE Position").setValue([comp.width / 2, comp.height / 2, 0]);
+		} else {
+			transform.property("ADBE Anchor Point").setValue([0, 0, 0]);
+			transform.property("ADBE Position").setValue([0, 0, 0]);
+		}
+		
+		// Return layer info
+		var result = {
+			name: shapeLayer.name,
+			index: shapeLayer.index,
+			enabled: shapeLayer.enabled,
+			shapeType: "groups",
+			groups: groups.length,
+			paths: pathCount
+		};
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+		
+		// Parse the JSON result into a structured object
+		var layerInfo LayerInfo
+		if err := json.Unmarshal([]byte(resultStr), &layerInfo); err != nil {
+			return nil, err
+		}
+		
+		// Check for error in result
+		if errMsg, hasErr := layerInfo["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+		
+		return layerInfo, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// SVGToShapeGroups converts an SVG drawing into shape groups, one per filled or stroked element
+func SVGToShapeGroups(options SVGImportOptions) ([]ShapePathGroup, []string, error) {
+	var doc *svg.Document
+	var err error
+	if strings.TrimSpace(options.SVG) != "" {
+		doc, err = svg.Parse([]byte(options.SVG))
+	} else if options.FilePath != "" {
+		doc, err = svg.ParseFile(options.FilePath)
+	} else {
+		return nil, nil, fmt.Errorf("either SVG markup or a file path is required: %w", ErrInvalidParams)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scale := options.Scale
+	if scale == 0 {
+		scale = 1
+	}
+
+	groups := make([]ShapePathGroup, 0, len(doc.Shapes))
+	for i, shape := range doc.Shapes {
+		group := ShapePathGroup{
+			Name:     shape.ID,
+			FillRule: shape.FillRule,
+			LineCap:  shape.LineCap,
+			LineJoin: shape.LineJoin,
+		}
+		if group.Name == "" {
+			group.Name = fmt.Sprintf("%s %d", shape.Element, i+1)
+		}
+
+		for _, sp := range shape.Subpaths {
+			data := ShapeData{Closed: sp.Closed}
+			for j := range sp.Vertices {
+				data.Vertices = append(data.Vertices, ShapeVertex{
+					sp.Vertices[j][0]*scale + options.Offset[0],
+					sp.Vertices[j][1]*scale + options.Offset[1],
+				})
+				data.InTangents = append(data.InTangents, ShapeTangent{sp.InTangents[j][0] * scale, sp.InTangents[j][1] * scale})
+				data.OutTangents = append(data.OutTangents, ShapeTangent{sp.OutTangents[j][0] * scale, sp.OutTangents[j][1] * scale})
+			}
+			group.Paths = append(group.Paths, data)
+		}
+
+		if shape.Fill != nil {
+			color := ColorRGB(shape.Fill.Color)
+			opacity := shape.Fill.Opacity * 100
+			group.FillColor = &color
+			group.FillOpacity = &opacity
+		}
+		if shape.Stroke != nil {
+			color := ColorRGB(shape.Stroke.Color)
+			opacity := shape.Stroke.Opacity * 100
+			group.StrokeColor = &color
+			group.StrokeOpacity = &opacity
+			group.StrokeWidth = shape.StrokeWidth * scale
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, doc.Warnings, nil
+}
+
+// ImportSVGShapeLayer converts an SVG drawing into a shape layer
+func ImportSVGShapeLayer(compositionName string, layerName string, options SVGImportOptions) (LayerInfo, error) {
+	groups, warnings, err := SVGToShapeGroups(options)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("SVG contains no drawable shapes")
+	}
+
+	layerInfo, err := AddShapeGroupsLayer(compositionName, layerName, groups, options.Center)
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		layerInfo["warnings"] = warnings
+	}
+	return layerInfo, nil
+}
+
 // MCP Function Definitions
 
 // MCPAddCustomShapeLayer adds a custom shape layer to a composition via MCP
@@ -398,10 +702,23 @@ func MCPAddCustomShapeLayer(args map[string]interface{}) (interface{}, error) {
 		return nil, ErrInvalidParams
 	}
 	
+	// Get the optional group tree
+	var groups []ShapeGroupSpec
+	if groupsRaw, ok := args["groups"].([]interface{}); ok {
+		parsed, err := ParseShapeGroupSpecs(groupsRaw)
+		if err != nil {
+			return nil, err
+		}
+		groups = parsed
+	}
+	
 	// Get vertices from args
 	verticesRaw, ok := args["vertices"].([]interface{})
 	if !ok {
-		return nil, fmt.Errorf("vertices parameter is required")
+		if len(groups) > 0 {
+			return AddShapeLayerTree(compositionName, layerName, groups)
+		}
+		return nil, fmt.Errorf("vertices or groups parameter is required")
 	}
 	
 	vertices := make([]ShapeVertex, len(verticesRaw))
@@ -484,6 +801,22 @@ func MCPAddCustomShapeLayer(args map[string]interface{}) (interface{}, error) {
 		shapeData.FeatherRadii = featherRadii
 	}
 	
+	// Handle curve fitting
+	if smooth, ok := args["smooth"].(bool); ok {
+		shapeData.Smooth = smooth
+	}
+	
+	if tolerance, ok := args["smooth_tolerance"].(float64); ok {
+		shapeData.SmoothTolerance = tolerance
+	}
+	
+	// With a group tree, the vertices become a first group named "Shape"
+	if len(groups) > 0 {
+		path := shapeData
+		groups = append([]ShapeGroupSpec{{Name: "Shape", Contents: []ShapeItemSpec{{Path: &path}}}}, groups...)
+		return AddShapeLayerTree(compositionName, layerName, groups)
+	}
+	
 	// Add the shape layer
 	return AddShapeLayer(compositionName, layerName, shapeData)
 }
@@ -513,9 +846,12 @@ func MCPAddPresetShapeLayer(args map[string]interface{}) (interface{}, error) {
 		"polygon":   true,
 		"star":      true,
 	}
+	for _, generated := range GeneratedShapeTypes() {
+		validShapeTypes[generated] = true
+	}
 	
 	if !validShapeTypes[shapeType] {
-		return nil, fmt.Errorf("invalid shape type: %s. Must be one of: rectangle, ellipse, polygon, star", shapeType)
+		return nil, fmt.Errorf("invalid shape type: %s. Must be one of: rectangle, ellipse, polygon, star, %s", shapeType, strings.Join(GeneratedShapeTypes(), ", "))
 	}
 	
 	// Get dimensions with defaults
@@ -530,6 +866,114 @@ func MCPAddPresetShapeLayer(args map[string]interface{}) (interface{}, error) {
 		height = heightRaw
 	}
 	
+	options, customized, err := parseShapeGenOptions(args)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Polygons and stars with explicit parameters are generated; otherwise they use the
+	// parametric After Effects shapes
+	if shapeType == "polygon" {
+		if !customized {
+			return AddPresetShapeLayer(compositionName, layerName, shapeType, width, height)
+		}
+		shapeType = "ngon"
+	}
+	if _, generated := shapeGenerators[shapeType]; generated && (shapeType != "star" || customized) {
+		return AddGeneratedShapeLayer(compositionName, layerName, shapeType, width, height, options)
+	}
+	
 	// Add the preset shape layer
 	return AddPresetShapeLayer(compositionName, layerName, shapeType, width, height)
+}
+
+// parseShapeGenOptions reads the shape generator parameters from MCP arguments and reports
+// whether any were given
+func parseShapeGenOptions(args map[string]interface{}) (ShapeGenOptions, bool, error) {
+	var options ShapeGenOptions
+	customized := false
+	
+	number := func(key string) (float64, bool) {
+		value, ok := args[key].(float64)
+		if ok {
+			customized = true
+		}
+		return value, ok
+	}
+	pair := func(key string, size int) ([]float64, error) {
+		raw, ok := args[key].([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		if len(raw) != size {
+			return nil, fmt.Errorf("%s must have %d numbers: %w", key, size, ErrInvalidParams)
+		}
+		values := make([]float64, size)
+		for i, item := range raw {
+			value, ok := item.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s must contain numbers: %w", key, ErrInvalidParams)
+			}
+			values[i] = value
+		}
+		customized = true
+		return values, nil
+	}
+	
+	if v, ok := number("sides"); ok {
+		options.Sides = int(v)
+	}
+	if v, ok := number("points"); ok {
+		options.Points = int(v)
+	}
+	if v, ok := number("corner_radius"); ok {
+		options.CornerRadius = v
+	}
+	if v, ok := number("inner_radius"); ok {
+		options.InnerRadius = v
+	}
+	if v, ok := number("start_angle"); ok {
+		options.StartAngle = v
+	}
+	if v, ok := number("end_angle"); ok {
+		options.EndAngle = &v
+	}
+	if v, ok := number("turns"); ok {
+		options.Turns = v
+	}
+	if v, ok := number("teeth"); ok {
+		options.Teeth = int(v)
+	}
+	if v, ok := number("tooth_depth"); ok {
+		options.ToothDepth = v
+	}
+	if v, ok := number("thickness"); ok {
+		options.Thickness = v
+	}
+	if v, ok := number("head_length"); ok {
+		options.HeadLength = v
+	}
+	if v, ok := number("tail_width"); ok {
+		options.TailWidth = v
+	}
+	if v, ok := number("rotation"); ok {
+		options.Rotation = v
+	}
+	
+	radii, err := pair("corner_radii", 4)
+	if err != nil {
+		return options, customized, err
+	}
+	if radii != nil {
+		options.CornerRadii = &[4]float64{radii[0], radii[1], radii[2], radii[3]}
+	}
+	tail, err := pair("tail", 2)
+	if err != nil {
+		return options, customized, err
+	}
+	if tail != nil {
+		options.Tail = &[2]float64{tail[0], tail[1]}
+	}
+	
+	return options, customized, nil
 } 
\ No newline at end of file
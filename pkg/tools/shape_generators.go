@@ -0,0 +1,718 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ShapeGenOptions parameterizes procedural shapes. Zero values select each shape's defaults.
+// Angles are in degrees, clockwise from 3 o'clock; ratios are fractions of the shape size.
+type ShapeGenOptions struct {
+	Sides        int         `json:"sides,omitempty"`        // ngon sides, default 6
+	Points       int         `json:"points,omitempty"`       // star points, default 5
+	CornerRadius float64     `json:"cornerRadius,omitempty"` // Corner rounding in pixels for ngon, rounded rectangle, speech bubble and callout
+	CornerRadii  *[4]float64 `json:"cornerRadii,omitempty"`  // Rounded rectangle radii: top-left, top-right, bottom-right, bottom-left
+	InnerRadius  float64     `json:"innerRadius,omitempty"`  // Star, arc, donut, spiral start and gear hole, as a ratio of the outer radius
+	StartAngle   float64     `json:"startAngle,omitempty"`   // Arc start
+	EndAngle     *float64    `json:"endAngle,omitempty"`     // Arc end, default 270
+	Turns        float64     `json:"turns,omitempty"`        // Spiral turns, default 3
+	Teeth        int         `json:"teeth,omitempty"`        // Gear teeth, default 12
+	ToothDepth   float64     `json:"toothDepth,omitempty"`   // Gear tooth depth ratio, default 0.2
+	Thickness    float64     `json:"thickness,omitempty"`    // Arrow shaft and checkmark thickness ratio of the height
+	HeadLength   float64     `json:"headLength,omitempty"`   // Arrow head length ratio of the width, default 0.4
+	Tail         *[2]float64 `json:"tail,omitempty"`         // Speech bubble or callout tip, relative to the shape center
+	TailWidth    float64     `json:"tailWidth,omitempty"`    // Width of the tail where it meets the body
+	Rotation     float64     `json:"rotation,omitempty"`     // Rotation applied to the generated shape
+}
+
+// GeneratedShape is the output of a shape generator
+type GeneratedShape struct {
+	Paths    []ShapeData `json:"paths"`
+	Filled   bool        `json:"filled"`   // Open shapes such as spirals are stroked only
+	FillRule string      `json:"fillRule"` // "evenodd" for shapes with holes
+}
+
+// Upper bounds on repeated features, which keep the generated paths to a size AE can draw
+const (
+	maxShapeRepeats = 1000 // ngon sides, star points and gear teeth
+	maxSpiralTurns  = 100
+)
+
+// shapeGenerators maps the generated shapeType values to their generator
+var shapeGenerators = map[string]func(w, h float64, o ShapeGenOptions) (GeneratedShape, error){
+	"ngon":              generateNGon,
+	"star":              generateStar,
+	"rounded_rectangle": generateRoundedRect,
+	"arrow":             generateArrow,
+	"arc":               generateArc,
+	"donut":             generateDonut,
+	"spiral":            generateSpiral,
+	"gear":              generateGear,
+	"speech_bubble":     generateSpeechBubble,
+	"callout":           generateCallout,
+	"checkmark":         generateCheckmark,
+}
+
+// GeneratedShapeTypes returns the shapeType values handled by GenerateShape
+func GeneratedShapeTypes() []string {
+	types := make([]string, 0, len(shapeGenerators))
+	for name := range shapeGenerators {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// GenerateShape builds the paths of a procedural shape inside a width x height box centered on
+// the origin
+func GenerateShape(shapeType string, width float64, height float64, options ShapeGenOptions) (GeneratedShape, error) {
+	generator, ok := shapeGenerators[shapeType]
+	if !ok {
+		return GeneratedShape{}, fmt.Errorf("unknown generated shape type %q (valid: %s): %w", shapeType, strings.Join(GeneratedShapeTypes(), ", "), ErrInvalidParams)
+	}
+	if width <= 0 || height <= 0 {
+		return GeneratedShape{}, fmt.Errorf("width and height must be positive: %w", ErrInvalidParams)
+	}
+
+	shape, err := generator(width, height, options)
+	if err != nil {
+		return GeneratedShape{}, err
+	}
+	if options.Rotation != 0 {
+		for i := range shape.Paths {
+			shape.Paths[i] = rotateShapeData(shape.Paths[i], options.Rotation)
+		}
+	}
+	if shape.FillRule == "" {
+		shape.FillRule = "nonzero"
+	}
+	return shape, nil
+}
+
+// AddGeneratedShapeLayer adds a procedural shape as a shape layer. Like the preset shapes, the
+// shape's top-left corner is placed at the composition origin, with a white fill and black
+// stroke; open shapes get a white stroke only.
+func AddGeneratedShapeLayer(compositionName string, layerName string, shapeType string, width float64, height float64, options ShapeGenOptions) (LayerInfo, error) {
+	shape, err := GenerateShape(shapeType, width, height, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Move the shape's bounding box to the origin
+	minX, minY, _, _ := ShapeDataBounds(shape.Paths)
+	for i := range shape.Paths {
+		shape.Paths[i] = translateShapeData(shape.Paths[i], -minX, -minY)
+	}
+
+	group := ShapePathGroup{
+		Name:     shapeType,
+		Paths:    shape.Paths,
+		FillRule: shape.FillRule,
+	}
+	if shape.Filled {
+		white, black := ColorRGB{1, 1, 1}, ColorRGB{0, 0, 0}
+		group.FillColor = &white
+		group.StrokeColor = &black
+		group.StrokeWidth = 2
+	} else {
+		white := ColorRGB{1, 1, 1}
+		group.StrokeColor = &white
+		group.StrokeWidth = 4
+		group.LineCap = "round"
+	}
+
+	layerInfo, err := AddShapeGroupsLayer(compositionName, layerName, []ShapePathGroup{group}, false)
+	if err != nil {
+		return nil, err
+	}
+	layerInfo["shapeType"] = shapeType
+	return layerInfo, nil
+}
+
+// ShapeDataBounds returns the bounding box (minX, minY, maxX, maxY) of paths, including curves
+func ShapeDataBounds(paths []ShapeData) (float64, float64, float64, float64) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	include := func(x, y float64) {
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+
+	for _, path := range paths {
+		n := len(path.Vertices)
+		for i := 0; i < n; i++ {
+			include(path.Vertices[i][0], path.Vertices[i][1])
+			if i == n-1 && !path.Closed {
+				break
+			}
+			// Sample the segment to this vertex's successor
+			p0, c1, c2, p1 := shapeSegment(path, i)
+			for s := 1; s < 16; s++ {
+				t := float64(s) / 16
+				x, y := cubicPoint(p0, c1, c2, p1, t)
+				include(x, y)
+			}
+		}
+	}
+
+	if math.IsInf(minX, 1) {
+		return 0, 0, 0, 0
+	}
+	return minX, minY, maxX, maxY
+}
+
+// shapeSegment returns the absolute control points of the segment from vertex i to the next
+func shapeSegment(path ShapeData, i int) (ShapeVertex, ShapeVertex, ShapeVertex, ShapeVertex) {
+	j := (i + 1) % len(path.Vertices)
+	p0, p1 := path.Vertices[i], path.Vertices[j]
+	c1, c2 := p0, p1
+	if i < len(path.OutTangents) {
+		c1 = ShapeVertex{p0[0] + path.OutTangents[i][0], p0[1] + path.OutTangents[i][1]}
+	}
+	if j < len(path.InTangents) {
+		c2 = ShapeVertex{p1[0] + path.InTangents[j][0], p1[1] + path.InTangents[j][1]}
+	}
+	return p0, c1, c2, p1
+}
+
+// cubicPoint evaluates a cubic Bezier
+func cubicPoint(p0, c1, c2, p1 ShapeVertex, t float64) (float64, float64) {
+	mt := 1 - t
+	a, b, c, d := mt*mt*mt, 3*mt*mt*t, 3*mt*t*t, t*t*t
+	return a*p0[0] + b*c1[0] + c*c2[0] + d*p1[0], a*p0[1] + b*c1[1] + c*c2[1] + d*p1[1]
+}
+
+// translateShapeData moves every vertex of a path
+func translateShapeData(path ShapeData, dx float64, dy float64) ShapeData {
+	moved := path
+	moved.Vertices = make([]ShapeVertex, len(path.Vertices))
+	for i, v := range path.Vertices {
+		moved.Vertices[i] = ShapeVertex{v[0] + dx, v[1] + dy}
+	}
+	return moved
+}
+
+// rotateShapeData rotates a path around the origin
+func rotateShapeData(path ShapeData, degrees float64) ShapeData {
+	r := degrees * math.Pi / 180
+	cos, sin := math.Cos(r), math.Sin(r)
+	rot := func(p [2]float64) [2]float64 {
+		return [2]float64{p[0]*cos - p[1]*sin, p[0]*sin + p[1]*cos}
+	}
+
+	rotated := path
+	rotated.Vertices = make([]ShapeVertex, len(path.Vertices))
+	rotated.InTangents = make([]ShapeTangent, len(path.InTangents))
+	rotated.OutTangents = make([]ShapeTangent, len(path.OutTangents))
+	for i, v := range path.Vertices {
+		rotated.Vertices[i] = rot(v)
+	}
+	for i, t := range path.InTangents {
+		rotated.InTangents[i] = rot(t)
+	}
+	for i, t := range path.OutTangents {
+		rotated.OutTangents[i] = rot(t)
+	}
+	return rotated
+}
+
+// scaleShapeData scales a path around the origin
+func scaleShapeData(path ShapeData, sx float64, sy float64) ShapeData {
+	scaled := path
+	scaled.Vertices = make([]ShapeVertex, len(path.Vertices))
+	scaled.InTangents = make([]ShapeTangent, len(path.InTangents))
+	scaled.OutTangents = make([]ShapeTangent, len(path.OutTangents))
+	for i, v := range path.Vertices {
+		scaled.Vertices[i] = ShapeVertex{v[0] * sx, v[1] * sy}
+	}
+	for i, t := range path.InTangents {
+		scaled.InTangents[i] = ShapeTangent{t[0] * sx, t[1] * sy}
+	}
+	for i, t := range path.OutTangents {
+		scaled.OutTangents[i] = ShapeTangent{t[0] * sx, t[1] * sy}
+	}
+	return scaled
+}
+
+// pathBuilder accumulates a path with absolute control points
+type pathBuilder struct {
+	vertices []ShapeVertex
+	in       []ShapeVertex
+	out      []ShapeVertex
+}
+
+func (b *pathBuilder) moveTo(p ShapeVertex) {
+	b.vertices = append(b.vertices, p)
+	b.in = append(b.in, p)
+	b.out = append(b.out, p)
+}
+
+func (b *pathBuilder) lineTo(p ShapeVertex) {
+	b.moveTo(p)
+}
+
+func (b *pathBuilder) cubicTo(c1 ShapeVertex, c2 ShapeVertex, p ShapeVertex) {
+	b.out[len(b.out)-1] = c1
+	b.vertices = append(b.vertices, p)
+	b.in = append(b.in, c2)
+	b.out = append(b.out, p)
+}
+
+// arcTo continues along a circle of radius r around c from angle a0 to a1 (radians), in
+// segments of at most 90 degrees. The current point must be on the circle at a0.
+func (b *pathBuilder) arcTo(c ShapeVertex, r float64, a0 float64, a1 float64) {
+	segments := int(math.Ceil(math.Abs(a1-a0) / (math.Pi / 2)))
+	if segments == 0 {
+		return
+	}
+	step := (a1 - a0) / float64(segments)
+	k := 4.0 / 3.0 * math.Tan(step/4) * r
+	for i := 0; i < segments; i++ {
+		t0, t1 := a0+float64(i)*step, a0+float64(i+1)*step
+		p1 := ShapeVertex{c[0] + r*math.Cos(t1), c[1] + r*math.Sin(t1)}
+		p0 := ShapeVertex{c[0] + r*math.Cos(t0), c[1] + r*math.Sin(t0)}
+		c1 := ShapeVertex{p0[0] - k*math.Sin(t0), p0[1] + k*math.Cos(t0)}
+		c2 := ShapeVertex{p1[0] + k*math.Sin(t1), p1[1] - k*math.Cos(t1)}
+		b.cubicTo(c1, c2, p1)
+	}
+}
+
+// build returns the path with tangents relative to their vertices. A closed path whose
+// last vertex repeats the first is merged so the closing segment keeps its curve.
+func (b *pathBuilder) build(closed bool) ShapeData {
+	vertices, in, out := b.vertices, b.in, b.out
+	last := len(vertices) - 1
+	if closed && last > 0 && math.Abs(vertices[last][0]-vertices[0][0]) < 1e-9 && math.Abs(vertices[last][1]-vertices[0][1]) < 1e-9 {
+		in = append([]ShapeVertex{in[last]}, in[1:last]...)
+		vertices, out = vertices[:last], out[:last]
+	}
+
+	data := ShapeData{Closed: closed}
+	for i, v := range vertices {
+		data.Vertices = append(data.Vertices, v)
+		data.InTangents = append(data.InTangents, ShapeTangent{in[i][0] - v[0], in[i][1] - v[1]})
+		data.OutTangents = append(data.OutTangents, ShapeTangent{out[i][0] - v[0], out[i][1] - v[1]})
+	}
+	return data
+}
+
+// reverseShapeData reverses the direction of a path, swapping in and out tangents
+func reverseShapeData(path ShapeData) ShapeData {
+	n := len(path.Vertices)
+	reversed := ShapeData{Closed: path.Closed}
+	for i := n - 1; i >= 0; i-- {
+		reversed.Vertices = append(reversed.Vertices, path.Vertices[i])
+		var in, out ShapeTangent
+		if i < len(path.OutTangents) {
+			in = path.OutTangents[i]
+		}
+		if i < len(path.InTangents) {
+			out = path.InTangents[i]
+		}
+		reversed.InTangents = append(reversed.InTangents, in)
+		reversed.OutTangents = append(reversed.OutTangents, out)
+	}
+	return reversed
+}
+
+// roundedPolygon builds a closed polygon whose corners are rounded with circular fillets.
+// Each fillet is a single cubic whose handles lie along the polygon edges, so the curve is
+// tangent to both edges. Radii are clamped so fillets never overlap.
+func roundedPolygon(points []ShapeVertex, radii []float64) ShapeData {
+	n := len(points)
+	b := &pathBuilder{}
+	for i := 0; i < n; i++ {
+		p := points[i]
+		prev, next := points[(i+n-1)%n], points[(i+1)%n]
+		r := 0.0
+		if i < len(radii) {
+			r = radii[i]
+		}
+
+		ux, uy, lu := prev[0]-p[0], prev[1]-p[1], math.Hypot(prev[0]-p[0], prev[1]-p[1])
+		vx, vy, lv := next[0]-p[0], next[1]-p[1], math.Hypot(next[0]-p[0], next[1]-p[1])
+		if r <= 0 || lu == 0 || lv == 0 {
+			b.lineTo(p)
+			continue
+		}
+		ux, uy, vx, vy = ux/lu, uy/lu, vx/lv, vy/lv
+
+		// Half of the corner angle
+		half := math.Acos(math.Max(-1, math.Min(1, ux*vx+uy*vy))) / 2
+		if half < 1e-6 || math.Pi/2-half < 1e-6 {
+			b.lineTo(p)
+			continue
+		}
+		d := r / math.Tan(half)
+		if limit := math.Min(lu, lv) / 2; d > limit {
+			d = limit
+			r = d * math.Tan(half)
+		}
+
+		// Handle length of a circular arc sweeping the exterior angle
+		sweep := math.Pi - 2*half
+		h := 4.0 / 3.0 * math.Tan(sweep/4) * r
+		t1 := ShapeVertex{p[0] + ux*d, p[1] + uy*d}
+		t2 := ShapeVertex{p[0] + vx*d, p[1] + vy*d}
+		b.lineTo(t1)
+		b.cubicTo(ShapeVertex{t1[0] - ux*h, t1[1] - uy*h}, ShapeVertex{t2[0] - vx*h, t2[1] - vy*h}, t2)
+	}
+	return b.build(true)
+}
+
+// regularPolygonPoints returns n points on a circle of radius r, the first at the top
+func regularPolygonPoints(n int, r float64, offset float64) []ShapeVertex {
+	points := make([]ShapeVertex, n)
+	for i := 0; i < n; i++ {
+		a := -math.Pi/2 + offset + 2*math.Pi*float64(i)/float64(n)
+		points[i] = ShapeVertex{r * math.Cos(a), r * math.Sin(a)}
+	}
+	return points
+}
+
+// uniformRadii returns n copies of r
+func uniformRadii(n int, r float64) []float64 {
+	radii := make([]float64, n)
+	for i := range radii {
+		radii[i] = r
+	}
+	return radii
+}
+
+// generateNGon builds a regular polygon with optional rounded corners
+func generateNGon(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	sides := o.Sides
+	if sides == 0 {
+		sides = 6
+	}
+	if sides < 3 || sides > maxShapeRepeats {
+		return GeneratedShape{}, fmt.Errorf("ngon needs 3 to %d sides: %w", maxShapeRepeats, ErrInvalidParams)
+	}
+	points := regularPolygonPoints(sides, math.Min(w, h)/2, 0)
+	return GeneratedShape{Paths: []ShapeData{roundedPolygon(points, uniformRadii(sides, o.CornerRadius))}, Filled: true}, nil
+}
+
+// generateStar builds a star with alternating outer and inner points
+func generateStar(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	points := o.Points
+	if points == 0 {
+		points = 5
+	}
+	if points < 2 || points > maxShapeRepeats {
+		return GeneratedShape{}, fmt.Errorf("star needs 2 to %d points: %w", maxShapeRepeats, ErrInvalidParams)
+	}
+	inner := o.InnerRadius
+	if inner <= 0 {
+		inner = 0.5
+	}
+	if inner >= 1 {
+		return GeneratedShape{}, fmt.Errorf("star inner radius must be less than 1: %w", ErrInvalidParams)
+	}
+
+	vertices := make([]ShapeVertex, 0, points*2)
+	for i := 0; i < points*2; i++ {
+		a := -math.Pi/2 + math.Pi*float64(i)/float64(points)
+		r := 1.0
+		if i%2 == 1 {
+			r = inner
+		}
+		vertices = append(vertices, ShapeVertex{r * math.Cos(a) * w / 2, r * math.Sin(a) * h / 2})
+	}
+	path := roundedPolygon(vertices, uniformRadii(len(vertices), o.CornerRadius))
+	return GeneratedShape{Paths: []ShapeData{path}, Filled: true}, nil
+}
+
+// generateRoundedRect builds a rectangle with a radius per corner
+func generateRoundedRect(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	radii := []float64{o.CornerRadius, o.CornerRadius, o.CornerRadius, o.CornerRadius}
+	if o.CornerRadii != nil {
+		radii = o.CornerRadii[:]
+	} else if o.CornerRadius == 0 {
+		radii = uniformRadii(4, math.Min(w, h)*0.15)
+	}
+	points := []ShapeVertex{{-w / 2, -h / 2}, {w / 2, -h / 2}, {w / 2, h / 2}, {-w / 2, h / 2}}
+	return GeneratedShape{Paths: []ShapeData{roundedPolygon(points, radii)}, Filled: true}, nil
+}
+
+// generateArrow builds a right-pointing block arrow
+func generateArrow(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	thickness := o.Thickness
+	if thickness <= 0 {
+		thickness = 0.4
+	}
+	head := o.HeadLength
+	if head <= 0 {
+		head = 0.4
+	}
+	s := h * math.Min(thickness, 1) / 2
+	hx := w/2 - w*math.Min(head, 1)
+	b := &pathBuilder{}
+	for _, p := range []ShapeVertex{{-w / 2, -s}, {hx, -s}, {hx, -h / 2}, {w / 2, 0}, {hx, h / 2}, {hx, s}, {-w / 2, s}} {
+		b.lineTo(p)
+	}
+	return GeneratedShape{Paths: []ShapeData{b.build(true)}, Filled: true}, nil
+}
+
+// generateArc builds an open arc, or an annular sector when an inner radius is given
+func generateArc(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	end := 270.0
+	if o.EndAngle != nil {
+		end = *o.EndAngle
+	}
+	a0, a1 := o.StartAngle*math.Pi/180, end*math.Pi/180
+	if a0 == a1 {
+		return GeneratedShape{}, fmt.Errorf("arc start and end angles must differ: %w", ErrInvalidParams)
+	}
+	if math.Abs(a1-a0) >= 2*math.Pi && o.InnerRadius > 0 {
+		return generateDonut(w, h, o)
+	}
+
+	b := &pathBuilder{}
+	b.moveTo(ShapeVertex{math.Cos(a0), math.Sin(a0)})
+	b.arcTo(ShapeVertex{0, 0}, 1, a0, a1)
+
+	shape := GeneratedShape{}
+	if o.InnerRadius > 0 {
+		r := math.Min(o.InnerRadius, 0.999)
+		b.lineTo(ShapeVertex{r * math.Cos(a1), r * math.Sin(a1)})
+		b.arcTo(ShapeVertex{0, 0}, r, a1, a0)
+		shape.Paths = []ShapeData{b.build(true)}
+		shape.Filled = true
+	} else {
+		shape.Paths = []ShapeData{b.build(false)}
+	}
+
+	shape.Paths[0] = scaleShapeData(shape.Paths[0], w/2, h/2)
+	return shape, nil
+}
+
+// circlePath builds a closed circle of radius r around the origin, clockwise from 3 o'clock
+func circlePath(r float64) ShapeData {
+	b := &pathBuilder{}
+	b.moveTo(ShapeVertex{r, 0})
+	b.arcTo(ShapeVertex{0, 0}, r, 0, 2*math.Pi)
+	return b.build(true)
+}
+
+// generateDonut builds a ring from an outer circle and a reversed inner circle
+func generateDonut(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	inner := o.InnerRadius
+	if inner <= 0 {
+		inner = 0.5
+	}
+	if inner >= 1 {
+		return GeneratedShape{}, fmt.Errorf("donut inner radius must be less than 1: %w", ErrInvalidParams)
+	}
+	outerPath := scaleShapeData(circlePath(1), w/2, h/2)
+	innerPath := scaleShapeData(reverseShapeData(circlePath(inner)), w/2, h/2)
+	return GeneratedShape{Paths: []ShapeData{outerPath, innerPath}, Filled: true, FillRule: "evenodd"}, nil
+}
+
+// generateSpiral builds an open Archimedean spiral from the inner radius to the edge, with
+// cubic segments per quarter turn whose handles follow the spiral's derivative
+func generateSpiral(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	turns := o.Turns
+	if turns <= 0 {
+		turns = 3
+	}
+	if turns > maxSpiralTurns {
+		return GeneratedShape{}, fmt.Errorf("spiral can have at most %d turns: %w", maxSpiralTurns, ErrInvalidParams)
+	}
+	r0 := math.Max(0, math.Min(o.InnerRadius, 0.95))
+	total := turns * 2 * math.Pi
+	growth := (1 - r0) / total
+
+	point := func(t float64) (ShapeVertex, ShapeVertex) {
+		r := r0 + growth*t
+		cos, sin := math.Cos(t), math.Sin(t)
+		p := ShapeVertex{r * cos, r * sin}
+		d := ShapeVertex{growth*cos - r*sin, growth*sin + r*cos}
+		return p, d
+	}
+
+	segments := int(math.Ceil(turns * 4))
+	step := total / float64(segments)
+	b := &pathBuilder{}
+	p0, d0 := point(0)
+	b.moveTo(p0)
+	for i := 1; i <= segments; i++ {
+		p1, d1 := point(float64(i) * step)
+		b.cubicTo(
+			ShapeVertex{p0[0] + d0[0]*step/3, p0[1] + d0[1]*step/3},
+			ShapeVertex{p1[0] - d1[0]*step/3, p1[1] - d1[1]*step/3},
+			p1,
+		)
+		p0, d0 = p1, d1
+	}
+	return GeneratedShape{Paths: []ShapeData{scaleShapeData(b.build(false), w/2, h/2)}}, nil
+}
+
+// generateGear builds a gear with trapezoidal teeth and an optional center hole
+func generateGear(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	teeth := o.Teeth
+	if teeth == 0 {
+		teeth = 12
+	}
+	if teeth < 3 || teeth > maxShapeRepeats {
+		return GeneratedShape{}, fmt.Errorf("gear needs 3 to %d teeth: %w", maxShapeRepeats, ErrInvalidParams)
+	}
+	depth := o.ToothDepth
+	if depth <= 0 {
+		depth = 0.2
+	}
+	root := 1 - math.Min(depth, 0.9)
+	pitch := 2 * math.Pi / float64(teeth)
+
+	// Each tooth: root arc, rising flank, tip arc, falling flank
+	b := &pathBuilder{}
+	start := -math.Pi / 2
+	b.moveTo(ShapeVertex{root * math.Cos(start), root * math.Sin(start)})
+	for i := 0; i < teeth; i++ {
+		a := start + float64(i)*pitch
+		b.arcTo(ShapeVertex{0, 0}, root, a, a+pitch*0.45)
+		tipStart := a + pitch*0.55
+		b.lineTo(ShapeVertex{math.Cos(tipStart), math.Sin(tipStart)})
+		b.arcTo(ShapeVertex{0, 0}, 1, tipStart, a+pitch*0.9)
+		b.lineTo(ShapeVertex{root * math.Cos(a+pitch), root * math.Sin(a+pitch)})
+	}
+
+	shape := GeneratedShape{Paths: []ShapeData{scaleShapeData(b.build(true), w/2, h/2)}, Filled: true}
+	if o.InnerRadius > 0 {
+		hole := reverseShapeData(circlePath(math.Min(o.InnerRadius, root*0.95)))
+		shape.Paths = append(shape.Paths, scaleShapeData(hole, w/2, h/2))
+		shape.FillRule = "evenodd"
+	}
+	return shape, nil
+}
+
+// bubblePath builds a rectangle body with rounded corners and a tail pointing at tip. The tail
+// leaves from the edge facing the tip.
+func bubblePath(w, h, radius float64, tip [2]float64, tailWidth float64) ShapeData {
+	hw, hh := w/2, h/2
+	radius = math.Min(radius, math.Min(hw, hh))
+	half := math.Min(tailWidth/2, math.Max(math.Min(hw, hh)-radius, 0))
+
+	// Keep the tail base clear of the rounded corners
+	clamp := func(v, limit float64) float64 {
+		limit = math.Max(limit-radius-half, 0)
+		return math.Max(-limit, math.Min(limit, v))
+	}
+
+	corners := []ShapeVertex{{-hw, -hh}, {hw, -hh}, {hw, hh}, {-hw, hh}}
+	var points []ShapeVertex
+	var radii []float64
+	addCorner := func(p ShapeVertex) {
+		points = append(points, p)
+		radii = append(radii, radius)
+	}
+	addTail := func(a, t, b ShapeVertex) {
+		points = append(points, a, t, b)
+		radii = append(radii, 0, 0, 0)
+	}
+
+	tipPoint := ShapeVertex{tip[0], tip[1]}
+	onSide := math.Abs(tip[0])/hw > math.Abs(tip[1])/hh
+	switch {
+	case !onSide && tip[1] < 0: // Top edge, left to right
+		x := clamp(tip[0], hw)
+		addCorner(corners[0])
+		addTail(ShapeVertex{x - half, -hh}, tipPoint, ShapeVertex{x + half, -hh})
+		addCorner(corners[1])
+		addCorner(corners[2])
+		addCorner(corners[3])
+	case onSide && tip[0] > 0: // Right edge, top to bottom
+		y := clamp(tip[1], hh)
+		addCorner(corners[0])
+		addCorner(corners[1])
+		addTail(ShapeVertex{hw, y - half}, tipPoint, ShapeVertex{hw, y + half})
+		addCorner(corners[2])
+		addCorner(corners[3])
+	case !onSide: // Bottom edge, right to left
+		x := clamp(tip[0], hw)
+		addCorner(corners[0])
+		addCorner(corners[1])
+		addCorner(corners[2])
+		addTail(ShapeVertex{x + half, hh}, tipPoint, ShapeVertex{x - half, hh})
+		addCorner(corners[3])
+	default: // Left edge, bottom to top
+		y := clamp(tip[1], hh)
+		addCorner(corners[0])
+		addCorner(corners[1])
+		addCorner(corners[2])
+		addCorner(corners[3])
+		addTail(ShapeVertex{-hw, y + half}, tipPoint, ShapeVertex{-hw, y - half})
+	}
+
+	return roundedPolygon(points, radii)
+}
+
+// generateSpeechBubble builds a rounded bubble with a tail, by default below its left side
+func generateSpeechBubble(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	radius := o.CornerRadius
+	if radius == 0 {
+		radius = math.Min(w, h) * 0.25
+	}
+	tip := [2]float64{-w * 0.25, h * 0.8}
+	if o.Tail != nil {
+		tip = *o.Tail
+	}
+	tailWidth := o.TailWidth
+	if tailWidth <= 0 {
+		tailWidth = w * 0.15
+	}
+	return GeneratedShape{Paths: []ShapeData{bubblePath(w, h, radius, tip, tailWidth)}, Filled: true}, nil
+}
+
+// generateCallout builds a square-cornered box with a narrow pointer, by default toward the
+// lower right
+func generateCallout(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	tip := [2]float64{w * 0.6, h * 1.1}
+	if o.Tail != nil {
+		tip = *o.Tail
+	}
+	tailWidth := o.TailWidth
+	if tailWidth <= 0 {
+		tailWidth = math.Min(w, h) * 0.2
+	}
+	return GeneratedShape{Paths: []ShapeData{bubblePath(w, h, o.CornerRadius, tip, tailWidth)}, Filled: true}, nil
+}
+
+// generateCheckmark builds a filled checkmark outline with a mitered elbow
+func generateCheckmark(w, h float64, o ShapeGenOptions) (GeneratedShape, error) {
+	thickness := o.Thickness
+	if thickness <= 0 {
+		thickness = 0.18
+	}
+	t := math.Min(w, h) * math.Min(thickness, 1) / 2
+
+	// Centerline of the stroke
+	a := ShapeVertex{-0.4 * w, 0.0}
+	e := ShapeVertex{-0.1 * w, 0.3 * h}
+	c := ShapeVertex{0.42 * w, -0.35 * h}
+
+	normal := func(p, q ShapeVertex) ShapeVertex {
+		dx, dy := q[0]-p[0], q[1]-p[1]
+		l := math.Hypot(dx, dy)
+		return ShapeVertex{-dy / l, dx / l}
+	}
+	n1, n2 := normal(a, e), normal(e, c)
+	mx, my := n1[0]+n2[0], n1[1]+n2[1]
+	ml := math.Hypot(mx, my)
+	mx, my = mx/ml, my/ml
+	miter := t / (mx*n1[0] + my*n1[1])
+
+	b := &pathBuilder{}
+	for _, p := range []ShapeVertex{
+		{a[0] + n1[0]*t, a[1] + n1[1]*t},
+		{e[0] + mx*miter, e[1] + my*miter},
+		{c[0] + n2[0]*t, c[1] + n2[1]*t},
+		{c[0] - n2[0]*t, c[1] - n2[1]*t},
+		{e[0] - mx*miter, e[1] - my*miter},
+		{a[0] - n1[0]*t, a[1] - n1[1]*t},
+	} {
+		b.lineTo(p)
+	}
+	return GeneratedShape{Paths: []ShapeData{b.build(true)}, Filled: true}, nil
+}
@@ -0,0 +1,222 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+	"github.com/sunqirui1987/ae-mcp/pkg/pathops"
+)
+
+// ShapeMorphOptions controls how a path morph is keyframed
+type ShapeMorphOptions struct {
+	PathIndex   int   `json:"pathIndex,omitempty"`   // 1-based index of the path on the layer, in contents order; defaults to 1
+	MinVertices int   `json:"minVertices,omitempty"` // Subdivide both shapes to at least this many vertices
+	Ease        *bool `json:"ease,omitempty"`        // Easy ease both keyframes, defaults to true
+}
+
+// ParseShapeData decodes a single shape path from a generic MCP argument map
+func ParseShapeData(raw map[string]interface{}) (ShapeData, error) {
+	var shapeData ShapeData
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return shapeData, fmt.Errorf("failed to serialize shape data: %w", err)
+	}
+	if err := json.Unmarshal(data, &shapeData); err != nil {
+		return shapeData, fmt.Errorf("invalid shape data: %w", err)
+	}
+	if len(shapeData.Vertices) < 2 {
+		return shapeData, fmt.Errorf("shape data needs at least 2 vertices: %w", ErrInvalidParams)
+	}
+	return shapeData, nil
+}
+
+// MatchShapeData prepares two shapes for morphing: both are subdivided without changing
+// their outline to a common vertex count, and to is reversed and rotated so its winding and
+// starting vertex minimize how far each vertex travels
+func MatchShapeData(from ShapeData, to ShapeData, minVertices int) (ShapeData, ShapeData, error) {
+	fromPath, toPath, err := pathops.Match(toPathopsPaths([]ShapeData{from})[0], toPathopsPaths([]ShapeData{to})[0], minVertices)
+	if err != nil {
+		return from, to, fmt.Errorf("%v: %w", err, ErrInvalidParams)
+	}
+	matchedFrom := fromPathopsPaths([]pathops.Path{fromPath})[0]
+	matchedTo := fromPathopsPaths([]pathops.Path{toPath})[0]
+	matchedFrom.Closed, matchedTo.Closed = from.Closed, to.Closed
+	return matchedFrom, matchedTo, nil
+}
+
+// MorphShapeLayer keyframes a shape layer's path from one shape to another between startTime
+// and endTime (composition seconds). The shapes are matched with MatchShapeData first and use
+// the layer's own coordinates. When the layer does not exist, a white-filled shape layer is
+// created whose coordinates are composition coordinates.
+func MorphShapeLayer(compositionName string, layerName string, from ShapeData, to ShapeData, startTime float64, endTime float64, options ShapeMorphOptions) (LayerInfo, error) {
+	if endTime <= startTime {
+		return nil, fmt.Errorf("end time must be after start time: %w", ErrInvalidParams)
+	}
+	matchedFrom, matchedTo, err := MatchShapeData(from, to, options.MinVertices)
+	if err != nil {
+		return nil, err
+	}
+
+	pathIndex := options.PathIndex
+	if pathIndex <= 0 {
+		pathIndex = 1
+	}
+	ease := options.Ease == nil || *options.Ease
+
+	fromJSON, err := json.Marshal(matchedFrom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize shape data: %w", err)
+	}
+	toJSON, err := json.Marshal(matchedTo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize shape data: %w", err)
+	}
+
+	script := `
+	try {
+		var compName = "` + escapeJSString(compositionName) + `";
+		var layerName = "` + escapeJSString(layerName) + `";
+		var fromData = ` + string(fromJSON) + `;
+		var toData = ` + string(toJSON) + `;
+		var pathIndex = ` + fmt.Sprintf("%d", pathIndex) + `;
+		var startTime = ` + fmt.Sprintf("%f", startTime) + `;
+		var endTime = ` + fmt.Sprintf("%f", endTime) + `;
+		var ease = ` + fmt.Sprintf("%t", ease) + `;
+
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+
+		// Find the layer, or create a shape layer for the morph
+		var shapeLayer = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === layerName) {
+				shapeLayer = comp.layer(i);
+				break;
+			}
+		}
+
+		var created = false;
+		if (!shapeLayer) {
+			shapeLayer = comp.layers.addShape();
+			shapeLayer.name = layerName;
+			var vectorGroup = shapeLayer.property("` + ShapeContents + `").addProperty("` + ShapeGroup + `");
+			vectorGroup.name = layerName;
+			var groupContents = vectorGroup.property("ADBE Vectors Group");
+			groupContents.addProperty("ADBE Vector Shape - Group");
+			var fill = groupContents.addProperty("` + ShapeFill + `");
+			fill.property("ADBE Vector Fill Color").setValue([1, 1, 1]);
+			var transform = shapeLayer.property("ADBE Transform Group");
+			transform.property("ADBE Anchor Point").setValue([0, 0, 0]);
+			transform.property("ADBE Position").setValue([0, 0, 0]);
+			created = true;
+		}
+
+		if (shapeLayer.matchName !== "` + ShapeLayer + `") {
+			return JSON.stringify({
+				error: "Layer is not a shape layer: " + layerName
+			});
+		}
+
+		// Collect the layer's path properties in contents order
+		var paths = [];
+		function collectPaths(group) {
+			for (var p = 1; p <= group.numProperties; p++) {
+				var prop = group.property(p);
+				if (prop.matchName === "ADBE Vector Shape - Group") {
+					paths.push(prop.property("` + ShapePath + `"));
+				} else if (prop.numProperties !== undefined) {
+					collectPaths(prop);
+				}
+			}
+		}
+		collectPaths(shapeLayer.property("` + ShapeContents + `"));
+
+		if (pathIndex > paths.length) {
+			return JSON.stringify({
+				error: "Path " + pathIndex + " not found; the layer has " + paths.length + " path(s)"
+			});
+		}
+		var pathProp = paths[pathIndex - 1];
+
+		function toShape(data) {
+			var shape = new Shape();
+			shape.vertices = data.vertices;
+			shape.inTangents = data.inTangents;
+			shape.outTangents = data.outTangents;
+			shape.closed = data.closed;
+			return shape;
+		}
+
+		pathProp.setValueAtTime(startTime, toShape(fromData));
+		pathProp.setValueAtTime(endTime, toShape(toData));
+
+		var keys = [pathProp.nearestKeyIndex(startTime), pathProp.nearestKeyIndex(endTime)];
+		if (ease) {
+			var easeIn = new KeyframeEase(0, 33.333);
+			var easeOut = new KeyframeEase(0, 33.333);
+			for (var k = 0; k < keys.length; k++) {
+				pathProp.setTemporalEaseAtKey(keys[k], [easeIn], [easeOut]);
+			}
+		}
+
+		var result = {
+			name: shapeLayer.name,
+			index: shapeLayer.index,
+			created: created,
+			pathIndex: pathIndex,
+			vertices: fromData.vertices.length,
+			startTime: startTime,
+			endTime: endTime,
+			keyframes: keys,
+			eased: ease
+		};
+
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var layerInfo LayerInfo
+		if err := json.Unmarshal([]byte(resultStr), &layerInfo); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := layerInfo["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return layerInfo, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
@@ -0,0 +1,458 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+)
+
+// ShapeOperator is a path operator or graphic added to a shape group. Type selects which
+// fields apply; unset fields keep the After Effects defaults.
+type ShapeOperator struct {
+	Type string `json:"type"` // trimPaths, repeater, offsetPaths, roundCorners, zigZag, twist, wigglePaths, fill, stroke, gradientFill or gradientStroke
+	Name string `json:"name,omitempty"`
+
+	// Trim paths
+	Start        *float64 `json:"start,omitempty"`        // Percent
+	End          *float64 `json:"end,omitempty"`          // Percent
+	TrimMultiple string   `json:"trimMultiple,omitempty"` // "simultaneously" or "individually"
+
+	// Trim paths offset in degrees, repeater offset in copies
+	Offset *float64 `json:"offset,omitempty"`
+
+	// Repeater and offset paths
+	Copies       *float64    `json:"copies,omitempty"`
+	Composite    string      `json:"composite,omitempty"`    // Repeater: "below" or "above"
+	AnchorPoint  *[2]float64 `json:"anchorPoint,omitempty"`  // Repeater transform
+	Position     *[2]float64 `json:"position,omitempty"`     // Repeater transform
+	Scale        *[2]float64 `json:"scale,omitempty"`        // Repeater transform, percent
+	Rotation     *float64    `json:"rotation,omitempty"`     // Repeater transform, degrees
+	StartOpacity *float64    `json:"startOpacity,omitempty"` // Repeater transform, percent
+	EndOpacity   *float64    `json:"endOpacity,omitempty"`   // Repeater transform, percent
+	Amount       *float64    `json:"amount,omitempty"`       // Offset paths, pixels
+	CopyOffset   *float64    `json:"copyOffset,omitempty"`   // Offset paths
+
+	// Round corners
+	Radius *float64 `json:"radius,omitempty"`
+
+	// Zig zag and wiggle paths
+	Size             *float64 `json:"size,omitempty"`
+	Detail           *float64 `json:"detail,omitempty"`    // Zig zag ridges per segment, wiggle detail
+	PointType        string   `json:"pointType,omitempty"` // "corner" or "smooth"
+	WigglesPerSecond *float64 `json:"wigglesPerSecond,omitempty"`
+	Correlation      *float64 `json:"correlation,omitempty"` // Percent
+	TemporalPhase    *float64 `json:"temporalPhase,omitempty"`
+	SpatialPhase     *float64 `json:"spatialPhase,omitempty"`
+	RandomSeed       *int     `json:"randomSeed,omitempty"`
+
+	// Twist
+	Angle  *float64    `json:"angle,omitempty"`
+	Center *[2]float64 `json:"center,omitempty"`
+
+	// Fills and strokes
+	Color      *ColorRGB    `json:"color,omitempty"`
+	Opacity    *float64     `json:"opacity,omitempty"`  // Percent
+	FillRule   string       `json:"fillRule,omitempty"` // "nonzero" or "evenodd"
+	Width      *float64     `json:"width,omitempty"`
+	LineCap    string       `json:"lineCap,omitempty"`    // "butt", "round" or "square"
+	LineJoin   string       `json:"lineJoin,omitempty"`   // "miter", "round" or "bevel"; also used by offset paths
+	MiterLimit *float64     `json:"miterLimit,omitempty"` // Also used by offset paths
+	Dashes     []float64    `json:"dashes,omitempty"`     // Alternating dash and gap lengths, up to three pairs
+	DashOffset *float64     `json:"dashOffset,omitempty"`
+	Taper      *StrokeTaper `json:"taper,omitempty"`
+
+	// Gradients
+	GradientType    string         `json:"gradientType,omitempty"` // "linear" or "radial"
+	StartPoint      *[2]float64    `json:"startPoint,omitempty"`
+	EndPoint        *[2]float64    `json:"endPoint,omitempty"`
+	HighlightLength *float64       `json:"highlightLength,omitempty"` // Radial, percent
+	HighlightAngle  *float64       `json:"highlightAngle,omitempty"`  // Radial, degrees
+	Stops           []GradientStop `json:"stops,omitempty"`
+}
+
+// GradientStop is a color stop of a gradient fill or stroke
+type GradientStop struct {
+	Position float64  `json:"position"` // 0-1 along the gradient
+	Color    ColorRGB `json:"color"`
+	Opacity  *float64 `json:"opacity,omitempty"` // 0-100, defaults to 100
+}
+
+// StrokeTaper narrows the ends of a stroke (After Effects 17.0 and later)
+type StrokeTaper struct {
+	StartLength *float64 `json:"startLength,omitempty"` // Percent of the path length
+	EndLength   *float64 `json:"endLength,omitempty"`
+	StartWidth  *float64 `json:"startWidth,omitempty"` // Percent of the stroke width
+	EndWidth    *float64 `json:"endWidth,omitempty"`
+	StartEase   *float64 `json:"startEase,omitempty"` // Percent
+	EndEase     *float64 `json:"endEase,omitempty"`
+}
+
+// shapeOperatorMatchNames maps ShapeOperator types to their match names
+var shapeOperatorMatchNames = map[string]string{
+	"trimPaths":      ShapeTrimPaths,
+	"repeater":       ShapeRepeater,
+	"offsetPaths":    ShapeOffsetPaths,
+	"roundCorners":   ShapeRoundCorners,
+	"zigZag":         ShapeZigZag,
+	"twist":          ShapeTwist,
+	"wigglePaths":    ShapeWigglePaths,
+	"fill":           ShapeFill,
+	"stroke":         ShapeStroke,
+	"gradientFill":   ShapeGradientFill,
+	"gradientStroke": ShapeGradientStroke,
+}
+
+// ParseShapeOperators decodes shape operators from a generic MCP argument array
+func ParseShapeOperators(raw []interface{}) ([]ShapeOperator, error) {
+	var operators []ShapeOperator
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize shape operators: %w", err)
+	}
+	if err := json.Unmarshal(data, &operators); err != nil {
+		return nil, fmt.Errorf("invalid shape operators: %w", err)
+	}
+	return operators, nil
+}
+
+// validateShapeOperator checks the operator type and its enumerated options
+func validateShapeOperator(op ShapeOperator) error {
+	if _, ok := shapeOperatorMatchNames[op.Type]; !ok {
+		return fmt.Errorf("invalid shape operator type %q (valid: trimPaths, repeater, offsetPaths, roundCorners, zigZag, twist, wigglePaths, fill, stroke, gradientFill, gradientStroke): %w", op.Type, ErrInvalidParams)
+	}
+	options := []struct {
+		field, value string
+		valid        []string
+	}{
+		{"trimMultiple", op.TrimMultiple, []string{"simultaneously", "individually"}},
+		{"composite", op.Composite, []string{"below", "above"}},
+		{"pointType", op.PointType, []string{"corner", "smooth"}},
+		{"fillRule", op.FillRule, []string{"nonzero", "evenodd"}},
+		{"lineCap", op.LineCap, []string{"butt", "round", "square"}},
+		{"lineJoin", op.LineJoin, []string{"miter", "round", "bevel"}},
+		{"gradientType", op.GradientType, []string{"linear", "radial"}},
+	}
+	for _, option := range options {
+		if option.value == "" {
+			continue
+		}
+		valid := false
+		for _, v := range option.valid {
+			valid = valid || v == option.value
+		}
+		if !valid {
+			return fmt.Errorf("invalid %s %q for %s: %w", option.field, option.value, op.Type, ErrInvalidParams)
+		}
+	}
+	if len(op.Dashes) > 6 {
+		return fmt.Errorf("a stroke supports at most three dash and gap pairs: %w", ErrInvalidParams)
+	}
+	for _, stop := range op.Stops {
+		if stop.Position < 0 || stop.Position > 1 {
+			return fmt.Errorf("gradient stop positions must be between 0 and 1: %w", ErrInvalidParams)
+		}
+	}
+	return nil
+}
+
+// shapeOperatorJS defines addShapeOperator(contents, op, warnings), which adds one
+// ShapeOperator to a shape contents group and returns a summary of what was added
+const shapeOperatorJS = `
+		var shapeOperatorMatchNames = {
+			trimPaths: "` + ShapeTrimPaths + `",
+			repeater: "` + ShapeRepeater + `",
+			offsetPaths: "` + ShapeOffsetPaths + `",
+			roundCorners: "` + ShapeRoundCorners + `",
+			zigZag: "` + ShapeZigZag + `",
+			twist: "` + ShapeTwist + `",
+			wigglePaths: "` + ShapeWigglePaths + `",
+			fill: "` + ShapeFill + `",
+			stroke: "` + ShapeStroke + `",
+			gradientFill: "` + ShapeGradientFill + `",
+			gradientStroke: "` + ShapeGradientStroke + `"
+		};
+		var shapeLineCaps = { butt: 1, round: 2, square: 3 };
+		var shapeLineJoins = { miter: 1, round: 2, bevel: 3 };
+		var shapePointTypes = { corner: 1, smooth: 2 };
+
+		function setShapeProp(group, matchName, value) {
+			if (value !== undefined && value !== null) {
+				group.property(matchName).setValue(value);
+			}
+		}
+
+		function applyStrokeOptions(graphic, op, warnings) {
+			setShapeProp(graphic, "ADBE Vector Stroke Width", op.width);
+			if (op.lineCap) graphic.property("ADBE Vector Stroke Line Cap").setValue(shapeLineCaps[op.lineCap]);
+			if (op.lineJoin) graphic.property("ADBE Vector Stroke Line Join").setValue(shapeLineJoins[op.lineJoin]);
+			setShapeProp(graphic, "ADBE Vector Stroke Miter Limit", op.miterLimit);
+
+			// Dashes alternate dash and gap lengths
+			if (op.dashes && op.dashes.length > 0) {
+				var dashes = graphic.property("ADBE Vector Stroke Dashes");
+				for (var d = 0; d < op.dashes.length; d++) {
+					var dashName = (d % 2 === 0 ? "ADBE Vector Stroke Dash " : "ADBE Vector Stroke Gap ") + (Math.floor(d / 2) + 1);
+					var dashProp = dashes.canAddProperty(dashName) ? dashes.addProperty(dashName) : dashes.property(dashName);
+					dashProp.setValue(op.dashes[d]);
+				}
+				if (op.dashOffset !== undefined) {
+					var offsetName = "ADBE Vector Stroke Offset";
+					var offsetProp = dashes.canAddProperty(offsetName) ? dashes.addProperty(offsetName) : dashes.property(offsetName);
+					offsetProp.setValue(op.dashOffset);
+				}
+			}
+
+			if (op.taper) {
+				var taper = graphic.property("ADBE Vector Stroke Taper");
+				if (!taper) {
+					warnings.push("Stroke taper requires After Effects 17.0 or later");
+				} else {
+					setShapeProp(taper, "ADBE Vector Taper Start Length", op.taper.startLength);
+					setShapeProp(taper, "ADBE Vector Taper End Length", op.taper.endLength);
+					setShapeProp(taper, "ADBE Vector Taper Start Width", op.taper.startWidth);
+					setShapeProp(taper, "ADBE Vector Taper End Width", op.taper.endWidth);
+					setShapeProp(taper, "ADBE Vector Taper Start Ease", op.taper.startEase);
+					setShapeProp(taper, "ADBE Vector Taper End Ease", op.taper.endEase);
+				}
+			}
+		}
+
+		function applyGradient(graphic, op, warnings) {
+			if (op.gradientType) graphic.property("ADBE Vector Grad Type").setValue(op.gradientType === "radial" ? 2 : 1);
+			setShapeProp(graphic, "ADBE Vector Grad Start Pt", op.startPoint);
+			setShapeProp(graphic, "ADBE Vector Grad End Pt", op.endPoint);
+			setShapeProp(graphic, "ADBE Vector Grad HiLite Length", op.highlightLength);
+			setShapeProp(graphic, "ADBE Vector Grad HiLite Angle", op.highlightAngle);
+
+			if (!op.stops || op.stops.length === 0) {
+				return true;
+			}
+			// Color stops (position, r, g, b) followed by opacity stops (position, opacity).
+			// Not every After Effects version accepts gradient colors from scripts.
+			var stops = op.stops.slice(0).sort(function(a, b) { return a.position - b.position; });
+			var value = [];
+			for (var s = 0; s < stops.length; s++) {
+				value.push(stops[s].position, stops[s].color[0], stops[s].color[1], stops[s].color[2]);
+			}
+			for (var s = 0; s < stops.length; s++) {
+				value.push(stops[s].position, stops[s].opacity === undefined ? 1 : stops[s].opacity / 100);
+			}
+			try {
+				graphic.property("ADBE Vector Grad Colors").setValue(value);
+				return true;
+			} catch (gradErr) {
+				warnings.push("Gradient stops could not be set by script in this After Effects version; the gradient keeps its default colors");
+				return false;
+			}
+		}
+
+		function addShapeOperator(contents, op, warnings) {
+			var added = contents.addProperty(shapeOperatorMatchNames[op.type]);
+			if (op.name) added.name = op.name;
+			var summary = { type: op.type, name: added.name, index: added.propertyIndex };
+
+			switch (op.type) {
+			case "trimPaths":
+				setShapeProp(added, "ADBE Vector Trim Start", op.start);
+				setShapeProp(added, "ADBE Vector Trim End", op.end);
+				setShapeProp(added, "ADBE Vector Trim Offset", op.offset);
+				if (op.trimMultiple) added.property("ADBE Vector Trim Type").setValue(op.trimMultiple === "individually" ? 2 : 1);
+				break;
+			case "repeater":
+				setShapeProp(added, "ADBE Vector Repeater Copies", op.copies);
+				setShapeProp(added, "ADBE Vector Repeater Offset", op.offset);
+				if (op.composite) added.property("ADBE Vector Repeater Order").setValue(op.composite === "above" ? 2 : 1);
+				var repeaterTransform = added.property("ADBE Vector Repeater Transform");
+				setShapeProp(repeaterTransform, "ADBE Vector Repeater Anchor", op.anchorPoint);
+				setShapeProp(repeaterTransform, "ADBE Vector Repeater Position", op.position);
+				setShapeProp(repeaterTransform, "ADBE Vector Repeater Scale", op.scale);
+				setShapeProp(repeaterTransform, "ADBE Vector Repeater Rotation", op.rotation);
+				setShapeProp(repeaterTransform, "ADBE Vector Repeater Opacity 1", op.startOpacity);
+				setShapeProp(repeaterTransform, "ADBE Vector Repeater Opacity 2", op.endOpacity);
+				break;
+			case "offsetPaths":
+				setShapeProp(added, "ADBE Vector Offset Amount", op.amount);
+				if (op.lineJoin) added.property("ADBE Vector Offset Line Join").setValue(shapeLineJoins[op.lineJoin]);
+				setShapeProp(added, "ADBE Vector Offset Miter Limit", op.miterLimit);
+				setShapeProp(added, "ADBE Vector Offset Copies", op.copies);
+				setShapeProp(added, "ADBE Vector Offset Copy Offset", op.copyOffset);
+				break;
+			case "roundCorners":
+				setShapeProp(added, "ADBE Vector RoundCorner Radius", op.radius);
+				break;
+			case "zigZag":
+				setShapeProp(added, "ADBE Vector Zigzag Size", op.size);
+				setShapeProp(added, "ADBE Vector Zigzag Detail", op.detail);
+				if (op.pointType) added.property("ADBE Vector Zigzag Points").setValue(shapePointTypes[op.pointType]);
+				break;
+			case "twist":
+				setShapeProp(added, "ADBE Vector Twist Angle", op.angle);
+				setShapeProp(added, "ADBE Vector Twist Center", op.center);
+				break;
+			case "wigglePaths":
+				setShapeProp(added, "ADBE Vector Roughen Size", op.size);
+				setShapeProp(added, "ADBE Vector Roughen Detail", op.detail);
+				if (op.pointType) added.property("ADBE Vector Roughen Points").setValue(shapePointTypes[op.pointType]);
+				setShapeProp(added, "ADBE Vector Temporal Freq", op.wigglesPerSecond);
+				setShapeProp(added, "ADBE Vector Correlation", op.correlation);
+				setShapeProp(added, "ADBE Vector Temporal Phase", op.temporalPhase);
+				setShapeProp(added, "ADBE Vector Spatial Phase", op.spatialPhase);
+				setShapeProp(added, "ADBE Vector Random Seed", op.randomSeed);
+				break;
+			case "fill":
+				setShapeProp(added, "ADBE Vector Fill Color", op.color);
+				setShapeProp(added, "ADBE Vector Fill Opacity", op.opacity);
+				if (op.fillRule) added.property("ADBE Vector Fill Rule").setValue(op.fillRule === "evenodd" ? 2 : 1);
+				break;
+			case "stroke":
+				setShapeProp(added, "ADBE Vector Stroke Color", op.color);
+				setShapeProp(added, "ADBE Vector Stroke Opacity", op.opacity);
+				applyStrokeOptions(added, op, warnings);
+				break;
+			case "gradientFill":
+				setShapeProp(added, "ADBE Vector Fill Opacity", op.opacity);
+				if (op.fillRule) added.property("ADBE Vector Fill Rule").setValue(op.fillRule === "evenodd" ? 2 : 1);
+				summary.stopsApplied = applyGradient(added, op, warnings);
+				break;
+			case "gradientStroke":
+				setShapeProp(added, "ADBE Vector Stroke Opacity", op.opacity);
+				applyStrokeOptions(added, op, warnings);
+				summary.stopsApplied = applyGradient(added, op, warnings);
+				break;
+			}
+			return summary;
+		}
+`
+
+// AddShapeOperators appends operators, fills and strokes to a shape layer. They are added to
+// the first group named groupName (searched depth-first), or to the layer's root contents when
+// groupName is empty, in the order given; operators affect the paths above them.
+func AddShapeOperators(compositionName string, layerName string, groupName string, operators []ShapeOperator) (LayerInfo, error) {
+	if len(operators) == 0 {
+		return nil, fmt.Errorf("at least one shape operator is required: %w", ErrInvalidParams)
+	}
+	for _, op := range operators {
+		if err := validateShapeOperator(op); err != nil {
+			return nil, err
+		}
+	}
+
+	operatorsJSON, err := json.Marshal(operators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize shape operators: %w", err)
+	}
+
+	script := `
+	try {
+		var compName = "` + escapeJSString(compositionName) + `";
+		var layerName = "` + escapeJSString(layerName) + `";
+		var groupName = "` + escapeJSString(groupName) + `";
+		var operators = ` + string(operatorsJSON) + `;
+		var warnings = [];
+
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+
+		// Find the shape layer
+		var shapeLayer = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === layerName) {
+				shapeLayer = comp.layer(i);
+				break;
+			}
+		}
+
+		if (!shapeLayer || shapeLayer.matchName !== "` + ShapeLayer + `") {
+			return JSON.stringify({
+				error: "Shape layer not found: " + layerName
+			});
+		}
+
+		// Find the target contents group
+		var contents = shapeLayer.property("` + ShapeContents + `");
+		function findGroup(group) {
+			for (var p = 1; p <= group.numProperties; p++) {
+				var prop = group.property(p);
+				if (prop.matchName === "` + ShapeGroup + `") {
+					if (prop.name === groupName) {
+						return prop.property("ADBE Vectors Group");
+					}
+					var nested = findGroup(prop.property("ADBE Vectors Group"));
+					if (nested) {
+						return nested;
+					}
+				}
+			}
+			return null;
+		}
+		if (groupName !== "") {
+			contents = findGroup(contents);
+			if (!contents) {
+				return JSON.stringify({
+					error: "Shape group not found: " + groupName
+				});
+			}
+		}
+		` + shapeOperatorJS + `
+		var added = [];
+		for (var o = 0; o < operators.length; o++) {
+			added.push(addShapeOperator(contents, operators[o], warnings));
+		}
+
+		var result = {
+			name: shapeLayer.name,
+			index: shapeLayer.index,
+			group: groupName === "" ? null : groupName,
+			operators: added,
+			warnings: warnings
+		};
+
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var layerInfo LayerInfo
+		if err := json.Unmarshal([]byte(resultStr), &layerInfo); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := layerInfo["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return layerInfo, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
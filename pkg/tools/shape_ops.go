@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/pathops"
+)
+
+// ShapeOpOptions controls boolean and offset operations on shape paths
+type ShapeOpOptions struct {
+	FillRule   string  `json:"fillRule,omitempty"`   // How input paths define their area: "nonzero" (default) or "evenodd"
+	Tolerance  float64 `json:"tolerance,omitempty"`  // Allowed curve deviation in pixels, default 0.5
+	Join       string  `json:"join,omitempty"`       // Offset corners: "round" (default), "miter" or "bevel"
+	MiterLimit float64 `json:"miterLimit,omitempty"` // Offset miter limit as a multiple of the distance, default 4
+}
+
+// ParseShapeDataList decodes shape paths from a generic MCP argument array
+func ParseShapeDataList(raw []interface{}) ([]ShapeData, error) {
+	var paths []ShapeData
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize shape paths: %w", err)
+	}
+	if err := json.Unmarshal(data, &paths); err != nil {
+		return nil, fmt.Errorf("invalid shape paths: %w", err)
+	}
+	for i, path := range paths {
+		if len(path.Vertices) < 3 {
+			return nil, fmt.Errorf("shape path %d needs at least 3 vertices: %w", i, ErrInvalidParams)
+		}
+	}
+	return paths, nil
+}
+
+// ShapeBoolean combines two sets of closed paths with "union", "intersect", "difference"
+// (a minus b) or "xor". The result is computed in Go, so it is deterministic and can be drawn
+// without a Merge Paths operator; holes wind opposite to their outer contour.
+func ShapeBoolean(operation string, a []ShapeData, b []ShapeData, options ShapeOpOptions) ([]ShapeData, error) {
+	result, err := pathops.Boolean(pathops.Op(operation), toPathopsPaths(a), toPathopsPaths(b), pathops.Options{
+		FillRule:  options.FillRule,
+		Tolerance: options.Tolerance,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidParams)
+	}
+	return fromPathopsPaths(result), nil
+}
+
+// OffsetShapeData grows the area of closed paths by distance pixels, or shrinks it when
+// distance is negative
+func OffsetShapeData(paths []ShapeData, distance float64, options ShapeOpOptions) ([]ShapeData, error) {
+	result, err := pathops.Offset(toPathopsPaths(paths), distance, pathops.Options{
+		FillRule:   options.FillRule,
+		Tolerance:  options.Tolerance,
+		Join:       options.Join,
+		MiterLimit: options.MiterLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidParams)
+	}
+	return fromPathopsPaths(result), nil
+}
+
+// AddShapePathsLayer draws closed paths in composition coordinates as one white-filled group
+func AddShapePathsLayer(compositionName string, layerName string, paths []ShapeData) (LayerInfo, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("the operation produced an empty shape: %w", ErrInvalidParams)
+	}
+	white := ColorRGB{1, 1, 1}
+	return AddShapeGroupsLayer(compositionName, layerName, []ShapePathGroup{{
+		Name:      layerName,
+		Paths:     paths,
+		FillColor: &white,
+	}}, false)
+}
+
+// toPathopsPaths converts shape paths for pathops
+func toPathopsPaths(paths []ShapeData) []pathops.Path {
+	converted := make([]pathops.Path, len(paths))
+	for i, path := range paths {
+		converted[i].Closed = path.Closed
+		for j, v := range path.Vertices {
+			converted[i].Vertices = append(converted[i].Vertices, pathops.Point(v))
+			var in, out pathops.Point
+			if j < len(path.InTangents) {
+				in = pathops.Point(path.InTangents[j])
+			}
+			if j < len(path.OutTangents) {
+				out = pathops.Point(path.OutTangents[j])
+			}
+			converted[i].InTangents = append(converted[i].InTangents, in)
+			converted[i].OutTangents = append(converted[i].OutTangents, out)
+		}
+	}
+	return converted
+}
+
+// fromPathopsPaths converts pathops results into closed shape paths
+func fromPathopsPaths(paths []pathops.Path) []ShapeData {
+	converted := make([]ShapeData, len(paths))
+	for i, path := range paths {
+		converted[i].Closed = true
+		for j, v := range path.Vertices {
+			converted[i].Vertices = append(converted[i].Vertices, ShapeVertex(v))
+			converted[i].InTangents = append(converted[i].InTangents, ShapeTangent(path.InTangents[j]))
+			converted[i].OutTangents = append(converted[i].OutTangents, ShapeTangent(path.OutTangents[j]))
+		}
+	}
+	return converted
+}
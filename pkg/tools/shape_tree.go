@@ -0,0 +1,499 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+)
+
+// ShapeGroupSpec is a shape group with its own transform and ordered contents. As in the
+// After Effects contents list, items are given top to bottom: items higher up draw in front,
+// and fills, strokes and operators apply to the paths above them, including paths in groups
+// above them.
+type ShapeGroupSpec struct {
+	Name      string               `json:"name,omitempty"`
+	Transform *ShapeGroupTransform `json:"transform,omitempty"`
+	Contents  []ShapeItemSpec      `json:"contents"`
+}
+
+// ShapeItemSpec is one entry of a group's contents; exactly one of Path, Group and Operator
+// is set
+type ShapeItemSpec struct {
+	Name     string          `json:"name,omitempty"` // Path name
+	Path     *ShapeData      `json:"path,omitempty"`
+	Group    *ShapeGroupSpec `json:"group,omitempty"`
+	Operator *ShapeOperator  `json:"operator,omitempty"` // Fills, strokes and path operators
+	Other    string          `json:"other,omitempty"`    // Read back only: match name of an item that can't be described, such as a parametric rectangle
+}
+
+// ShapeGroupTransform is the transform of a shape group
+type ShapeGroupTransform struct {
+	AnchorPoint *[2]float64 `json:"anchorPoint,omitempty"`
+	Position    *[2]float64 `json:"position,omitempty"`
+	Scale       *[2]float64 `json:"scale,omitempty"` // Percent
+	Rotation    *float64    `json:"rotation,omitempty"`
+	Opacity     *float64    `json:"opacity,omitempty"`
+	Skew        *float64    `json:"skew,omitempty"`
+	SkewAxis    *float64    `json:"skewAxis,omitempty"`
+}
+
+// ParseShapeGroupSpecs decodes a shape group tree from a generic MCP argument array
+func ParseShapeGroupSpecs(raw []interface{}) ([]ShapeGroupSpec, error) {
+	var groups []ShapeGroupSpec
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize shape groups: %w", err)
+	}
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return nil, fmt.Errorf("invalid shape groups: %w", err)
+	}
+	return groups, nil
+}
+
+// prepareShapeGroupSpec validates a group tree, smoothing paths that ask for it
+func prepareShapeGroupSpec(spec *ShapeGroupSpec, location string) error {
+	for i := range spec.Contents {
+		item := &spec.Contents[i]
+		itemLocation := fmt.Sprintf("%s item %d", location, i+1)
+
+		set := 0
+		for _, present := range []bool{item.Path != nil, item.Group != nil, item.Operator != nil} {
+			if present {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("%s must have exactly one of path, group or operator: %w", itemLocation, ErrInvalidParams)
+		}
+
+		switch {
+		case item.Path != nil:
+			if len(item.Path.Vertices) == 0 {
+				return fmt.Errorf("%s has a path without vertices: %w", itemLocation, ErrInvalidParams)
+			}
+			if item.Path.Smooth {
+				smoothed, err := SmoothShapeData(*item.Path, item.Path.SmoothTolerance)
+				if err != nil {
+					return err
+				}
+				item.Path = &smoothed
+			}
+		case item.Group != nil:
+			if err := prepareShapeGroupSpec(item.Group, itemLocation); err != nil {
+				return err
+			}
+		case item.Operator != nil:
+			if err := validateShapeOperator(*item.Operator); err != nil {
+				return fmt.Errorf("%s: %w", itemLocation, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AddShapeLayerTree adds a shape layer built from a group tree in a single script run. Path
+// coordinates are composition coordinates: the layer's anchor point and position are zero.
+func AddShapeLayerTree(compositionName string, layerName string, groups []ShapeGroupSpec) (LayerInfo, error) {
+	for i := range groups {
+		if err := prepareShapeGroupSpec(&groups[i], fmt.Sprintf("group %d", i+1)); err != nil {
+			return nil, err
+		}
+	}
+
+	groupsJSON, err := json.Marshal(groups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize shape groups: %w", err)
+	}
+
+	script := `
+	try {
+		var compName = "` + escapeJSString(compositionName) + `";
+		var layerName = "` + escapeJSString(layerName) + `";
+		var groups = ` + string(groupsJSON) + `;
+		var warnings = [];
+
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+		` + shapeOperatorJS + `
+		var shapeLayer = comp.layers.addShape();
+		shapeLayer.name = layerName;
+		var counts = { groups: 0, paths: 0, operators: 0 };
+
+		// Adding properties can invalidate references to their siblings, so contents groups
+		// are looked up again by their index path before every change
+		function resolveContents(indexPath) {
+			var contents = shapeLayer.property("` + ShapeContents + `");
+			for (var k = 0; k < indexPath.length; k++) {
+				contents = contents.property(indexPath[k]).property("ADBE Vectors Group");
+			}
+			return contents;
+		}
+
+		function buildShapeGroup(parentPath, spec) {
+			var group = resolveContents(parentPath).addProperty("` + ShapeGroup + `");
+			var groupIndex = group.propertyIndex;
+			if (spec.name) group.name = spec.name;
+			var groupPath = parentPath.concat([groupIndex]);
+			counts.groups++;
+
+			var items = spec.contents || [];
+			for (var n = 0; n < items.length; n++) {
+				var entry = items[n];
+				var contents = resolveContents(groupPath);
+				if (entry.path) {
+					var pathGroup = contents.addProperty("ADBE Vector Shape - Group");
+					if (entry.name) pathGroup.name = entry.name;
+					var shape = new Shape();
+					shape.vertices = entry.path.vertices;
+					if (entry.path.inTangents && entry.path.inTangents.length > 0) shape.inTangents = entry.path.inTangents;
+					if (entry.path.outTangents && entry.path.outTangents.length > 0) shape.outTangents = entry.path.outTangents;
+					shape.closed = entry.path.closed;
+					pathGroup.property("` + ShapePath + `").setValue(shape);
+					counts.paths++;
+				} else if (entry.group) {
+					buildShapeGroup(groupPath, entry.group);
+				} else if (entry.operator) {
+					addShapeOperator(contents, entry.operator, warnings);
+					counts.operators++;
+				}
+			}
+
+			if (spec.transform) {
+				var t = resolveContents(parentPath).property(groupIndex).property("ADBE Vector Transform Group");
+				setShapeProp(t, "ADBE Vector Anchor", spec.transform.anchorPoint);
+				setShapeProp(t, "ADBE Vector Position", spec.transform.position);
+				setShapeProp(t, "ADBE Vector Scale", spec.transform.scale);
+				setShapeProp(t, "ADBE Vector Rotation", spec.transform.rotation);
+				setShapeProp(t, "ADBE Vector Group Opacity", spec.transform.opacity);
+				setShapeProp(t, "ADBE Vector Skew", spec.transform.skew);
+				setShapeProp(t, "ADBE Vector Skew Axis", spec.transform.skewAxis);
+			}
+		}
+
+		for (var g = 0; g < groups.length; g++) {
+			buildShapeGroup([], groups[g]);
+		}
+
+		// Path coordinates map directly onto the composition
+		var transform = shapeLayer.property("ADBE Transform Group");
+		transform.property("ADBE Anchor Point").setValue([0, 0, 0]);
+		transform.property("ADBE Position").setValue([0, 0, 0]);
+
+		var result = {
+			name: shapeLayer.name,
+			index: shapeLayer.index,
+			enabled: shapeLayer.enabled,
+			shapeType: "tree",
+			groups: counts.groups,
+			paths: counts.paths,
+			operators: counts.operators,
+			warnings: warnings
+		};
+
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	return runShapeTreeScript(script)
+}
+
+// GetShapeLayerTree reads a shape layer's contents back as a group tree in the format
+// AddShapeLayer accepts. Root groups are returned as "groups"; anything else at the root
+// level is returned as "rootItems". Gradient stops can't be read by script and are omitted.
+func GetShapeLayerTree(compositionName string, layerName string) (LayerInfo, error) {
+	script := `
+	try {
+		var compName = "` + escapeJSString(compositionName) + `";
+		var layerName = "` + escapeJSString(layerName) + `";
+
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+
+		// Find the shape layer
+		var shapeLayer = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === layerName) {
+				shapeLayer = comp.layer(i);
+				break;
+			}
+		}
+
+		if (!shapeLayer || shapeLayer.matchName !== "` + ShapeLayer + `") {
+			return JSON.stringify({
+				error: "Shape layer not found: " + layerName
+			});
+		}
+
+		var operatorTypes = {};
+		operatorTypes["` + ShapeTrimPaths + `"] = "trimPaths";
+		operatorTypes["` + ShapeRepeater + `"] = "repeater";
+		operatorTypes["` + ShapeOffsetPaths + `"] = "offsetPaths";
+		operatorTypes["` + ShapeRoundCorners + `"] = "roundCorners";
+		operatorTypes["` + ShapeZigZag + `"] = "zigZag";
+		operatorTypes["` + ShapeTwist + `"] = "twist";
+		operatorTypes["` + ShapeWigglePaths + `"] = "wigglePaths";
+		operatorTypes["` + ShapeFill + `"] = "fill";
+		operatorTypes["` + ShapeStroke + `"] = "stroke";
+		operatorTypes["` + ShapeGradientFill + `"] = "gradientFill";
+		operatorTypes["` + ShapeGradientStroke + `"] = "gradientStroke";
+		var lineCapNames = ["", "butt", "round", "square"];
+		var lineJoinNames = ["", "miter", "round", "bevel"];
+		var pointTypeNames = ["", "corner", "smooth"];
+
+		function read(group, matchName) {
+			var prop = group.property(matchName);
+			return prop ? prop.value : undefined;
+		}
+
+		function point(value) {
+			return value === undefined ? undefined : [value[0], value[1]];
+		}
+
+		function readStroke(prop, op) {
+			op.width = read(prop, "ADBE Vector Stroke Width");
+			op.lineCap = lineCapNames[read(prop, "ADBE Vector Stroke Line Cap")];
+			op.lineJoin = lineJoinNames[read(prop, "ADBE Vector Stroke Line Join")];
+			op.miterLimit = read(prop, "ADBE Vector Stroke Miter Limit");
+
+			var dashes = prop.property("ADBE Vector Stroke Dashes");
+			if (dashes) {
+				var values = [];
+				for (var d = 1; d <= dashes.numProperties; d++) {
+					var dash = dashes.property(d);
+					if (dash.matchName === "ADBE Vector Stroke Offset") {
+						op.dashOffset = dash.value;
+					} else {
+						values.push(dash.value);
+					}
+				}
+				if (values.length > 0) op.dashes = values;
+			}
+
+			var taper = prop.property("ADBE Vector Stroke Taper");
+			if (taper) {
+				var taperValues = {
+					startLength: read(taper, "ADBE Vector Taper Start Length"),
+					endLength: read(taper, "ADBE Vector Taper End Length"),
+					startWidth: read(taper, "ADBE Vector Taper Start Width"),
+					endWidth: read(taper, "ADBE Vector Taper End Width"),
+					startEase: read(taper, "ADBE Vector Taper Start Ease"),
+					endEase: read(taper, "ADBE Vector Taper End Ease")
+				};
+				if (taperValues.startLength || taperValues.endLength) op.taper = taperValues;
+			}
+		}
+
+		function readGradient(prop, op) {
+			op.gradientType = read(prop, "ADBE Vector Grad Type") === 2 ? "radial" : "linear";
+			op.startPoint = point(read(prop, "ADBE Vector Grad Start Pt"));
+			op.endPoint = point(read(prop, "ADBE Vector Grad End Pt"));
+			op.highlightLength = read(prop, "ADBE Vector Grad HiLite Length");
+			op.highlightAngle = read(prop, "ADBE Vector Grad HiLite Angle");
+		}
+
+		function readOperator(prop, type) {
+			var op = { type: type, name: prop.name };
+			switch (type) {
+			case "trimPaths":
+				op.start = read(prop, "ADBE Vector Trim Start");
+				op.end = read(prop, "ADBE Vector Trim End");
+				op.offset = read(prop, "ADBE Vector Trim Offset");
+				op.trimMultiple = read(prop, "ADBE Vector Trim Type") === 2 ? "individually" : "simultaneously";
+				break;
+			case "repeater":
+				op.copies = read(prop, "ADBE Vector Repeater Copies");
+				op.offset = read(prop, "ADBE Vector Repeater Offset");
+				op.composite = read(prop, "ADBE Vector Repeater Order") === 2 ? "above" : "below";
+				var rt = prop.property("ADBE Vector Repeater Transform");
+				op.anchorPoint = point(read(rt, "ADBE Vector Repeater Anchor"));
+				op.position = point(read(rt, "ADBE Vector Repeater Position"));
+				op.scale = point(read(rt, "ADBE Vector Repeater Scale"));
+				op.rotation = read(rt, "ADBE Vector Repeater Rotation");
+				op.startOpacity = read(rt, "ADBE Vector Repeater Opacity 1");
+				op.endOpacity = read(rt, "ADBE Vector Repeater Opacity 2");
+				break;
+			case "offsetPaths":
+				op.amount = read(prop, "ADBE Vector Offset Amount");
+				op.lineJoin = lineJoinNames[read(prop, "ADBE Vector Offset Line Join")];
+				op.miterLimit = read(prop, "ADBE Vector Offset Miter Limit");
+				op.copies = read(prop, "ADBE Vector Offset Copies");
+				op.copyOffset = read(prop, "ADBE Vector Offset Copy Offset");
+				break;
+			case "roundCorners":
+				op.radius = read(prop, "ADBE Vector RoundCorner Radius");
+				break;
+			case "zigZag":
+				op.size = read(prop, "ADBE Vector Zigzag Size");
+				op.detail = read(prop, "ADBE Vector Zigzag Detail");
+				op.pointType = pointTypeNames[read(prop, "ADBE Vector Zigzag Points")];
+				break;
+			case "twist":
+				op.angle = read(prop, "ADBE Vector Twist Angle");
+				op.center = point(read(prop, "ADBE Vector Twist Center"));
+				break;
+			case "wigglePaths":
+				op.size = read(prop, "ADBE Vector Roughen Size");
+				op.detail = read(prop, "ADBE Vector Roughen Detail");
+				op.pointType = pointTypeNames[read(prop, "ADBE Vector Roughen Points")];
+				op.wigglesPerSecond = read(prop, "ADBE Vector Temporal Freq");
+				op.correlation = read(prop, "ADBE Vector Correlation");
+				op.temporalPhase = read(prop, "ADBE Vector Temporal Phase");
+				op.spatialPhase = read(prop, "ADBE Vector Spatial Phase");
+				op.randomSeed = read(prop, "ADBE Vector Random Seed");
+				break;
+			case "fill":
+				var fillColor = read(prop, "ADBE Vector Fill Color");
+				op.color = [fillColor[0], fillColor[1], fillColor[2]];
+				op.opacity = read(prop, "ADBE Vector Fill Opacity");
+				op.fillRule = read(prop, "ADBE Vector Fill Rule") === 2 ? "evenodd" : "nonzero";
+				break;
+			case "stroke":
+				var strokeColor = read(prop, "ADBE Vector Stroke Color");
+				op.color = [strokeColor[0], strokeColor[1], strokeColor[2]];
+				op.opacity = read(prop, "ADBE Vector Stroke Opacity");
+				readStroke(prop, op);
+				break;
+			case "gradientFill":
+				op.opacity = read(prop, "ADBE Vector Fill Opacity");
+				op.fillRule = read(prop, "ADBE Vector Fill Rule") === 2 ? "evenodd" : "nonzero";
+				readGradient(prop, op);
+				break;
+			case "gradientStroke":
+				op.opacity = read(prop, "ADBE Vector Stroke Opacity");
+				readStroke(prop, op);
+				readGradient(prop, op);
+				break;
+			}
+			return op;
+		}
+
+		function readContents(contents) {
+			var items = [];
+			for (var p = 1; p <= contents.numProperties; p++) {
+				var prop = contents.property(p);
+				if (prop.matchName === "` + ShapeGroup + `") {
+					items.push({ group: readGroup(prop) });
+				} else if (prop.matchName === "ADBE Vector Shape - Group") {
+					var shape = prop.property("` + ShapePath + `").value;
+					items.push({
+						name: prop.name,
+						path: {
+							vertices: shape.vertices,
+							inTangents: shape.inTangents,
+							outTangents: shape.outTangents,
+							closed: shape.closed
+						}
+					});
+				} else if (operatorTypes[prop.matchName]) {
+					items.push({ operator: readOperator(prop, operatorTypes[prop.matchName]) });
+				} else {
+					items.push({ name: prop.name, other: prop.matchName });
+				}
+			}
+			return items;
+		}
+
+		function readGroup(group) {
+			var t = group.property("ADBE Vector Transform Group");
+			return {
+				name: group.name,
+				transform: {
+					anchorPoint: point(read(t, "ADBE Vector Anchor")),
+					position: point(read(t, "ADBE Vector Position")),
+					scale: point(read(t, "ADBE Vector Scale")),
+					rotation: read(t, "ADBE Vector Rotation"),
+					opacity: read(t, "ADBE Vector Group Opacity"),
+					skew: read(t, "ADBE Vector Skew"),
+					skewAxis: read(t, "ADBE Vector Skew Axis")
+				},
+				contents: readContents(group.property("ADBE Vectors Group"))
+			};
+		}
+
+		var rootItems = readContents(shapeLayer.property("` + ShapeContents + `"));
+		var groups = [];
+		var others = [];
+		for (var r = 0; r < rootItems.length; r++) {
+			if (rootItems[r].group) {
+				groups.push(rootItems[r].group);
+			} else {
+				others.push(rootItems[r]);
+			}
+		}
+
+		var result = {
+			name: shapeLayer.name,
+			index: shapeLayer.index,
+			groups: groups,
+			rootItems: others
+		};
+
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	return runShapeTreeScript(script)
+}
+
+// runShapeTreeScript executes a shape tree script and decodes its result
+func runShapeTreeScript(script string) (LayerInfo, error) {
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var layerInfo LayerInfo
+		if err := json.Unmarshal([]byte(resultStr), &layerInfo); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := layerInfo["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return layerInfo, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
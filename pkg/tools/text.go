@@ -34,6 +34,31 @@ type TextOptions struct {
 	FauxItalic   *bool    `json:"fauxItalic,omitempty"`
 	AllCaps      *bool    `json:"allCaps,omitempty"`
 	SmallCaps    *bool    `json:"smallCaps,omitempty"`
+	
+	// Paragraph (box) text
+	BoxSize         [2]float64  `json:"boxSize,omitempty"`     // Width and height of the text box; creates box text when set
+	BoxPosition     *[2]float64 `json:"boxPosition,omitempty"` // Top-left corner of the text box in composition space
+	FirstLineIndent float64     `json:"firstLineIndent,omitempty"`
+	SpaceBefore     float64     `json:"spaceBefore,omitempty"`
+	SpaceAfter      float64     `json:"spaceAfter,omitempty"`
+	Vertical        *bool       `json:"vertical,omitempty"`
+	
+	// Text on path
+	Path *TextPathSettings `json:"path,omitempty"`
+	
+	// Shrink or wrap the text to fit a box or the title-safe area
+	AutoFit *TextAutoFit `json:"autoFit,omitempty"`
+}
+
+// TextPathSettings binds a text layer to a mask path
+type TextPathSettings struct {
+	MaskName            string     `json:"maskName,omitempty"` // Existing mask on the text layer, or the name for a new mask
+	Shape               *ShapeData `json:"shape,omitempty"`    // Creates a new mask from this shape, in composition space
+	FirstMargin         *float64   `json:"firstMargin,omitempty"`
+	LastMargin          *float64   `json:"lastMargin,omitempty"`
+	PerpendicularToPath *bool      `json:"perpendicularToPath,omitempty"`
+	ForceAlignment      *bool      `json:"forceAlignment,omitempty"`
+	ReversePath         *bool      `json:"reversePath,omitempty"`
 }
 
 // TextModifications represents modifications to be applied to a text layer
@@ -49,6 +74,10 @@ func AddTextLayer(compName string, layerName string, text string, options *TextO
 	justification := "CENTER_JUSTIFY" // Valid values: LEFT_JUSTIFY, CENTER_JUSTIFY, RIGHT_JUSTIFY
 	applyFill := true
 	tracking := 0.0
+	boxSize := [2]float64{0, 0}
+	boxPositionJS := "null"
+	textPathScript := ""
+	autoFitScript := ""
 	
 	// Apply provided options if available
 	if options != nil {
@@ -88,6 +117,62 @@ func AddTextLayer(compName string, layerName string, text string, options *TextO
 		if options.Tracking != 0 {
 			tracking = options.Tracking
 		}
+		if options.BoxSize[0] > 0 && options.BoxSize[1] > 0 {
+			boxSize = options.BoxSize
+		}
+		if options.BoxPosition != nil {
+			boxPositionJS = fmt.Sprintf("[%f, %f]", options.BoxPosition[0], options.BoxPosition[1])
+		}
+		if options.Path != nil {
+			pathJS, err := textPathJS(options.Path)
+			if err != nil {
+				return nil, err
+			}
+			textPathScript = pathJS
+		}
+		if options.AutoFit != nil {
+			fitJS, err := textAutoFitJS(options.AutoFit)
+			if err != nil {
+				return nil, err
+			}
+			autoFitScript = fitJS
+		}
+	}
+
+	// Resolve the font to an installed PostScript name
+	fontStyle := ""
+	userFont := false
+	additionalOptions := options
+	if options != nil {
+		fontStyle = options.FontStyle
+		userFont = options.FontName != "" || options.FontFamily != ""
+	}
+	var warnings []string
+	resolution, err := ResolveFont(fontName, fontStyle)
+	if err != nil {
+		// The default font is only a preference, so don't fail when it is missing
+		if userFont {
+			return nil, err
+		}
+		resolution = FontResolution{PostScriptName: fontName}
+	}
+	fontName = resolution.PostScriptName
+	if resolution.Warning != "" {
+		warnings = append(warnings, resolution.Warning)
+	}
+	if resolution.StyleMatched && fontStyle != "" {
+		// The font itself provides the style, so skip faux bold/italic
+		styledOptions := *options
+		styledOptions.FontStyle = ""
+		additionalOptions = &styledOptions
+	}
+	substitutionCheck := ""
+	if userFont {
+		substitutionCheck = fontSubstitutionCheckJS(fontName)
+	}
+	warningsJSON, err := json.Marshal(append([]string{}, warnings...))
+	if err != nil {
+		return nil, err
 	}
 
 	// Construct the script to add a text layer
@@ -120,9 +205,16 @@ func AddTextLayer(compName string, layerName string, text string, options *TextO
 			});
 		}
 		
-		// Add the text layer
-		var textLayer = comp.layers.addText(textContent);
+		// Add the text layer, as paragraph text when a box size is given
+		var boxSize = [` + fmt.Sprintf("%f, %f", boxSize[0], boxSize[1]) + `];
+		var textLayer = null;
+		if (boxSize[0] > 0 && boxSize[1] > 0) {
+			textLayer = comp.layers.addBoxText(boxSize, textContent);
+		} else {
+			textLayer = comp.layers.addText(textContent);
+		}
 		textLayer.name = layerName;
+		var warnings = ` + string(warningsJSON) + `;
 		var textProp = textLayer.property("Source Text");
 		var textDocument = textProp.value;
 		
@@ -135,18 +227,25 @@ func AddTextLayer(compName string, layerName string, text string, options *TextO
 		textDocument.tracking = tracking;
 		
 		// Apply additional properties if provided
-		` + getAdditionalTextProperties(options) + `
+		` + getAdditionalTextProperties(additionalOptions) + `
 		
 		// Apply the text document
 		textProp.setValue(textDocument);
+		` + substitutionCheck + `
 		
 		// Set position if specified
-		if (position[0] !== 0 || position[1] !== 0) {
+		var boxPosition = ` + boxPositionJS + `;
+		if (boxPosition && textLayer.sourceText.value.boxText) {
+			// Place the top-left corner of the text box at the requested point
+			var boxTextPos = textLayer.sourceText.value.boxTextPos;
+			textLayer.position.setValue([boxPosition[0] - boxTextPos[0], boxPosition[1] - boxTextPos[1], 0]);
+		} else if (position[0] !== 0 || position[1] !== 0) {
 			textLayer.position.setValue([position[0], position[1], 0]);
 		} else {
 			// Center the text in the composition
 			textLayer.position.setValue([comp.width/2, comp.height/2, 0]);
 		}
+		` + autoFitScript + textPathScript + `
 		
 		// Return information about the created text layer
 		var result = {
@@ -155,8 +254,17 @@ func AddTextLayer(compName string, layerName string, text string, options *TextO
 			id: textLayer.index,
 			text: textContent,
 			fontSize: fontSize,
-			fontName: fontName
+			fontName: fontName,
+			boxText: textLayer.sourceText.value.boxText
 		};
+		if (typeof autoFitResult !== "undefined" && autoFitResult) {
+			result.fontSize = autoFitResult.fontSize;
+			result.text = textLayer.sourceText.value.text;
+			result.autoFit = autoFitResult;
+		}
+		if (warnings.length > 0) {
+			result.warnings = warnings;
+		}
 		
 		return returnjson(result);
 	} catch (err) {
@@ -260,9 +368,98 @@ func getAdditionalTextProperties(options *TextOptions) string {
 		textDocument.leading = ` + fmt.Sprintf("%f", options.Leading) + `;`
 	}
 	
+	// Paragraph options (writable only in newer After Effects versions)
+	if options.FirstLineIndent != 0 {
+		script += paragraphPropertyJS("firstLineIndent", fmt.Sprintf("%f", options.FirstLineIndent))
+	}
+	
+	if options.SpaceBefore != 0 {
+		script += paragraphPropertyJS("spaceBefore", fmt.Sprintf("%f", options.SpaceBefore))
+	}
+	
+	if options.SpaceAfter != 0 {
+		script += paragraphPropertyJS("spaceAfter", fmt.Sprintf("%f", options.SpaceAfter))
+	}
+	
+	if options.Vertical != nil {
+		script += paragraphPropertyJS("lineOrientation", lineOrientationJS(*options.Vertical))
+	}
+	
 	return script
 }
 
+// paragraphPropertyJS sets a text document property that older After Effects versions
+// treat as read-only, recording a warning instead of failing when it can't be set
+func paragraphPropertyJS(property string, value string) string {
+	return `
+		try {
+			textDocument.` + property + ` = ` + value + `;
+		} catch (paragraphErr) {
+			warnings.push("Could not set ` + property + `: " + paragraphErr.toString());
+		}`
+}
+
+// lineOrientationJS returns the ExtendScript line orientation for horizontal or vertical text
+func lineOrientationJS(vertical bool) string {
+	if vertical {
+		return "LineOrientation.VERTICAL_RIGHT_TO_LEFT"
+	}
+	return "LineOrientation.HORIZONTAL"
+}
+
+// textPathJS returns the ExtendScript that binds textLayer to a mask path,
+// creating the mask from a shape when one is given
+func textPathJS(settings *TextPathSettings) (string, error) {
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize text path settings: %w", err)
+	}
+
+	return `
+		// Bind the text to a mask path
+		var pathSettings = ` + string(settingsJSON) + `;
+		var masks = textLayer.property("` + MaskGroup + `");
+		var pathMask = null;
+		if (pathSettings.shape) {
+			// Mask vertices live in layer space; convert from composition space
+			var layerPos = textLayer.transform.position.value;
+			var layerAnchor = textLayer.transform.anchorPoint.value;
+			var maskVertices = [];
+			for (var i = 0; i < pathSettings.shape.vertices.length; i++) {
+				var v = pathSettings.shape.vertices[i];
+				maskVertices.push([v[0] - layerPos[0] + layerAnchor[0], v[1] - layerPos[1] + layerAnchor[1]]);
+			}
+			var maskShape = new Shape();
+			maskShape.vertices = maskVertices;
+			if (pathSettings.shape.inTangents) maskShape.inTangents = pathSettings.shape.inTangents;
+			if (pathSettings.shape.outTangents) maskShape.outTangents = pathSettings.shape.outTangents;
+			maskShape.closed = pathSettings.shape.closed;
+			pathMask = masks.addProperty("ADBE Mask Atom");
+			pathMask.name = pathSettings.maskName || "Text Path";
+			pathMask.maskMode = MaskMode.NONE;
+			pathMask.property("ADBE Mask Shape").setValue(maskShape);
+		} else if (pathSettings.maskName) {
+			pathMask = masks.property(pathSettings.maskName);
+		} else if (masks.numProperties > 0) {
+			pathMask = masks.property(1);
+		}
+		
+		if (!pathMask) {
+			return JSON.stringify({
+				error: "Mask not found for text path: " + (pathSettings.maskName || "(no masks on layer)")
+			});
+		}
+		
+		var pathOptions = textLayer.property("` + TextSourceText + `").property("` + TextPathOptions + `");
+		pathOptions.property("ADBE Text Path").setValue(pathMask.propertyIndex);
+		if (pathSettings.reversePath !== undefined) pathOptions.property("ADBE Text Reverse Path").setValue(pathSettings.reversePath ? 1 : 0);
+		if (pathSettings.perpendicularToPath !== undefined) pathOptions.property("ADBE Text Perpendicular To Path").setValue(pathSettings.perpendicularToPath ? 1 : 0);
+		if (pathSettings.forceAlignment !== undefined) pathOptions.property("ADBE Text Force Align Path").setValue(pathSettings.forceAlignment ? 1 : 0);
+		if (pathSettings.firstMargin !== undefined) pathOptions.property("ADBE Text First Margin").setValue(pathSettings.firstMargin);
+		if (pathSettings.lastMargin !== undefined) pathOptions.property("ADBE Text Last Margin").setValue(pathSettings.lastMargin);
+	`, nil
+}
+
 // ModifyTextLayer modifies an existing text layer in a composition
 func ModifyTextLayer(compName string, layerName string, modifications TextModifications) (TextLayerInfo, error) {
 	// Build the script
@@ -316,6 +513,7 @@ func ModifyTextLayer(compName string, layerName string, modifications TextModifi
 		
 		// Apply modifications
 		var modified = false;
+		var warnings = [];
 	`
 
 	// Add modifications to the script
@@ -326,43 +524,78 @@ func ModifyTextLayer(compName string, layerName string, modifications TextModifi
 		`
 	}
 
-	if fontSize, ok := modifications["fontSize"].(float64); ok {
+	var fontWarnings []string
+
+	// Inline markup replaces the text and adds styled runs
+	var styleRuns []TextStyleRun
+	if markup, ok := modifications["markup"].(string); ok {
+		plainText, markupRuns, err := ParseTextMarkup(markup)
+		if err != nil {
+			return nil, fmt.Errorf("invalid text markup: %w", err)
+		}
 		script += `
-		textDocument.fontSize = ` + fmt.Sprintf("%f", fontSize) + `;
+		textDocument.text = "` + escapeJSString(plainText) + `";
 		modified = true;
 		`
+		styleRuns = append(styleRuns, markupRuns...)
 	}
 
-	if fontName, ok := modifications["fontName"].(string); ok {
+	if rawStyles, ok := modifications["styles"].([]interface{}); ok {
+		runs, err := ParseTextStyleRuns(rawStyles)
+		if err != nil {
+			return nil, err
+		}
+		styleRuns = append(styleRuns, runs...)
+	}
+
+	if fontSize, ok := modifications["fontSize"].(float64); ok {
 		script += `
-		textDocument.font = "` + fontName + `";
+		textDocument.fontSize = ` + fmt.Sprintf("%f", fontSize) + `;
 		modified = true;
 		`
 	}
-	
-	// Handle fontFamily as an alias for font
-	if fontFamily, ok := modifications["fontFamily"].(string); ok {
+
+	// Resolve the font to an installed PostScript name; fontFamily takes precedence over fontName
+	fontQuery, _ := modifications["fontName"].(string)
+	if fontFamily, ok := modifications["fontFamily"].(string); ok && fontFamily != "" {
+		fontQuery = fontFamily
+	}
+	fontStyle, _ := modifications["fontStyle"].(string)
+	fauxStyle := fontStyle
+	resolvedFont := ""
+	if fontQuery != "" {
+		resolution, err := ResolveFont(fontQuery, fontStyle)
+		if err != nil {
+			return nil, err
+		}
+		resolvedFont = resolution.PostScriptName
+		if resolution.Warning != "" {
+			fontWarnings = append(fontWarnings, resolution.Warning)
+		}
+		if resolution.StyleMatched {
+			fauxStyle = ""
+		}
 		script += `
-		textDocument.font = "` + fontFamily + `";
+		textDocument.font = "` + escapeJSString(resolvedFont) + `";
 		modified = true;
 		`
 	}
 	
-	// Handle fontStyle by converting it to fauxBold and fauxItalic
-	if fontStyle, ok := modifications["fontStyle"].(string); ok {
+	// Handle fontStyle the font doesn't provide by converting it to fauxBold and fauxItalic
+	if fauxStyle != "" {
 		// Instead of trying to set read-only fontStyle property directly,
 		// we use fauxBold and fauxItalic based on the style name
-		if fontStyle == "Bold" || fontStyle == "bold" {
+		if fauxStyle == "Bold" || fauxStyle == "bold" {
 			script += `
 			textDocument.fauxBold = true;
 			modified = true;
 			`
-		} else if fontStyle == "Italic" || fontStyle == "italic" {
+		} else if fauxStyle == "Italic" || fauxStyle == "italic" {
 			script += `
 			textDocument.fauxItalic = true;
 			modified = true;
 			`
-		} else if fontStyle == "Bold Italic" || fontStyle == "bold italic" || fontStyle == "Bold-Italic" {
+		} else if fauxStyle == "Bold Italic" || fauxStyle == "bold italic" || fauxStyle == "Bold-Italic" {
 			script += `
 			textDocument.fauxBold = true;
 			textDocument.fauxItalic = true;
@@ -493,13 +726,122 @@ func ModifyTextLayer(compName string, layerName string, modifications TextModifi
 		`
 	}
 
+	// Handle paragraph options
+	if firstLineIndent, ok := modifications["firstLineIndent"].(float64); ok {
+		script += paragraphPropertyJS("firstLineIndent", fmt.Sprintf("%f", firstLineIndent)) + `
+		modified = true;
+		`
+	}
+	
+	if spaceBefore, ok := modifications["spaceBefore"].(float64); ok {
+		script += paragraphPropertyJS("spaceBefore", fmt.Sprintf("%f", spaceBefore)) + `
+		modified = true;
+		`
+	}
+	
+	if spaceAfter, ok := modifications["spaceAfter"].(float64); ok {
+		script += paragraphPropertyJS("spaceAfter", fmt.Sprintf("%f", spaceAfter)) + `
+		modified = true;
+		`
+	}
+	
+	if vertical, ok := modifications["vertical"].(bool); ok {
+		script += paragraphPropertyJS("lineOrientation", lineOrientationJS(vertical)) + `
+		modified = true;
+		`
+	}
+	
+	// Resize the text box of paragraph text
+	if boxSize, ok := modifications["boxSize"].([]interface{}); ok && len(boxSize) >= 2 {
+		w, _ := boxSize[0].(float64)
+		h, _ := boxSize[1].(float64)
+		script += `
+		if (textDocument.boxText) {
+			textDocument.boxTextSize = [` + fmt.Sprintf("%f, %f", w, h) + `];
+			modified = true;
+		} else {
+			warnings.push("boxSize ignored: layer is point text, not paragraph text");
+		}
+		`
+	}
+
+	// Styled runs are applied after whole-text properties so they take precedence
+	styleApplyJS := ""
+	styleFallbackJS := ""
+	for i := range styleRuns {
+		if styleRuns[i].FontName == "" {
+			continue
+		}
+		resolution, err := ResolveFont(styleRuns[i].FontName, "")
+		if err != nil {
+			return nil, err
+		}
+		styleRuns[i].FontName = resolution.PostScriptName
+		if resolution.Warning != "" {
+			fontWarnings = append(fontWarnings, resolution.Warning)
+		}
+	}
+	if len(styleRuns) > 0 {
+		applyJS, fallbackJS, err := textStyleRunsJS(styleRuns)
+		if err != nil {
+			return nil, err
+		}
+		styleApplyJS = applyJS
+		styleFallbackJS = fallbackJS
+	}
+
 	// Complete the script
 	script += `
 		// Apply the text document if modified
 		if (modified) {
 			textProp.setValue(textDocument);
 		}
-		
+		`
+	if resolvedFont != "" {
+		script += fontSubstitutionCheckJS(resolvedFont)
+	}
+	
+	// Fit the text into a box or the title-safe area before any split into segment layers
+	if fitRaw, ok := modifications["autoFit"].(map[string]interface{}); ok {
+		fit, err := ParseTextAutoFit(fitRaw)
+		if err != nil {
+			return nil, err
+		}
+		fitJS, err := textAutoFitJS(fit)
+		if err != nil {
+			return nil, err
+		}
+		script += fitJS
+	}
+	
+	// Style the runs once the text is fitted, since fitting sets one font size for all of it
+	if styleApplyJS != "" {
+		script += styleApplyJS + `
+		if (styleMethod !== null) {
+			textProp.setValue(textDocument);
+		}
+		`
+	}
+	script += styleFallbackJS
+	for _, warning := range fontWarnings {
+		script += `
+		warnings.push("` + escapeJSString(warning) + `");`
+	}
+	
+	// Bind the text to a mask path
+	if pathRaw, ok := modifications["path"].(map[string]interface{}); ok {
+		pathSettings, err := ParseTextPathSettings(pathRaw)
+		if err != nil {
+			return nil, err
+		}
+		pathJS, err := textPathJS(pathSettings)
+		if err != nil {
+			return nil, err
+		}
+		script += pathJS
+	}
+
+	script += `
 		// Return information about the modified text layer
 		var result = {
 			name: textLayer.name,
@@ -507,7 +849,133 @@ func ModifyTextLayer(compName string, layerName string, modifications TextModifi
 			id: textLayer.index,
 			text: textDocument.text,
 			fontSize: textDocument.fontSize,
-			fontName: textDocument.font
+			fontName: textDocument.font,
+			boxText: textDocument.boxText
+		};
+		if (typeof autoFitResult !== "undefined" && autoFitResult) {
+			result.autoFit = autoFitResult;
+		}
+		if (typeof styleMethod !== "undefined" && styleMethod !== null) {
+			result.styleMethod = styleMethod;
+			if (styleSegmentLayers.length > 0) {
+				result.segmentLayers = styleSegmentLayers;
+			}
+		}
+		if (warnings.length > 0) {
+			result.warnings = warnings;
+		}
+		
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+		
+		// Parse the JSON result into a structured object
+		var textLayerDetails TextLayerInfo
+		if err := json.Unmarshal([]byte(resultStr), &textLayerDetails); err != nil {
+			return nil, err
+		}
+		
+		// Check for error in result
+		if errMsg, hasErr := textLayerDetails["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+		
+		return textLayerDetails, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// ParseTextPathSettings decodes text path settings from a generic MCP argument map
+func ParseTextPathSettings(raw map[string]interface{}) (*TextPathSettings, error) {
+	var settings TextPathSettings
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize text path settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("invalid text path settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// SetTextPath binds an existing text layer to a mask path
+func SetTextPath(compName string, layerName string, settings TextPathSettings) (TextLayerInfo, error) {
+	pathJS, err := textPathJS(&settings)
+	if err != nil {
+		return nil, err
+	}
+
+	script := `
+	try {
+		var compName = "` + compName + `";
+		var layerName = "` + layerName + `";
+		
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+		
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+		
+		// Find the text layer
+		var textLayer = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === layerName) {
+				textLayer = comp.layer(i);
+				break;
+			}
+		}
+		
+		if (!textLayer) {
+			return JSON.stringify({
+				error: "Layer not found: " + layerName
+			});
+		}
+		
+		if (!(textLayer instanceof TextLayer)) {
+			return JSON.stringify({
+				error: "Layer is not a text layer: " + layerName
+			});
+		}
+		` + pathJS + `
+		
+		var result = {
+			name: textLayer.name,
+			index: textLayer.index,
+			path: {
+				mask: pathMask.name,
+				maskIndex: pathMask.propertyIndex,
+				reversePath: pathOptions.property("ADBE Text Reverse Path").value === 1,
+				perpendicularToPath: pathOptions.property("ADBE Text Perpendicular To Path").value === 1,
+				forceAlignment: pathOptions.property("ADBE Text Force Align Path").value === 1,
+				firstMargin: pathOptions.property("ADBE Text First Margin").value,
+				lastMargin: pathOptions.property("ADBE Text Last Margin").value
+			}
 		};
 		
 		return returnjson(result);
@@ -0,0 +1,487 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sunqirui1987/ae-mcp/pkg/ae"
+	"sort"
+	"strings"
+)
+
+// Text animator match names
+const (
+	TextAnimator           = "ADBE Text Animator"
+	TextAnimatorProperties = "ADBE Text Animator Properties"
+	TextSelectors          = "ADBE Text Selectors"
+	TextRangeSelector      = "ADBE Text Selector"
+	TextRangeAdvanced      = "ADBE Text Range Advanced"
+)
+
+// TextAnimatorValues represents the animator properties to add and the values they animate to.
+// Nil fields are not added to the animator.
+type TextAnimatorValues struct {
+	Position        []float64 `json:"position,omitempty"` // [x, y] or [x, y, z] offset
+	AnchorPoint     []float64 `json:"anchorPoint,omitempty"`
+	Scale           []float64 `json:"scale,omitempty"` // Percent, [x, y] or [x, y, z]
+	Rotation        *float64  `json:"rotation,omitempty"`
+	Skew            *float64  `json:"skew,omitempty"`
+	Opacity         *float64  `json:"opacity,omitempty"`
+	Tracking        *float64  `json:"tracking,omitempty"`
+	FillColor       *ColorRGB `json:"fillColor,omitempty"`
+	StrokeColor     *ColorRGB `json:"strokeColor,omitempty"`
+	StrokeWidth     *float64  `json:"strokeWidth,omitempty"`
+	Blur            []float64 `json:"blur,omitempty"` // [x, y]
+	CharacterOffset *float64  `json:"characterOffset,omitempty"`
+}
+
+// TextSelectorKeyframe represents a keyframe on a range selector property
+type TextSelectorKeyframe struct {
+	Property string  `json:"property"` // start, end or offset
+	Time     float64 `json:"time"`     // Composition time in seconds
+	Value    float64 `json:"value"`
+	Ease     bool    `json:"ease,omitempty"` // Ease in and out of this keyframe
+}
+
+// TextRangeSelectorOptions represents the settings of a text animator range selector
+type TextRangeSelectorOptions struct {
+	Start      *float64               `json:"start,omitempty"`
+	End        *float64               `json:"end,omitempty"`
+	Offset     *float64               `json:"offset,omitempty"`
+	Units      string                 `json:"units,omitempty"`   // percentage or index
+	BasedOn    string                 `json:"basedOn,omitempty"` // characters, characters_excluding_spaces, words or lines
+	Mode       string                 `json:"mode,omitempty"`    // add, subtract, intersect, min, max or difference
+	Amount     *float64               `json:"amount,omitempty"`
+	Shape      string                 `json:"shape,omitempty"` // square, ramp_up, ramp_down, triangle, round or smooth
+	Smoothness *float64               `json:"smoothness,omitempty"`
+	EaseHigh   *float64               `json:"easeHigh,omitempty"`
+	EaseLow    *float64               `json:"easeLow,omitempty"`
+	Randomize  *bool                  `json:"randomize,omitempty"`
+	RandomSeed *int                   `json:"randomSeed,omitempty"`
+	Keyframes  []TextSelectorKeyframe `json:"keyframes,omitempty"`
+}
+
+// TextAnimatorSpec describes a text animator with its properties and range selector
+type TextAnimatorSpec struct {
+	Name       string                   `json:"name,omitempty"`
+	Properties TextAnimatorValues       `json:"properties"`
+	Selector   TextRangeSelectorOptions `json:"selector"`
+}
+
+// Range selector popup values, as After Effects numbers them
+var (
+	textSelectorUnits = map[string]int{
+		"percentage": 1,
+		"index":      2,
+	}
+	textSelectorBasedOn = map[string]int{
+		"characters":                  1,
+		"characters_excluding_spaces": 2,
+		"words":                       3,
+		"lines":                       4,
+	}
+	textSelectorModes = map[string]int{
+		"add":        1,
+		"subtract":   2,
+		"intersect":  3,
+		"min":        4,
+		"max":        5,
+		"difference": 6,
+	}
+	textSelectorShapes = map[string]int{
+		"square":    1,
+		"ramp_up":   2,
+		"ramp_down": 3,
+		"triangle":  4,
+		"round":     5,
+		"smooth":    6,
+	}
+)
+
+// lookupSelectorOption converts a range selector option name to its popup value
+func lookupSelectorOption(options map[string]int, kind string, name string) (int, error) {
+	if name == "" {
+		return 0, nil
+	}
+	key := strings.ToLower(strings.NewReplacer(" ", "_", "-", "_").Replace(name))
+	if value, ok := options[key]; ok {
+		return value, nil
+	}
+
+	valid := make([]string, 0, len(options))
+	for option := range options {
+		valid = append(valid, option)
+	}
+	sort.Strings(valid)
+	return 0, fmt.Errorf("invalid %s: %s. Must be one of: %s", kind, name, strings.Join(valid, ", "))
+}
+
+// TextAnimatorPresets returns the built-in text animator presets, keyed by name.
+// Keyframe times are relative to the layer in point and scaled to the requested duration.
+func TextAnimatorPresets() map[string]TextAnimatorSpec {
+	zero := 0.0
+	hundred := 100.0
+	fifty := 50.0
+	return map[string]TextAnimatorSpec{
+		// Characters appear one at a time
+		"typewriter": {
+			Name:       "Typewriter",
+			Properties: TextAnimatorValues{Opacity: &zero},
+			Selector: TextRangeSelectorOptions{
+				BasedOn: "characters",
+				Shape:   "square",
+				End:     &hundred,
+				Keyframes: []TextSelectorKeyframe{
+					{Property: "start", Time: 0, Value: 0},
+					{Property: "start", Time: 1, Value: 100},
+				},
+			},
+		},
+		// Words rise into place while fading in
+		"fade-up-by-word": {
+			Name: "Fade Up By Word",
+			Properties: TextAnimatorValues{
+				Position: []float64{0, 60, 0},
+				Opacity:  &zero,
+			},
+			Selector: TextRangeSelectorOptions{
+				BasedOn:  "words",
+				Shape:    "ramp_up",
+				EaseHigh: &fifty,
+				EaseLow:  &fifty,
+				Keyframes: []TextSelectorKeyframe{
+					{Property: "offset", Time: 0, Value: -100, Ease: true},
+					{Property: "offset", Time: 1, Value: 100, Ease: true},
+				},
+			},
+		},
+		// Random characters settle into the final text
+		"scramble": {
+			Name: "Scramble",
+			Properties: TextAnimatorValues{
+				CharacterOffset: &fifty,
+			},
+			Selector: TextRangeSelectorOptions{
+				BasedOn:   "characters_excluding_spaces",
+				Shape:     "square",
+				Randomize: boolPtr(true),
+				End:       &hundred,
+				Keyframes: []TextSelectorKeyframe{
+					{Property: "start", Time: 0, Value: 0},
+					{Property: "start", Time: 1, Value: 100},
+				},
+			},
+		},
+		// Characters come into focus from a blur
+		"blur-in": {
+			Name: "Blur In",
+			Properties: TextAnimatorValues{
+				Blur:    []float64{40, 40},
+				Opacity: &zero,
+			},
+			Selector: TextRangeSelectorOptions{
+				BasedOn: "characters",
+				Shape:   "ramp_up",
+				Keyframes: []TextSelectorKeyframe{
+					{Property: "offset", Time: 0, Value: -100, Ease: true},
+					{Property: "offset", Time: 1, Value: 100, Ease: true},
+				},
+			},
+		},
+		// Letter spacing closes in while the text fades in
+		"tracking-in": {
+			Name: "Tracking In",
+			Properties: TextAnimatorValues{
+				Tracking: &hundred,
+				Opacity:  &zero,
+			},
+			Selector: TextRangeSelectorOptions{
+				BasedOn: "characters",
+				Shape:   "square",
+				Start:   &zero,
+				Keyframes: []TextSelectorKeyframe{
+					{Property: "end", Time: 0, Value: 100, Ease: true},
+					{Property: "end", Time: 1, Value: 0, Ease: true},
+				},
+			},
+		},
+	}
+}
+
+// boolPtr returns a pointer to a bool value
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+// AddTextAnimator adds a text animator with a range selector to a text layer
+func AddTextAnimator(compName string, layerName string, spec TextAnimatorSpec) (TextLayerInfo, error) {
+	return addTextAnimator(compName, layerName, spec, false)
+}
+
+// addTextAnimator adds a text animator; with fromInPoint, selector keyframe times are
+// relative to the layer in point instead of composition times
+func addTextAnimator(compName string, layerName string, spec TextAnimatorSpec, fromInPoint bool) (TextLayerInfo, error) {
+	// Convert option names to the popup values After Effects expects
+	units, err := lookupSelectorOption(textSelectorUnits, "units", spec.Selector.Units)
+	if err != nil {
+		return nil, err
+	}
+	basedOn, err := lookupSelectorOption(textSelectorBasedOn, "basedOn", spec.Selector.BasedOn)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := lookupSelectorOption(textSelectorModes, "mode", spec.Selector.Mode)
+	if err != nil {
+		return nil, err
+	}
+	shape, err := lookupSelectorOption(textSelectorShapes, "shape", spec.Selector.Shape)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range spec.Selector.Keyframes {
+		if key.Property != "start" && key.Property != "end" && key.Property != "offset" {
+			return nil, fmt.Errorf("invalid selector keyframe property: %s. Must be one of: start, end, offset", key.Property)
+		}
+	}
+	if spec.Name == "" {
+		spec.Name = "Animator"
+	}
+
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize text animator: %w", err)
+	}
+
+	script := `
+	try {
+		` + keyframeJS + `
+		var compName = "` + compName + `";
+		var layerName = "` + layerName + `";
+		var spec = ` + string(specJSON) + `;
+		var fromInPoint = ` + fmt.Sprintf("%t", fromInPoint) + `;
+		var popups = {
+			units: ` + fmt.Sprintf("%d", units) + `,
+			basedOn: ` + fmt.Sprintf("%d", basedOn) + `,
+			mode: ` + fmt.Sprintf("%d", mode) + `,
+			shape: ` + fmt.Sprintf("%d", shape) + `
+		};
+
+		// Find the composition
+		var comp = null;
+		for (var i = 1; i <= app.project.numItems; i++) {
+			var item = app.project.item(i);
+			if (item instanceof CompItem && item.name === compName) {
+				comp = item;
+				break;
+			}
+		}
+
+		if (!comp) {
+			return JSON.stringify({
+				error: "Composition not found: " + compName
+			});
+		}
+
+		// Find the text layer
+		var textLayer = null;
+		for (var i = 1; i <= comp.numLayers; i++) {
+			if (comp.layer(i).name === layerName) {
+				textLayer = comp.layer(i);
+				break;
+			}
+		}
+
+		if (!textLayer) {
+			return JSON.stringify({
+				error: "Layer not found: " + layerName
+			});
+		}
+
+		if (!(textLayer instanceof TextLayer)) {
+			return JSON.stringify({
+				error: "Layer is not a text layer: " + layerName
+			});
+		}
+
+		// Add the animator
+		var animators = textLayer.property("` + TextSourceText + `").property("` + TextAnimators + `");
+		var animator = animators.addProperty("` + TextAnimator + `");
+		animator.name = spec.name;
+		var animatorProps = animator.property("` + TextAnimatorProperties + `");
+		var added = [];
+
+		function addAnimatorProperty(matchName, value) {
+			var prop = animatorProps.addProperty(matchName);
+			prop.setValue(value);
+			added.push(prop.name);
+			return prop;
+		}
+
+		function toVector3(value, fill) {
+			return [value[0], value.length > 1 ? value[1] : value[0], value.length > 2 ? value[2] : fill];
+		}
+
+		var props = spec.properties;
+		if (props.anchorPoint) addAnimatorProperty("ADBE Text Anchor Point 3D", toVector3(props.anchorPoint, 0));
+		if (props.position) addAnimatorProperty("ADBE Text Position 3D", toVector3(props.position, 0));
+		if (props.scale) addAnimatorProperty("ADBE Text Scale 3D", toVector3(props.scale, 100));
+		if (props.skew !== undefined) addAnimatorProperty("ADBE Text Skew", props.skew);
+		if (props.rotation !== undefined) addAnimatorProperty("ADBE Text Rotation", props.rotation);
+		if (props.opacity !== undefined) addAnimatorProperty("ADBE Text Opacity", props.opacity);
+		if (props.fillColor) addAnimatorProperty("ADBE Text Fill Color", [props.fillColor[0], props.fillColor[1], props.fillColor[2], 1]);
+		if (props.strokeColor) addAnimatorProperty("ADBE Text Stroke Color", [props.strokeColor[0], props.strokeColor[1], props.strokeColor[2], 1]);
+		if (props.strokeWidth !== undefined) addAnimatorProperty("ADBE Text Stroke Width", props.strokeWidth);
+		if (props.tracking !== undefined) addAnimatorProperty("ADBE Text Tracking Amount", props.tracking);
+		if (props.characterOffset !== undefined) addAnimatorProperty("ADBE Text Character Offset", props.characterOffset);
+		if (props.blur) addAnimatorProperty("ADBE Text Blur", [props.blur[0], props.blur.length > 1 ? props.blur[1] : props.blur[0]]);
+
+		// Configure the range selector
+		var selector = animator.property("` + TextSelectors + `").addProperty("` + TextRangeSelector + `");
+		var advanced = selector.property("` + TextRangeAdvanced + `");
+		var sel = spec.selector;
+
+		// Units and "based on" must be set before start/end/offset, which depend on them
+		if (popups.units) advanced.property("ADBE Text Range Units").setValue(popups.units);
+		if (popups.basedOn) advanced.property("ADBE Text Range Type2").setValue(popups.basedOn);
+		if (popups.mode) advanced.property("ADBE Text Selector Mode").setValue(popups.mode);
+		if (popups.shape) advanced.property("ADBE Text Range Shape").setValue(popups.shape);
+		if (sel.amount !== undefined) advanced.property("ADBE Text Selector Max Amount").setValue(sel.amount);
+		if (sel.smoothness !== undefined) advanced.property("ADBE Text Selector Smoothness").setValue(sel.smoothness);
+		if (sel.easeHigh !== undefined) advanced.property("ADBE Text Levels Max Ease").setValue(sel.easeHigh);
+		if (sel.easeLow !== undefined) advanced.property("ADBE Text Levels Min Ease").setValue(sel.easeLow);
+		if (sel.randomize !== undefined) advanced.property("ADBE Text Randomize Order").setValue(sel.randomize ? 1 : 0);
+		if (sel.randomSeed !== undefined) advanced.property("ADBE Text Random Seed").setValue(sel.randomSeed);
+
+		var useIndex = popups.units === 2;
+		var selectorProps = {
+			start: selector.property(useIndex ? "ADBE Text Index Start" : "ADBE Text Percent Start"),
+			end: selector.property(useIndex ? "ADBE Text Index End" : "ADBE Text Percent End"),
+			offset: selector.property(useIndex ? "ADBE Text Index Offset" : "ADBE Text Percent Offset")
+		};
+		if (sel.start !== undefined) selectorProps.start.setValue(sel.start);
+		if (sel.end !== undefined) selectorProps.end.setValue(sel.end);
+		if (sel.offset !== undefined) selectorProps.offset.setValue(sel.offset);
+
+		// Keyframe the selector
+		var keyframes = sel.keyframes || [];
+		var timeOffset = fromInPoint ? textLayer.inPoint : 0;
+		var selectorKeys = { start: [], end: [], offset: [] };
+		for (var i = 0; i < keyframes.length; i++) {
+			var key = keyframes[i];
+			selectorKeys[key.property].push({ time: timeOffset + key.time, value: key.value, ease: key.ease });
+		}
+		var keyframeErrors = [];
+		for (var keyProp in selectorKeys) {
+			if (selectorKeys[keyProp].length > 0) {
+				keyframeErrors = keyframeErrors.concat(setPropertyKeyframes(selectorProps[keyProp], selectorKeys[keyProp]).errors);
+			}
+		}
+
+		var result = {
+			name: textLayer.name,
+			index: textLayer.index,
+			animator: {
+				name: animator.name,
+				index: animator.propertyIndex,
+				properties: added,
+				keyframes: keyframes.length
+			}
+		};
+		if (keyframeErrors.length > 0) {
+			result.animator.keyframeErrors = keyframeErrors;
+		}
+
+		return returnjson(result);
+	} catch (err) {
+		return "ERROR: " + err.toString();
+	}
+	`
+
+	// Execute the script
+	result, err := ae.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract result
+	if resultStr, ok := result.(string); ok {
+		// Check if the result indicates an error
+		if len(resultStr) > 7 && resultStr[:7] == "ERROR: " {
+			return nil, ErrAEScriptError(resultStr[7:])
+		}
+
+		// Parse the JSON result into a structured object
+		var textLayerDetails TextLayerInfo
+		if err := json.Unmarshal([]byte(resultStr), &textLayerDetails); err != nil {
+			return nil, err
+		}
+
+		// Check for error in result
+		if errMsg, hasErr := textLayerDetails["error"].(string); hasErr {
+			return nil, fmt.Errorf("%s", errMsg)
+		}
+
+		return textLayerDetails, nil
+	}
+
+	return nil, ErrInvalidResponse
+}
+
+// ApplyTextAnimatorPreset adds one of the built-in text animator presets to a text layer.
+// The preset animation starts startTime seconds after the layer in point and lasts duration seconds.
+func ApplyTextAnimatorPreset(compName string, layerName string, presetName string, startTime float64, duration float64) (TextLayerInfo, error) {
+	spec, ok := TextAnimatorPresets()[strings.ToLower(presetName)]
+	if !ok {
+		names := make([]string, 0)
+		for name := range TextAnimatorPresets() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown text animator preset: %s. Must be one of: %s", presetName, strings.Join(names, ", "))
+	}
+
+	if duration <= 0 {
+		duration = 1
+	}
+
+	// Preset keyframes span [0, 1]; place them on the requested time range
+	keyframes := make([]TextSelectorKeyframe, len(spec.Selector.Keyframes))
+	for i, key := range spec.Selector.Keyframes {
+		key.Time = startTime + key.Time*duration
+		keyframes[i] = key
+	}
+	spec.Selector.Keyframes = keyframes
+
+	return addTextAnimator(compName, layerName, spec, true)
+}
+
+// MCP Function Definitions
+
+// MCPAddTextAnimator adds a text animator to a text layer via MCP
+func MCPAddTextAnimator(args map[string]interface{}) (interface{}, error) {
+	compName, ok := args["composition_name"].(string)
+	if !ok || compName == "" {
+		return nil, fmt.Errorf("composition_name is required")
+	}
+
+	layerName, ok := args["layer_name"].(string)
+	if !ok || layerName == "" {
+		return nil, fmt.Errorf("layer_name is required")
+	}
+
+	// Round-trip the loosely typed arguments through JSON into the animator spec
+	specJSON, err := json.Marshal(map[string]interface{}{
+		"name":       args["animator_name"],
+		"properties": args["properties"],
+		"selector":   args["selector"],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize text animator: %w", err)
+	}
+
+	var spec TextAnimatorSpec
+	if err := json.Unmarshal(specJSON, &spec); err != nil {
+		return nil, fmt.Errorf("invalid text animator parameters: %w", err)
+	}
+
+	return AddTextAnimator(compName, layerName, spec)
+}
@@ -0,0 +1,192 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TextAutoFit shrinks or wraps point text until it fits a target box
+type TextAutoFit struct {
+	Mode        string      `json:"mode,omitempty"`        // "shrink" (default), "wrap" or "wrapShrink"
+	Box         *[4]float64 `json:"box,omitempty"`         // Left, top, width, height in composition space; defaults to the title-safe area
+	MinFontSize float64     `json:"minFontSize,omitempty"` // Smallest font size shrinking may use, defaults to 8
+	KeepInside  *bool       `json:"keepInside,omitempty"`  // Move the layer into the box when it sticks out, defaults to true
+}
+
+// validTextAutoFitModes lists the supported auto-fit modes
+var validTextAutoFitModes = map[string]bool{
+	"shrink":     true,
+	"wrap":       true,
+	"wrapShrink": true,
+}
+
+// ParseTextAutoFit decodes auto-fit settings from a generic MCP argument map
+func ParseTextAutoFit(raw map[string]interface{}) (*TextAutoFit, error) {
+	var fit TextAutoFit
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize auto-fit settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &fit); err != nil {
+		return nil, fmt.Errorf("invalid auto-fit settings: %w", err)
+	}
+	return &fit, nil
+}
+
+// textAutoFitJS returns the ExtendScript that fits textLayer into the target box and stores
+// a report in autoFitResult. Shrinking binary-searches the font size; wrapping breaks lines
+// greedily at spaces by measuring each candidate line.
+func textAutoFitJS(fit *TextAutoFit) (string, error) {
+	if fit.Mode != "" && !validTextAutoFitModes[fit.Mode] {
+		return "", fmt.Errorf("invalid auto-fit mode %q (valid: shrink, wrap, wrapShrink): %w", fit.Mode, ErrInvalidParams)
+	}
+	if fit.Box != nil && (fit.Box[2] <= 0 || fit.Box[3] <= 0) {
+		return "", fmt.Errorf("auto-fit box must have a positive width and height: %w", ErrInvalidParams)
+	}
+
+	fitJSON, err := json.Marshal(fit)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize auto-fit settings: %w", err)
+	}
+
+	return layerBoundsJS + `
+		// Fit the text into the target box
+		var autoFitResult = (function(fit) {
+			var fitProp = textLayer.property("Source Text");
+			var fitDoc = fitProp.value;
+			if (fitDoc.boxText) {
+				warnings.push("Auto-fit applies to point text; paragraph text already wraps within its box");
+				return null;
+			}
+			if (fitProp.numKeys > 0) {
+				warnings.push("Auto-fit skipped: Source Text is keyframed");
+				return null;
+			}
+
+			var box = titleSafeBox(comp);
+			if (fit.box) {
+				box = {
+					left: fit.box[0],
+					top: fit.box[1],
+					right: fit.box[0] + fit.box[2],
+					bottom: fit.box[1] + fit.box[3],
+					width: fit.box[2],
+					height: fit.box[3]
+				};
+			}
+
+			var mode = fit.mode || "shrink";
+			var wrap = mode === "wrap" || mode === "wrapShrink";
+			var shrink = mode === "shrink" || mode === "wrapShrink";
+			var minFontSize = fit.minFontSize || 8;
+			var t = measureTimeForLayer(textLayer, comp.time);
+			var originalText = fitDoc.text;
+			var originalFontSize = fitDoc.fontSize;
+
+			function measure() {
+				return layerBounds(textLayer, t, false).compBounds;
+			}
+
+			function setText(text) {
+				fitDoc.text = text;
+				fitProp.setValue(fitDoc);
+			}
+
+			// Break each paragraph into lines no wider than the box
+			function wrapText() {
+				var paragraphs = originalText.split(/\r\n|\r|\n/);
+				var lines = [];
+				for (var p = 0; p < paragraphs.length; p++) {
+					var words = paragraphs[p].split(" ");
+					var line = "";
+					for (var w = 0; w < words.length; w++) {
+						var candidate = line === "" ? words[w] : line + " " + words[w];
+						setText(candidate);
+						if (line !== "" && measure().width > box.width) {
+							lines.push(line);
+							line = words[w];
+						} else {
+							line = candidate;
+						}
+					}
+					lines.push(line);
+				}
+				setText(lines.join("\r"));
+			}
+
+			// Lay the text out at a font size and report whether it fits
+			function layout(size) {
+				fitDoc.fontSize = size;
+				if (wrap) {
+					wrapText();
+				} else {
+					setText(originalText);
+				}
+				var b = measure();
+				return b.width <= box.width + 0.5 && b.height <= box.height + 0.5;
+			}
+
+			var fitted = layout(originalFontSize);
+			if (!fitted && shrink) {
+				var lo = Math.min(minFontSize, originalFontSize);
+				var hi = originalFontSize;
+				if (!layout(lo)) {
+					warnings.push("Text does not fit the target box even at font size " + lo);
+				} else {
+					for (var k = 0; k < 12 && hi - lo > 0.1; k++) {
+						var mid = (lo + hi) / 2;
+						if (layout(mid)) {
+							lo = mid;
+						} else {
+							hi = mid;
+						}
+					}
+					fitted = layout(Math.floor(lo * 10) / 10);
+				}
+			} else if (!fitted) {
+				warnings.push("Text still exceeds the target box after wrapping");
+			}
+
+			// Move the text into the box when it sticks out
+			var moved = false;
+			if (fit.keepInside !== false) {
+				var b = measure();
+				var dx = 0, dy = 0;
+				if (b.left < box.left) {
+					dx = box.left - b.left;
+				} else if (b.right > box.right) {
+					dx = Math.max(box.right - b.right, box.left - b.left);
+				}
+				if (b.top < box.top) {
+					dy = box.top - b.top;
+				} else if (b.bottom > box.bottom) {
+					dy = Math.max(box.bottom - b.bottom, box.top - b.top);
+				}
+				if (dx !== 0 || dy !== 0) {
+					var fitPosition = textLayer.property("ADBE Transform Group").property("ADBE Position");
+					if (textLayer.parent || fitPosition.dimensionsSeparated || fitPosition.numKeys > 0) {
+						warnings.push("Text extends outside the target box but its position is parented or animated; not moved");
+					} else {
+						var pos = fitPosition.value;
+						pos[0] += dx;
+						pos[1] += dy;
+						fitPosition.setValue(pos);
+						moved = true;
+					}
+				}
+			}
+
+			return {
+				mode: mode,
+				fitted: fitted,
+				fontSize: fitDoc.fontSize,
+				originalFontSize: originalFontSize,
+				wrapped: fitDoc.text !== originalText,
+				moved: moved,
+				box: box,
+				bounds: measure()
+			};
+		})(` + string(fitJSON) + `);
+		textDocument = textLayer.property("Source Text").value;
+`, nil
+}
@@ -0,0 +1,477 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// TextStyleRun styles a range of characters within a text layer.
+// The range is either Start/Length (in characters, as After Effects counts them)
+// or the Nth occurrence of Text.
+type TextStyleRun struct {
+	Start      int    `json:"start,omitempty"`
+	Length     *int   `json:"length,omitempty"`     // Defaults to the end of the text
+	Text       string `json:"text,omitempty"`       // Style an occurrence of this substring instead of Start/Length
+	Occurrence int    `json:"occurrence,omitempty"` // 1-based occurrence of Text, defaults to the first
+
+	FontName      string    `json:"fontName,omitempty"`
+	FontSize      *float64  `json:"fontSize,omitempty"`
+	FillColor     *ColorRGB `json:"fillColor,omitempty"`
+	StrokeColor   *ColorRGB `json:"strokeColor,omitempty"`
+	StrokeWidth   *float64  `json:"strokeWidth,omitempty"`
+	Tracking      *float64  `json:"tracking,omitempty"`
+	BaselineShift *float64  `json:"baselineShift,omitempty"`
+	FauxBold      *bool     `json:"fauxBold,omitempty"`
+	FauxItalic    *bool     `json:"fauxItalic,omitempty"`
+	AllCaps       *bool     `json:"allCaps,omitempty"`
+	SmallCaps     *bool     `json:"smallCaps,omitempty"`
+}
+
+// ParseTextStyleRuns decodes styled runs from a generic MCP argument list
+func ParseTextStyleRuns(raw []interface{}) ([]TextStyleRun, error) {
+	var runs []TextStyleRun
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize text styles: %w", err)
+	}
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("invalid text styles: %w", err)
+	}
+
+	for i, run := range runs {
+		if run.Start < 0 {
+			return nil, fmt.Errorf("text style %d: start must not be negative", i)
+		}
+		if run.Length != nil && *run.Length <= 0 {
+			return nil, fmt.Errorf("text style %d: length must be positive", i)
+		}
+		if run.Occurrence < 0 {
+			return nil, fmt.Errorf("text style %d: occurrence must be positive", i)
+		}
+	}
+
+	return runs, nil
+}
+
+// ParseTextMarkup converts inline markup into plain text and styled runs.
+//
+// Supported tags: <b>, <i>, <caps>, <smallcaps>, <font=PostScriptName>, <size=48>,
+// <color=#RRGGBB> or <color=r,g,b> (0-1), <stroke=#RRGGBB>, <tracking=50> and
+// <baseline=10>. Tags nest and are closed with </tag>; write \< for a literal "<".
+func ParseTextMarkup(markup string) (string, []TextStyleRun, error) {
+	type openTag struct {
+		name  string
+		style TextStyleRun
+	}
+
+	var plain strings.Builder
+	var runs []TextStyleRun
+	var stack []openTag
+	position := 0 // Current offset in UTF-16 code units, matching ExtendScript string indices
+	runStart := 0
+
+	// flush emits a run for the text written since the last tag change
+	flush := func() {
+		if len(stack) == 0 || position == runStart {
+			runStart = position
+			return
+		}
+		run := TextStyleRun{}
+		for _, tag := range stack {
+			mergeTextStyle(&run, tag.style)
+		}
+		run.Start = runStart
+		length := position - runStart
+		run.Length = &length
+		runs = append(runs, run)
+		runStart = position
+	}
+
+	for i := 0; i < len(markup); {
+		if strings.HasPrefix(markup[i:], `\<`) {
+			plain.WriteByte('<')
+			position++
+			i += 2
+			continue
+		}
+
+		if markup[i] != '<' {
+			r, size := utf8.DecodeRuneInString(markup[i:])
+			plain.WriteString(markup[i : i+size])
+			position += len(utf16.Encode([]rune{r}))
+			i += size
+			continue
+		}
+
+		end := strings.IndexByte(markup[i:], '>')
+		if end < 0 {
+			return "", nil, fmt.Errorf("unterminated tag at offset %d", i)
+		}
+		tag := markup[i+1 : i+end]
+		i += end + 1
+
+		if strings.HasPrefix(tag, "/") {
+			name := strings.ToLower(strings.TrimSpace(tag[1:]))
+			if len(stack) == 0 || stack[len(stack)-1].name != name {
+				return "", nil, fmt.Errorf("unexpected closing tag </%s>", name)
+			}
+			flush()
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		name, style, err := parseMarkupTag(tag)
+		if err != nil {
+			return "", nil, err
+		}
+		flush()
+		stack = append(stack, openTag{name: name, style: style})
+	}
+
+	if len(stack) > 0 {
+		return "", nil, fmt.Errorf("unclosed tag <%s>", stack[len(stack)-1].name)
+	}
+
+	return plain.String(), runs, nil
+}
+
+// parseMarkupTag parses the inside of an opening markup tag into a style
+func parseMarkupTag(tag string) (string, TextStyleRun, error) {
+	var style TextStyleRun
+	name, value := tag, ""
+	if eq := strings.IndexByte(tag, '='); eq >= 0 {
+		name, value = tag[:eq], strings.Trim(strings.TrimSpace(tag[eq+1:]), `"'`)
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	parseNumber := func() (*float64, error) {
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for <%s>: %q", name, value)
+		}
+		return &n, nil
+	}
+
+	var err error
+	switch name {
+	case "b":
+		style.FauxBold = boolPtr(true)
+	case "i":
+		style.FauxItalic = boolPtr(true)
+	case "caps":
+		style.AllCaps = boolPtr(true)
+	case "smallcaps":
+		style.SmallCaps = boolPtr(true)
+	case "font":
+		if value == "" {
+			return "", style, fmt.Errorf("<font> requires a font name")
+		}
+		style.FontName = value
+	case "size":
+		style.FontSize, err = parseNumber()
+	case "tracking":
+		style.Tracking, err = parseNumber()
+	case "baseline":
+		style.BaselineShift, err = parseNumber()
+	case "color":
+		style.FillColor, err = parseMarkupColor(value)
+	case "stroke":
+		style.StrokeColor, err = parseMarkupColor(value)
+	default:
+		return "", style, fmt.Errorf("unknown tag <%s>", name)
+	}
+	if err != nil {
+		return "", style, err
+	}
+
+	return name, style, nil
+}
+
+// parseMarkupColor parses #RRGGBB or comma-separated 0-1 components
+func parseMarkupColor(value string) (*ColorRGB, error) {
+	var color ColorRGB
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		if len(hex) != 6 {
+			return nil, fmt.Errorf("invalid color: %q", value)
+		}
+		for i := 0; i < 3; i++ {
+			n, err := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid color: %q", value)
+			}
+			color[i] = float64(n) / 255
+		}
+		return &color, nil
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid color: %q", value)
+	}
+	for i, part := range parts {
+		n, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color: %q", value)
+		}
+		color[i] = n
+	}
+	return &color, nil
+}
+
+// mergeTextStyle copies the style attributes set in src onto dst
+func mergeTextStyle(dst *TextStyleRun, src TextStyleRun) {
+	if src.FontName != "" {
+		dst.FontName = src.FontName
+	}
+	if src.FontSize != nil {
+		dst.FontSize = src.FontSize
+	}
+	if src.FillColor != nil {
+		dst.FillColor = src.FillColor
+	}
+	if src.StrokeColor != nil {
+		dst.StrokeColor = src.StrokeColor
+	}
+	if src.StrokeWidth != nil {
+		dst.StrokeWidth = src.StrokeWidth
+	}
+	if src.Tracking != nil {
+		dst.Tracking = src.Tracking
+	}
+	if src.BaselineShift != nil {
+		dst.BaselineShift = src.BaselineShift
+	}
+	if src.FauxBold != nil {
+		dst.FauxBold = src.FauxBold
+	}
+	if src.FauxItalic != nil {
+		dst.FauxItalic = src.FauxItalic
+	}
+	if src.AllCaps != nil {
+		dst.AllCaps = src.AllCaps
+	}
+	if src.SmallCaps != nil {
+		dst.SmallCaps = src.SmallCaps
+	}
+}
+
+// textStyleRunsJS returns the ExtendScript that styles character ranges of textDocument.
+// The first script runs before the text document is applied and uses
+// TextDocument.characterRange (After Effects 24.3+). The second runs afterwards and,
+// when character ranges are unavailable, splits the text into one layer per styled
+// segment, parented to the original layer, which is then disabled.
+func textStyleRunsJS(runs []TextStyleRun) (string, string, error) {
+	runsJSON, err := json.Marshal(runs)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to serialize text styles: %w", err)
+	}
+
+	applyJS := `
+		// Styled character runs
+		var styleRuns = ` + string(runsJSON) + `;
+		var styleMethod = null;
+		var styleSegmentLayers = [];
+
+		function applyTextStyle(target, style) {
+			if (style.fontName !== undefined) target.font = style.fontName;
+			if (style.fontSize !== undefined) target.fontSize = style.fontSize;
+			if (style.fillColor !== undefined) {
+				target.applyFill = true;
+				target.fillColor = style.fillColor;
+			}
+			if (style.strokeColor !== undefined) {
+				target.applyStroke = true;
+				target.strokeColor = style.strokeColor;
+			}
+			if (style.strokeWidth !== undefined) target.strokeWidth = style.strokeWidth;
+			if (style.tracking !== undefined) target.tracking = style.tracking;
+			if (style.baselineShift !== undefined) target.baselineShift = style.baselineShift;
+			if (style.fauxBold !== undefined) target.fauxBold = style.fauxBold;
+			if (style.fauxItalic !== undefined) target.fauxItalic = style.fauxItalic;
+			if (style.allCaps !== undefined) target.allCaps = style.allCaps;
+			if (style.smallCaps !== undefined) target.smallCaps = style.smallCaps;
+		}
+
+		// Resolve runs to [start, end) character ranges of the text
+		function resolveStyleRuns(text, runs) {
+			var resolved = [];
+			for (var r = 0; r < runs.length; r++) {
+				var run = runs[r];
+				var start, end;
+				if (run.text !== undefined) {
+					var occurrence = run.occurrence || 1;
+					var found = -1;
+					var from = 0;
+					for (var k = 0; k < occurrence; k++) {
+						found = text.indexOf(run.text, from);
+						if (found < 0) break;
+						from = found + 1;
+					}
+					if (found < 0) {
+						warnings.push("Styled text not found: " + run.text);
+						continue;
+					}
+					start = found;
+					end = found + run.text.length;
+				} else {
+					start = run.start || 0;
+					end = run.length !== undefined ? start + run.length : text.length;
+				}
+				end = Math.min(end, text.length);
+				if (start >= end) {
+					warnings.push("Styled range is outside the text: " + start + "-" + end);
+					continue;
+				}
+				resolved.push({ start: start, end: end, style: run });
+			}
+			return resolved;
+		}
+
+		var resolvedRuns = resolveStyleRuns(textDocument.text, styleRuns);
+		if (resolvedRuns.length > 0 && typeof textDocument.characterRange === "function") {
+			try {
+				for (var r = 0; r < resolvedRuns.length; r++) {
+					applyTextStyle(textDocument.characterRange(resolvedRuns[r].start, resolvedRuns[r].end), resolvedRuns[r].style);
+				}
+				styleMethod = "characterRange";
+				modified = true;
+			} catch (rangeErr) {
+				warnings.push("Character range styling failed, splitting into layers: " + rangeErr.toString());
+				textDocument = textProp.value;
+			}
+		}
+		`
+
+	fallbackJS := `
+		// Fall back to one layer per styled segment
+		if (resolvedRuns.length > 0 && styleMethod === null) {
+			var baseDoc = textProp.value;
+			var fullText = baseDoc.text;
+			var segmentComment = "ae-mcp style segment of " + textLayer.name;
+
+			// Remove segments from an earlier split of this layer
+			for (var i = comp.numLayers; i >= 1; i--) {
+				var existing = comp.layer(i);
+				if (existing.parent === textLayer && existing.comment === segmentComment) {
+					existing.remove();
+				}
+			}
+
+			// Cut the text at run boundaries and line breaks
+			var cutMap = {};
+			cutMap[0] = true;
+			cutMap[fullText.length] = true;
+			for (var r = 0; r < resolvedRuns.length; r++) {
+				cutMap[resolvedRuns[r].start] = true;
+				cutMap[resolvedRuns[r].end] = true;
+			}
+			for (var c = 0; c < fullText.length; c++) {
+				var ch = fullText.charAt(c);
+				if (ch === "\r" || ch === "\n") {
+					cutMap[c] = true;
+					cutMap[c + 1] = true;
+				}
+			}
+			var cuts = [];
+			for (var key in cutMap) {
+				cuts.push(parseInt(key, 10));
+			}
+			cuts.sort(function(a, b) { return a - b; });
+
+			var lines = [[]];
+			for (var c = 0; c < cuts.length - 1; c++) {
+				var segText = fullText.substring(cuts[c], cuts[c + 1]);
+				if (segText === "\n" && cuts[c] > 0 && fullText.charAt(cuts[c] - 1) === "\r") {
+					continue;
+				}
+				if (segText === "\r" || segText === "\n") {
+					lines.push([]);
+					continue;
+				}
+				var segStyle = {};
+				for (var r = 0; r < resolvedRuns.length; r++) {
+					if (resolvedRuns[r].start <= cuts[c] && cuts[c] < resolvedRuns[r].end) {
+						for (var prop in resolvedRuns[r].style) {
+							segStyle[prop] = resolvedRuns[r].style[prop];
+						}
+					}
+				}
+				lines[lines.length - 1].push({ text: segText, style: segStyle });
+			}
+
+			var copiedProps = ["font", "fontSize", "applyFill", "fillColor", "applyStroke", "strokeColor",
+				"strokeWidth", "tracking", "fauxBold", "fauxItalic", "allCaps", "smallCaps", "baselineShift"];
+
+			// Measure the advance width of a segment, including trailing spaces
+			function segmentAdvance(layer, doc, text) {
+				doc.text = text + "|";
+				layer.property("Source Text").setValue(doc);
+				var withBar = layer.sourceRectAtTime(comp.time, false);
+				doc.text = "|";
+				layer.property("Source Text").setValue(doc);
+				var bar = layer.sourceRectAtTime(comp.time, false);
+				doc.text = text;
+				layer.property("Source Text").setValue(doc);
+				return (withBar.left + withBar.width) - (bar.left + bar.width);
+			}
+
+			var leading = baseDoc.autoLeading ? baseDoc.fontSize * 1.2 : baseDoc.leading;
+			var segmentIndex = 0;
+			for (var l = 0; l < lines.length; l++) {
+				var lineLayers = [];
+				var lineWidth = 0;
+				for (var s = 0; s < lines[l].length; s++) {
+					var segment = lines[l][s];
+					var segLayer = comp.layers.addText(segment.text);
+					var segDoc = segLayer.property("Source Text").value;
+					for (var p = 0; p < copiedProps.length; p++) {
+						try {
+							segDoc[copiedProps[p]] = baseDoc[copiedProps[p]];
+						} catch (copyErr) {}
+					}
+					applyTextStyle(segDoc, segment.style);
+					segDoc.justification = ParagraphJustification.LEFT_JUSTIFY;
+					var advance = segmentAdvance(segLayer, segDoc, segment.text);
+
+					segmentIndex++;
+					segLayer.name = textLayer.name + " #" + segmentIndex;
+					segLayer.comment = segmentComment;
+					segLayer.threeDLayer = textLayer.threeDLayer;
+					segLayer.moveBefore(textLayer);
+					segLayer.startTime = textLayer.startTime;
+					segLayer.inPoint = textLayer.inPoint;
+					segLayer.outPoint = textLayer.outPoint;
+					segLayer.parent = textLayer;
+
+					lineLayers.push({ layer: segLayer, x: lineWidth });
+					lineWidth += advance;
+				}
+
+				// Lay the line out in the original layer's space, honoring its justification
+				var offset = 0;
+				if (baseDoc.justification === ParagraphJustification.CENTER_JUSTIFY) {
+					offset = -lineWidth / 2;
+				} else if (baseDoc.justification === ParagraphJustification.RIGHT_JUSTIFY) {
+					offset = -lineWidth;
+				}
+				for (var s = 0; s < lineLayers.length; s++) {
+					lineLayers[s].layer.property("ADBE Transform Group").property("ADBE Position").setValue([lineLayers[s].x + offset, l * leading, 0]);
+					styleSegmentLayers.push(lineLayers[s].layer.name);
+				}
+			}
+
+			if (baseDoc.boxText) {
+				warnings.push("Split segments are laid out as point text; paragraph wrapping is not preserved");
+			}
+			textLayer.enabled = false;
+			styleMethod = "splitLayers";
+		}
+		`
+
+	return applyJS, fallbackJS, nil
+}